@@ -124,12 +124,24 @@ func genconfig() {
 		LogLevel: "",
 	}
 	DB := &common.DBConfig{
-		LdbPath: "/data/storage/",
+		LdbPath:         "/data/storage/",
+		Storage:         "leveldb",
+		Cache:           "map",
+		CompactInterval: 0,
+		BlockPruneDepth: 0,
 	}
 	Snapshot := &common.SnapshotConfig{
 		Enable:   false,
 		FilePath: "",
+		Interval: 0,
+		Keep:     3,
 	}
+	StateSync := &common.StateSyncConfig{
+		Enable:   false,
+		MinPeers: 2,
+	}
+	Checkpoint := &common.CheckpointConfig{}
+	Upgrade := &common.UpgradeConfig{}
 	P2P := &common.P2PConfig{
 		ListenAddr:   "0.0.0.0:30000",
 		SeedNodes:    seedNodes,
@@ -178,16 +190,19 @@ func genconfig() {
 			Password: password,
 		}
 		c := &common.Config{
-			ACC:      ACC,
-			Genesis:  "/var/lib/iserver/",
-			VM:       VM,
-			DB:       DB,
-			Snapshot: Snapshot,
-			P2P:      P2P,
-			RPC:      RPC,
-			Log:      Log,
-			Metrics:  Metrics,
-			Debug:    Debug,
+			ACC:        ACC,
+			Genesis:    "/var/lib/iserver/",
+			VM:         VM,
+			DB:         DB,
+			Snapshot:   Snapshot,
+			StateSync:  StateSync,
+			Checkpoint: Checkpoint,
+			Upgrade:    Upgrade,
+			P2P:        P2P,
+			RPC:        RPC,
+			Log:        Log,
+			Metrics:    Metrics,
+			Debug:      Debug,
 		}
 
 		if i == 0 {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/backup"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/db/kv"
+)
+
+var backupCommand = &command{
+	name:  "backup",
+	usage: "Take a backup of the running node through its debug server",
+	run:   runBackup,
+}
+
+var restoreCommand = &command{
+	name:  "restore",
+	usage: "Restore the dbs of the stopped node from a backup",
+	run:   runRestore,
+}
+
+func runBackup(conf *common.Config, args []string) error {
+	fs := newFlagSet("backup", "backup [--out file | --dir dir]")
+	out := fs.StringP("out", "o", "", "Save the backup to the tar.gz `file`")
+	dir := fs.StringP("dir", "d", "", "Extract the backup to the `dir`, which must not exist")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if (*out == "") == (*dir == "") {
+		fs.Usage()
+		return fmt.Errorf("exactly one of --out and --dir is required")
+	}
+	target := *out
+	if target == "" {
+		target = *dir
+	}
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("%v already exists", target)
+	}
+
+	url, err := debugURL(conf, "/debug/backup/")
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(url, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("backup failed: %s", body)
+	}
+
+	tmp := target + ".tmp"
+	err = os.RemoveAll(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if *out != "" {
+		err = saveBackup(resp.Body, tmp)
+	} else {
+		err = backup.Extract(resp.Body, tmp)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *dir != "" {
+		m, err := backup.VerifyFiles(tmp)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Backup of block %v %v, %v files\n", m.Number, m.Hash, len(m.Files))
+	}
+	err = os.Rename(tmp, target)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Saved backup to %v\n", target)
+	return nil
+}
+
+func saveBackup(r io.Reader, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func runRestore(conf *common.Config, args []string) error {
+	fs := newFlagSet("restore", "restore file|dir")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("restore takes exactly one backup")
+	}
+	var chainID uint32
+	if conf.P2P != nil {
+		chainID = conf.P2P.ChainID
+	}
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	m, err := backup.Restore(fs.Arg(0), conf.DB.LdbPath, blockcache.WALDir(conf), storageType, chainID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored block %v %v taken at %v\n", m.Number, m.Hash, m.Time)
+	return nil
+}
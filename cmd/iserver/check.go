@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+)
+
+var checkCommand = &command{
+	name:  "check",
+	usage: "Cross-validate the blockchain db and the state db of the stopped node, and repair the indexes",
+	run:   runCheck,
+}
+
+// stateCheck is the result of checking the tag of the state db against the blockchain db.
+type stateCheck struct {
+	Tag    string `json:"tag"`
+	Number int64  `json:"number"`
+	// Behind is the number of the blocks after the state tag, which are dropped and synced again at start.
+	Behind   int64    `json:"behind"`
+	Digest   string   `json:"digest,omitempty"`
+	Problems []string `json:"problems"`
+}
+
+func runCheck(conf *common.Config, args []string) error {
+	fs := newFlagSet("check", "check [--from number] [--repair]")
+	from := fs.Int64("from", 0, "First block number to check, the tx total is checked only from 0")
+	repair := fs.Bool("repair", false, "Rewrite the missing or wrong index entries from the blocks")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+
+	chain, err := block.NewBlockChainWithStorage(conf.DB.LdbPath+"BlockChainDB", storageType)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+	cacheType, err := mvcc.ParseCacheType(conf.DB.Cache)
+	if err != nil {
+		return err
+	}
+	stateDB, err := db.NewMVCCDBWithStorage(conf.DB.LdbPath+"StateDB", storageType, cacheType)
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	result, err := chain.Check(*from, *repair)
+	if err != nil {
+		return err
+	}
+	state, err := checkState(chain, stateDB)
+	if err != nil {
+		return err
+	}
+	err = printJSON(struct {
+		Chain *block.CheckResult `json:"chain"`
+		State *stateCheck        `json:"state"`
+	}{result, state})
+	if err != nil {
+		return err
+	}
+
+	if n := result.Unrepaired(); n > 0 || len(state.Problems) > 0 {
+		if !*repair && n > 0 {
+			fmt.Println("Run with --repair to rewrite the index entries, the other problems need a resync or a restore")
+		}
+		return fmt.Errorf("%v problems of the blockchain db, %v of the state db", n, len(state.Problems))
+	}
+	fmt.Println("The blockchain db and the state db are consistent")
+	return nil
+}
+
+// checkState checks that the tag of the state db is a block on the chain, whose state digest is the one flushed.
+func checkState(chain block.Chain, stateDB db.MVCCDB) (*stateCheck, error) {
+	tag := stateDB.CurrentTag()
+	c := &stateCheck{
+		Tag:      common.Base58Encode([]byte(tag)),
+		Number:   -1,
+		Problems: make([]string, 0),
+	}
+	if tag == "" {
+		c.Problems = append(c.Problems, "state db has no tag")
+		return c, nil
+	}
+	blk, err := chain.GetBlockByHash([]byte(tag))
+	if err != nil {
+		c.Problems = append(c.Problems, fmt.Sprintf("block of the state tag isn't in the blockchain db: %v", err))
+		return c, nil
+	}
+	c.Number = blk.Head.Number
+	c.Behind = chain.Length() - 1 - c.Number
+	hash, err := chain.GetHashByNumber(c.Number)
+	if err != nil || !bytes.Equal(hash, []byte(tag)) {
+		c.Problems = append(c.Problems, fmt.Sprintf("block of the state tag isn't block %v of the chain", c.Number))
+	}
+	if c.Behind < 0 {
+		c.Problems = append(c.Problems, fmt.Sprintf("state db is %v blocks ahead of the blockchain db", -c.Behind))
+	}
+
+	digest, err := stateDB.Digest()
+	if err != nil {
+		return nil, err
+	}
+	c.Digest = common.Base58Encode(digest.State)
+	saved, err := chain.GetStateDigest([]byte(tag))
+	if err == nil && !bytes.Equal(saved, digest.Encode()) {
+		c.Problems = append(c.Problems, "state digest differs from the one saved with the block")
+	}
+	return c, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/iost-official/go-iost/common"
+	flag "github.com/spf13/pflag"
+)
+
+// command is a maintenance subcommand of iserver, it works on the data of the config without starting the node.
+type command struct {
+	name  string
+	usage string
+	run   func(conf *common.Config, args []string) error
+}
+
+var commands = []*command{
+	snapshotCommand,
+	pruneCommand,
+	genesisCommand,
+	replayCommand,
+	walCommand,
+	migrateCommand,
+	backupCommand,
+	restoreCommand,
+	checkCommand,
+}
+
+func findCommand(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func commandUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: iserver [options] [command [arguments]]\n\nOptions:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %v\n", c.name, c.usage)
+	}
+}
+
+// runCommand runs the subcommand in args, and exits the program.
+func runCommand(conf *common.Config, args []string) {
+	c := findCommand(args[0])
+	if c == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		commandUsage()
+		os.Exit(2)
+	}
+	err := c.run(conf, args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v: %v\n", c.name, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// newFlagSet returns the flag set of a subcommand.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: iserver %v\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// debugURL returns the url of the path on the debug server of the running node.
+func debugURL(conf *common.Config, path string) (string, error) {
+	if conf.Debug == nil {
+		return "", fmt.Errorf("debug server is disabled in the config")
+	}
+	host, port, err := net.SplitHostPort(conf.Debug.ListenAddr)
+	if err != nil {
+		return "", err
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, port) + path, nil
+}
+
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/genesis"
+	"github.com/iost-official/go-iost/db"
+	"gopkg.in/yaml.v2"
+)
+
+var genesisCommand = &command{
+	name:  "genesis",
+	usage: "Generate the genesis config of a new chain from a spec",
+	run:   runGenesis,
+}
+
+const genesisUsage = "genesis init --spec file [--out dir]"
+
+func runGenesis(conf *common.Config, args []string) error {
+	fs := newFlagSet("genesis", genesisUsage)
+	specFile := fs.StringP("spec", "s", "", "Genesis spec `file`")
+	out := fs.StringP("out", "o", "genesis", "Output `dir` of genesis.yml, keys.yml and the contracts")
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing genesis command")
+	}
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+	if args[0] != "init" {
+		fs.Usage()
+		return fmt.Errorf("unknown genesis command %q", args[0])
+	}
+	if *specFile == "" {
+		fs.Usage()
+		return fmt.Errorf("no genesis spec file")
+	}
+	return initGenesis(conf, *specFile, *out)
+}
+
+func initGenesis(conf *common.Config, specFile, out string) (err error) {
+	if _, err := os.Stat(filepath.Join(out, "genesis.yml")); err == nil {
+		return fmt.Errorf("genesis config already exists in %v", out)
+	}
+	spec, err := genesis.LoadSpec(specFile)
+	if err != nil {
+		return err
+	}
+	contractPath := filepath.Join(out, "contract")
+	gConf, keys, err := spec.GenesisConfig(contractPath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			os.RemoveAll(contractPath)
+		}
+	}()
+	// The default contracts come with the genesis of the config, the extra ones are relative to the spec.
+	err = copyDir(filepath.Join(conf.Genesis, "contract"), contractPath)
+	if err != nil {
+		return fmt.Errorf("copy default contracts failed: %v", err)
+	}
+	for _, c := range spec.ContractFiles {
+		file := c.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(specFile), file)
+		}
+		for _, f := range []string{file, file + ".abi"} {
+			err = copyFile(f, filepath.Join(contractPath, filepath.Base(f)), 0644)
+			if err != nil {
+				return fmt.Errorf("copy contract %v failed: %v", c.ID, err)
+			}
+		}
+	}
+
+	hash, err := dryRunGenesis(gConf)
+	if err != nil {
+		return fmt.Errorf("dry run genesis failed: %v", err)
+	}
+
+	// The contract path is decided by the genesis dir when the node starts.
+	fileConf := *gConf
+	fileConf.ContractPath = ""
+	b, err := yaml.Marshal(&fileConf)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(out, "genesis.yml"), b, 0644)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		b, err = yaml.Marshal(keys)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(filepath.Join(out, "keys.yml"), b, 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Generated genesis config in %v, %v keys generated\n", out, len(keys))
+	fmt.Printf("Genesis hash: %v\n", hash)
+	return nil
+}
+
+// dryRunGenesis generates the genesis block in a temporary db, and returns its hash.
+func dryRunGenesis(gConf *common.GenesisConfig) (string, error) {
+	dir, err := ioutil.TempDir("", "iost-genesis")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(filepath.Join(dir, "StateDB"))
+	if err != nil {
+		return "", err
+	}
+	defer stateDB.Close()
+	blk, err := genesis.GenGenesis(stateDB, gConf)
+	if err != nil {
+		return "", err
+	}
+	return common.Base58Encode(blk.HeadHash()), nil
+}
+
+func copyDir(src, dst string) error {
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(dst, 0755)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		err = copyFile(filepath.Join(src, f.Name()), filepath.Join(dst, f.Name()), f.Mode())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
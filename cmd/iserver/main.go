@@ -31,6 +31,8 @@ import (
 var (
 	configFile = flag.StringP("config", "f", "", "Configuration `file`")
 	help       = flag.BoolP("help", "h", false, "Display available options")
+	dev        = flag.Bool("dev", false, "Run a single-node developer chain with an ephemeral genesis")
+	devPeriod  = flag.Duration("dev-period", 0, "Block producing `interval` of the developer chain, 0 means producing blocks as soon as txs arrive")
 )
 
 func initMetrics(metricsConfig *common.MetricsConfig) error {
@@ -67,6 +69,8 @@ func initLogger(logConfig *common.LogConfig) {
 }
 
 func main() {
+	flag.CommandLine.SetInterspersed(false)
+	flag.Usage = commandUsage
 	flag.Parse()
 	if *help {
 		flag.Usage()
@@ -77,6 +81,14 @@ func main() {
 	}
 
 	conf := common.NewConfig(*configFile)
+	if flag.NArg() > 0 {
+		runCommand(conf, flag.Args())
+	}
+	if *dev {
+		if err := iserver.EnableDev(conf, *devPeriod); err != nil {
+			ilog.Fatalf("enable developer chain failed. err=%v", err)
+		}
+	}
 
 	global.SetGlobalConf(conf)
 
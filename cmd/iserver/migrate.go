@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/schema"
+)
+
+var migrateCommand = &command{
+	name:  "migrate",
+	usage: "Migrate the dbs of the stopped node to the schema versions of this iserver",
+	run:   runMigrate,
+}
+
+func runMigrate(conf *common.Config, args []string) error {
+	fs := newFlagSet("migrate", "migrate [--dry-run]")
+	dryRun := fs.Bool("dry-run", false, "Print the versions of the dbs and the migration steps without running them")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range []*schema.Schema{block.Schema, db.Schema} {
+		err := migrate(s, conf.DB.LdbPath+s.Name, storageType, *dryRun)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrate(s *schema.Schema, path string, storageType kv.StorageType, dryRun bool) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) && storageType != kv.MemoryStorage {
+		fmt.Printf("%v: not created, skipped\n", s.Name)
+		return nil
+	}
+	storage, err := kv.NewStorage(path, storageType)
+	if err != nil {
+		return fmt.Errorf("failed to open %v: %v", s.Name, err)
+	}
+	defer storage.Close()
+
+	version, _, err := s.Get(storage)
+	if err != nil {
+		return err
+	}
+	steps, err := s.Plan(version)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v: version %v, expected %v\n", s.Name, version, s.Version)
+	if dryRun {
+		for _, m := range steps {
+			fmt.Printf("  to version %v: %v\n", m.Version, m.Description)
+		}
+		return nil
+	}
+	_, err = s.Migrate(storage, func(m *schema.Migration) {
+		fmt.Printf("  migrating to version %v: %v\n", m.Version, m.Description)
+	})
+	if err != nil {
+		return err
+	}
+	if len(steps) > 0 {
+		fmt.Printf("%v: migrated to version %v\n", s.Name, s.Version)
+	}
+	return nil
+}
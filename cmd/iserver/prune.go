@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+)
+
+var pruneCommand = &command{
+	name:  "prune",
+	usage: "Rewrite the live state of the stopped node, or compact the state db of the running node",
+	run:   runPrune,
+}
+
+func runPrune(conf *common.Config, args []string) error {
+	fs := newFlagSet("prune", "prune [--online]")
+	online := fs.Bool("online", false, "Compact the state db of the running node through its debug server")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *online {
+		return compactOnline(conf)
+	}
+
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	result, err := db.Prune(conf.DB.LdbPath+"StateDB", storageType)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Pruned state db at tag %v, %v keys, %v -> %v bytes, reclaimed %v bytes\n",
+		common.Base58Encode([]byte(result.Tag)), result.Keys, result.Before, result.After, result.Reclaimed)
+	return nil
+}
+
+func compactOnline(conf *common.Config) error {
+	url, err := debugURL(conf, "/debug/statedb/compact/")
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(url, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("compact failed: %s", body)
+	}
+	fmt.Println(string(body))
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/replay"
+)
+
+var replayCommand = &command{
+	name:  "replay",
+	usage: "Re-execute the blocks of the stopped node and report the first divergent tx",
+	run:   runReplay,
+}
+
+func runReplay(conf *common.Config, args []string) error {
+	fs := newFlagSet("replay", "replay [--from number] [--to number] [--snapshot file]")
+	from := fs.Int64("from", 1, "First block to verify")
+	to := fs.Int64("to", 0, "Last block to verify, default is the top of the chain")
+	snap := fs.String("snapshot", "", "Snapshot `file` of the base state, default is the latest automatic snapshot before --from, or the genesis")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	result, err := replay.Run(conf, &replay.Options{
+		From:     *from,
+		To:       *to,
+		Snapshot: *snap,
+		Progress: func(number int64) {
+			if number%1000 == 0 {
+				fmt.Fprintf(os.Stderr, "replayed block %v\n", number)
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = printJSON(result)
+	if err != nil {
+		return err
+	}
+	if result.Divergence != nil {
+		return fmt.Errorf("block %v diverges: %v", result.Divergence.Number, result.Divergence.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/db/kv"
+)
+
+var snapshotCommand = &command{
+	name:  "snapshot",
+	usage: "Export, import or verify the state snapshot file",
+	run:   runSnapshot,
+}
+
+const snapshotUsage = "snapshot export [--out file] | import [--in file] | verify file"
+
+func runSnapshot(conf *common.Config, args []string) error {
+	fs := newFlagSet("snapshot", snapshotUsage)
+	out := fs.StringP("out", "o", filepath.Join(conf.DB.LdbPath, "Snapshot.iost"), "Snapshot `file` to export to")
+	in := fs.StringP("in", "i", "", "Snapshot `file` to import from, default is the snapshot file path of the config")
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing snapshot command")
+	}
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "export":
+		return exportSnapshot(conf, *out)
+	case "import":
+		if *in == "" && conf.Snapshot != nil {
+			*in = conf.Snapshot.FilePath
+		}
+		if *in == "" {
+			return fmt.Errorf("no snapshot file to import")
+		}
+		return importSnapshot(conf, *in)
+	case "verify":
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return fmt.Errorf("verify takes exactly one file")
+		}
+		return verifySnapshot(fs.Arg(0))
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown snapshot command %q", args[0])
+	}
+}
+
+func exportSnapshot(conf *common.Config, path string) error {
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	m, err := snapshot.Export(filepath.Join(conf.DB.LdbPath, "StateDB"), storageType, conf.P2P.ChainID, file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported the state of block %v to %v\n", m.Number, path)
+	return printJSON(m)
+}
+
+func importSnapshot(conf *common.Config, path string) error {
+	if _, err := os.Stat(filepath.Join(conf.DB.LdbPath, "BlockChainDB")); err == nil {
+		return fmt.Errorf("blockchain db already exists in %v", conf.DB.LdbPath)
+	}
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	m, err := snapshot.Import(file, filepath.Join(conf.DB.LdbPath, "StateDB"), storageType, conf.P2P.ChainID)
+	if err != nil {
+		return err
+	}
+	err = snapshot.MarkImported(conf.DB.LdbPath, m.Hash)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported the state of block %v to %v, start iserver with snapshot enabled to run from it\n", m.Number, conf.DB.LdbPath)
+	return printJSON(m)
+}
+
+func verifySnapshot(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	m, err := snapshot.Verify(file)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Snapshot %v is valid\n", path)
+	return printJSON(m)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/db/wal"
+)
+
+var walCommand = &command{
+	name:  "wal",
+	usage: "List, check, dump or truncate the block cache wal of the stopped node",
+	run:   runWAL,
+}
+
+const walUsage = "wal list | check | dump [--from index] [--limit n] | truncate [--dir dir]"
+
+var errDumpLimit = errors.New("dump limit reached")
+
+// walEntry is the summary of a block cache wal entry.
+type walEntry struct {
+	Index      uint64   `json:"index"`
+	Segment    string   `json:"segment"`
+	Type       string   `json:"type"`
+	Number     int64    `json:"number,omitempty"`
+	Hash       string   `json:"hash,omitempty"`
+	ParentHash string   `json:"parentHash,omitempty"`
+	Witness    string   `json:"witness,omitempty"`
+	Txs        int      `json:"txs,omitempty"`
+	SerialNum  int64    `json:"serialNum,omitempty"`
+	Active     []string `json:"active,omitempty"`
+	Pending    []string `json:"pending,omitempty"`
+	Err        string   `json:"error,omitempty"`
+}
+
+func runWAL(conf *common.Config, args []string) error {
+	fs := newFlagSet("wal", walUsage)
+	dir := fs.StringP("dir", "d", blockcache.WALDir(conf), "Wal `dir`, default is the block cache wal of the config")
+	from := fs.Uint64("from", 0, "First entry index to dump")
+	limit := fs.Int("limit", 0, "Max number of entries to dump, 0 means unlimited")
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing wal command")
+	}
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		segments, err := wal.Inspect(*dir, nil)
+		if err != nil {
+			return err
+		}
+		return printJSON(segments)
+	case "check":
+		return checkWAL(*dir)
+	case "dump":
+		return dumpWAL(*dir, *from, *limit)
+	case "truncate":
+		return truncateWAL(*dir)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown wal command %q", args[0])
+	}
+}
+
+func checkWAL(dir string) error {
+	segments, err := wal.Inspect(dir, func(segment string, e *wal.Entry) error {
+		_, err := blockcache.DecodeWALEntry(e.Data)
+		if err != nil {
+			return fmt.Errorf("entry %v in %v can't be decoded: %v", e.Index, segment, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = printJSON(segments)
+	if err != nil {
+		return err
+	}
+	for _, s := range segments {
+		if !s.Corrupted() {
+			continue
+		}
+		if s.Tail && s.Torn {
+			fmt.Printf("The last record of %v is torn at byte %v, it's dropped when the node recovers\n", s.Name, s.ValidBytes)
+			return nil
+		}
+		return fmt.Errorf("%v is corrupted at byte %v: %v, run `iserver wal truncate` to drop the records from it", s.Name, s.ValidBytes, s.Err)
+	}
+	return nil
+}
+
+func dumpWAL(dir string, from uint64, limit int) error {
+	count := 0
+	_, err := wal.Inspect(dir, func(segment string, e *wal.Entry) error {
+		if e.Index < from {
+			return nil
+		}
+		if limit > 0 && count >= limit {
+			return errDumpLimit
+		}
+		count++
+		b, err := json.Marshal(decodeWALEntry(segment, e))
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	})
+	if err == errDumpLimit {
+		return nil
+	}
+	return err
+}
+
+func decodeWALEntry(segment string, e *wal.Entry) *walEntry {
+	entry := &walEntry{
+		Index:   e.Index,
+		Segment: segment,
+	}
+	r, err := blockcache.DecodeWALEntry(e.Data)
+	if err != nil {
+		entry.Err = err.Error()
+		return entry
+	}
+	entry.Type = r.Type.String()
+	entry.Active = r.WitnessList.Active()
+	entry.Pending = r.WitnessList.Pending()
+	switch r.Type {
+	case blockcache.BcMessageType_LinkType:
+		entry.Number = r.Block.Head.Number
+		entry.Hash = common.Base58Encode(r.Block.HeadHash())
+		entry.ParentHash = common.Base58Encode(r.Block.Head.ParentHash)
+		entry.Witness = r.Block.Head.Witness
+		entry.Txs = len(r.Block.Txs)
+		entry.SerialNum = r.SerialNum
+	case blockcache.BcMessageType_UpdateActiveType:
+		entry.Hash = common.Base58Encode(r.BlockHash)
+	}
+	return entry
+}
+
+func truncateWAL(dir string) error {
+	s, err := wal.Truncate(dir)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		fmt.Println("The wal isn't corrupted, nothing is truncated")
+		return nil
+	}
+	fmt.Printf("Truncated %v at byte %v (%v), %v entries before it are kept\n", s.Name, s.ValidBytes, s.Err, s.Entries)
+	fmt.Printf("The dropped records are kept in the files with the %v suffix\n", wal.CorruptedSuffix)
+	return nil
+}
@@ -46,11 +46,33 @@ type GenesisConfig struct {
 	ContractPath     string
 	AdminInfo        *Witness
 	FoundationInfo   *Witness
+	// InitialAccounts are the accounts created in the genesis block with their initial balances.
+	InitialAccounts []*Witness
+	// Contracts are the extra contracts deployed in the genesis block, their code is in ContractPath.
+	Contracts []*GenesisContract
+}
+
+// GenesisContract is a contract deployed in the genesis block.
+type GenesisContract struct {
+	ID string
+	// File is the name of the code file in the contract path, its abi file is File + ".abi".
+	File string
 }
 
 // DBConfig config of the database
 type DBConfig struct {
 	LdbPath string
+	// Storage is the storage type of the dbs, leveldb, bbolt or memory, default is leveldb.
+	// The memory storage loses the data once iserver stops, it's refused outside the dev chains.
+	Storage string
+	// Cache is the cache type of the state writes not flushed yet, map, btree or trie, default is map.
+	Cache string
+	// CompactInterval is the interval of compacting the state db online, 0 disables it.
+	CompactInterval time.Duration
+	// BlockPruneDepth is the depth below the LIB from which the txs of the blocks are pruned, the heads and the
+	// receipts are kept, and the pruned blocks can't be synced from the node. 0 keeps all the txs, the depth is at
+	// least 1000.
+	BlockPruneDepth int64
 }
 
 // VMConfig config of the v8vm
@@ -116,6 +138,32 @@ type MetricsConfig struct {
 type SnapshotConfig struct {
 	Enable   bool
 	FilePath string
+	// Interval is the number of irreversible blocks between automatic snapshots, 0 disables them.
+	Interval int64
+	// Keep is the number of the latest automatic snapshots to keep.
+	Keep int
+}
+
+// StateSyncConfig is the config of syncing state from peers' snapshots.
+type StateSyncConfig struct {
+	// Enable makes a node with empty databases download a snapshot from peers instead of replaying from genesis.
+	// Only the snapshot of the block of a checkpoint in the checkpoint config is downloaded.
+	Enable bool
+	// MinPeers is the number of peers which must advertise the same snapshot before it's downloaded.
+	MinPeers int
+}
+
+// Checkpoint is the trusted hash of a block.
+type Checkpoint struct {
+	Number int64
+	Hash   string
+}
+
+// CheckpointConfig is the config of the trusted checkpoints, a chain not matching them is rejected.
+type CheckpointConfig struct {
+	// GenesisHash is the base58 hash of the genesis block, empty skips the check.
+	GenesisHash string
+	Blocks      []*Checkpoint
 }
 
 // DebugConfig is the config of debug.
@@ -123,6 +171,20 @@ type DebugConfig struct {
 	ListenAddr string
 }
 
+// DevConfig is the config of the single-node developer chain.
+type DevConfig struct {
+	Enable bool
+	// BlockInterval is the interval of producing blocks, zero means producing a block as soon as txs arrive.
+	BlockInterval time.Duration
+}
+
+// UpgradeConfig is the config of the protocol upgrades, it must be the same on all nodes of a chain.
+type UpgradeConfig struct {
+	// StateRootNumber is the number of the first block committing the state root, 0 disables it.
+	// The node builds the state tree in the background when it starts before the block.
+	StateRootNumber int64
+}
+
 // VersionConfig contrains netname(mainnet / testnet etc) and protocol info
 type VersionConfig struct {
 	NetName         string
@@ -131,17 +193,21 @@ type VersionConfig struct {
 
 // Config provide all configuration for the application
 type Config struct {
-	ACC      *ACCConfig
-	Genesis  string
-	VM       *VMConfig
-	DB       *DBConfig
-	Snapshot *SnapshotConfig
-	P2P      *P2PConfig
-	RPC      *RPCConfig
-	Log      *LogConfig
-	Metrics  *MetricsConfig
-	Debug    *DebugConfig
-	Version  *VersionConfig
+	ACC        *ACCConfig
+	Genesis    string
+	VM         *VMConfig
+	DB         *DBConfig
+	Snapshot   *SnapshotConfig
+	StateSync  *StateSyncConfig
+	Checkpoint *CheckpointConfig
+	P2P        *P2PConfig
+	RPC        *RPCConfig
+	Log        *LogConfig
+	Metrics    *MetricsConfig
+	Debug      *DebugConfig
+	Version    *VersionConfig
+	Upgrade    *UpgradeConfig
+	Dev        *DevConfig
 }
 
 // LoadYamlAsViper load yaml file as viper object
@@ -171,6 +237,11 @@ func NewConfig(configfile string) *Config {
 	return c
 }
 
+// IsDev returns whether the node runs as a single-node developer chain.
+func (c *Config) IsDev() bool {
+	return c.Dev != nil && c.Dev.Enable
+}
+
 // YamlString config to string
 func (c *Config) YamlString() string {
 	bs, err := yaml.Marshal(c)
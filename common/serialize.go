@@ -128,7 +128,7 @@ func (sd *SimpleDecoder) ParseBytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(sd.input) < int(length) {
+	if length < 0 || len(sd.input) < int(length) {
 		return nil, fmt.Errorf("bytes length too large: %v > %v", length, len(sd.input))
 	}
 	result := sd.input[:length]
@@ -0,0 +1,80 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/iost-official/go-iost/common"
+)
+
+const hashLength = 32
+
+// Checkpoints are the trusted hashes of the genesis and some blocks.
+// A chain forking before a checkpoint can't pass it, so a forged history is rejected at the checkpoint.
+// The nil Checkpoints accepts any block.
+type Checkpoints struct {
+	hashes  map[int64][]byte
+	numbers []int64
+}
+
+// New returns the Checkpoints of the config, it returns nil if there is no checkpoint.
+func New(conf *common.CheckpointConfig) (*Checkpoints, error) {
+	if conf == nil || (conf.GenesisHash == "" && len(conf.Blocks) == 0) {
+		return nil, nil
+	}
+	c := &Checkpoints{
+		hashes: make(map[int64][]byte),
+	}
+	if conf.GenesisHash != "" {
+		hash := common.Base58Decode(conf.GenesisHash)
+		if len(hash) != hashLength {
+			return nil, fmt.Errorf("invalid genesis hash %v", conf.GenesisHash)
+		}
+		c.hashes[0] = hash
+		c.numbers = append(c.numbers, 0)
+	}
+	for _, b := range conf.Blocks {
+		hash := common.Base58Decode(b.Hash)
+		if b.Number <= 0 || len(hash) != hashLength {
+			return nil, fmt.Errorf("invalid checkpoint %v: %v", b.Number, b.Hash)
+		}
+		if _, ok := c.hashes[b.Number]; ok {
+			return nil, fmt.Errorf("duplicated checkpoint %v", b.Number)
+		}
+		c.hashes[b.Number] = hash
+		c.numbers = append(c.numbers, b.Number)
+	}
+	sort.Slice(c.numbers, func(i, j int) bool { return c.numbers[i] < c.numbers[j] })
+	return c, nil
+}
+
+// Check checks the block of the number and the hash against the checkpoint of the number.
+func (c *Checkpoints) Check(number int64, hash []byte) error {
+	if c == nil {
+		return nil
+	}
+	expected, ok := c.hashes[number]
+	if !ok || bytes.Equal(expected, hash) {
+		return nil
+	}
+	return fmt.Errorf("block %v doesn't match the checkpoint, hash=%v, checkpoint=%v",
+		number, common.Base58Encode(hash), common.Base58Encode(expected))
+}
+
+// Trusted returns whether the block of the number and the hash is a checkpoint.
+func (c *Checkpoints) Trusted(number int64, hash []byte) bool {
+	if c == nil {
+		return false
+	}
+	expected, ok := c.hashes[number]
+	return ok && bytes.Equal(expected, hash)
+}
+
+// Numbers returns the numbers of the checkpoints in ascending order.
+func (c *Checkpoints) Numbers() []int64 {
+	if c == nil {
+		return nil
+	}
+	return c.numbers
+}
@@ -0,0 +1,46 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoints(t *testing.T) {
+	genesis := common.Sha3([]byte("genesis"))
+	hash := common.Sha3([]byte("block 100"))
+	c, err := New(&common.CheckpointConfig{
+		GenesisHash: common.Base58Encode(genesis),
+		Blocks: []*common.Checkpoint{
+			{Number: 200, Hash: common.Base58Encode(common.Sha3([]byte("block 200")))},
+			{Number: 100, Hash: common.Base58Encode(hash)},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{0, 100, 200}, c.Numbers())
+
+	assert.Nil(t, c.Check(0, genesis))
+	assert.NotNil(t, c.Check(0, hash))
+	assert.Nil(t, c.Check(100, hash))
+	assert.NotNil(t, c.Check(100, genesis))
+	assert.Nil(t, c.Check(101, genesis))
+	assert.True(t, c.Trusted(100, hash))
+	assert.False(t, c.Trusted(100, genesis))
+	assert.False(t, c.Trusted(101, genesis))
+
+	var empty *Checkpoints
+	assert.Nil(t, empty.Check(100, genesis))
+	assert.False(t, empty.Trusted(100, hash))
+	empty, err = New(&common.CheckpointConfig{})
+	assert.Nil(t, err)
+	assert.Nil(t, empty)
+
+	_, err = New(&common.CheckpointConfig{GenesisHash: "abc"})
+	assert.NotNil(t, err)
+	_, err = New(&common.CheckpointConfig{Blocks: []*common.Checkpoint{
+		{Number: 100, Hash: common.Base58Encode(hash)},
+		{Number: 100, Hash: common.Base58Encode(hash)},
+	}})
+	assert.NotNil(t, err)
+}
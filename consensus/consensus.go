@@ -22,6 +22,7 @@ const (
 type Consensus interface {
 	Start() error
 	Stop()
+	ProducerStats(witness string) []*pob.ProducerStat
 }
 
 // New returns the different consensus strategy.
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/vm/database"
 )
 
 var (
@@ -15,6 +17,8 @@ var (
 	errNumber     = errors.New("wrong number")
 	errTxHash     = errors.New("wrong txs hash")
 	errMerkleHash = errors.New("wrong tx receipt merkle hash")
+	errVersion    = errors.New("wrong block version")
+	errStateRoot  = errors.New("wrong state root")
 	// errTxReceipt  = errors.New("wrong tx receipt")
 
 	// TxExecTimeLimit the maximum verify execution time of a transaction
@@ -24,6 +28,9 @@ var (
 // VerifyBlockHead verifies the block head.
 func VerifyBlockHead(blk *block.Block, parentBlock *block.Block, lib *block.Block) error {
 	bh := blk.Head
+	if bh.Version != block.VersionOf(bh.Number) {
+		return errVersion
+	}
 	if bh.Time > time.Now().UnixNano() {
 		return errFutureBlk
 	}
@@ -45,3 +52,26 @@ func VerifyBlockHead(blk *block.Block, parentBlock *block.Block, lib *block.Bloc
 
 	return nil
 }
+
+// StateRoot updates the state tree with the state written by the txs of the block executed on db, and returns
+// the state root committed in the block head, nil if the version of the block doesn't commit it. The first block
+// committing it takes the tree prepared by the node in the background, or builds it from the whole state if
+// it isn't prepared in time.
+func StateRoot(blk, parent *block.Block, db db.MVCCDB) ([]byte, error) {
+	if blk.Head.Version < block.V1 {
+		return nil, nil
+	}
+	return db.UpdateStateTree(database.StateTable, parent.Head.Version < block.V1)
+}
+
+// VerifyStateRoot verifies the state root in the block head against the state after the txs of the block are executed on db.
+func VerifyStateRoot(blk, parent *block.Block, db db.MVCCDB) error {
+	root, err := StateRoot(blk, parent, db)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, blk.Head.StateRoot) {
+		return errStateRoot
+	}
+	return nil
+}
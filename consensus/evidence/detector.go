@@ -0,0 +1,102 @@
+package evidence
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+
+	"github.com/iost-official/go-iost/core/block"
+)
+
+// Detector remembers the recent block heads of every witness and finds the conflicting ones.
+type Detector struct {
+	heads map[string]*block.Block
+	slots map[string][]*block.Block
+	mu    sync.Mutex
+}
+
+// NewDetector returns a Detector instance.
+func NewDetector() *Detector {
+	return &Detector{
+		heads: make(map[string]*block.Block),
+		slots: make(map[string][]*block.Block),
+	}
+}
+
+func headKey(witness string, number int64) string {
+	return witness + "/" + strconv.FormatInt(number, 10)
+}
+
+func slotKey(witness string, slot int64) string {
+	return witness + "/" + strconv.FormatInt(slot, 10)
+}
+
+// Check records the block and returns the evidence if its witness has signed another block
+// at the same height, or a conflicting block in the same slot. The block should have a verified signature.
+func (d *Detector) Check(blk *block.Block) *Evidence {
+	head := &block.Block{Head: blk.Head, Sign: blk.Sign}
+	if err := head.CalculateHeadHash(); err != nil {
+		return nil
+	}
+	d.mu.Lock()
+	other := d.check(head)
+	d.mu.Unlock()
+	if other == nil {
+		return nil
+	}
+	e, err := New(other, head)
+	if err != nil {
+		return nil
+	}
+	return e
+}
+
+func (d *Detector) check(head *block.Block) *block.Block {
+	// The same height is checked apart from the slot, as a witness may sign a block
+	// at a height it has signed in an earlier slot.
+	var other *block.Block
+	key := headKey(head.Head.Witness, head.Head.Number)
+	if b, ok := d.heads[key]; !ok {
+		d.heads[key] = head
+	} else if !bytes.Equal(b.HeadHash(), head.HeadHash()) {
+		other = b
+	}
+
+	key = slotKey(head.Head.Witness, slotOf(head.Head))
+	known := false
+	for _, b := range d.slots[key] {
+		if bytes.Equal(b.HeadHash(), head.HeadHash()) {
+			known = true
+		} else if other == nil && conflict(b, head) == nil {
+			other = b
+		}
+	}
+	if !known {
+		d.slots[key] = append(d.slots[key], head)
+	}
+	return other
+}
+
+// Prune forgets the block heads below the number.
+func (d *Detector) Prune(number int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for k, blk := range d.heads {
+		if blk.Head.Number < number {
+			delete(d.heads, k)
+		}
+	}
+	for k, blks := range d.slots {
+		kept := blks[:0]
+		for _, blk := range blks {
+			if blk.Head.Number >= number {
+				kept = append(kept, blk)
+			}
+		}
+		if len(kept) == 0 {
+			delete(d.slots, k)
+		} else {
+			d.slots[k] = kept
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package evidence
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+)
+
+var (
+	errIncomplete = errors.New("evidence is incomplete")
+	errWitness    = errors.New("blocks are signed by different witnesses")
+	errNumber     = errors.New("blocks are at different heights")
+	errSlot       = errors.New("blocks are at different heights in different slots")
+	errLinked     = errors.New("blocks are on the same chain")
+	errSameBlock  = errors.New("blocks are the same")
+	errSignature  = errors.New("wrong block signature")
+)
+
+// Evidence proves that a witness has signed two different block heads at the same height,
+// or two blocks at adjacent heights in the same slot with the higher one not on the lower one.
+//
+// A witness produces its blocks of a slot one after another on the same chain, so
+// the second case catches a witness which forks its own chain in a slot.
+type Evidence struct {
+	A *block.Block
+	B *block.Block
+}
+
+// New returns the evidence made of the heads and signatures of a and b.
+func New(a, b *block.Block) (*Evidence, error) {
+	if a == nil || b == nil || a.Head == nil || b.Head == nil {
+		return nil, errIncomplete
+	}
+	a = &block.Block{Head: a.Head, Sign: a.Sign}
+	b = &block.Block{Head: b.Head, Sign: b.Sign}
+	if err := a.CalculateHeadHash(); err != nil {
+		return nil, err
+	}
+	if err := b.CalculateHeadHash(); err != nil {
+		return nil, err
+	}
+	// Keep the order of the two blocks stable, so the same evidence has the same hash.
+	if bytes.Compare(a.HeadHash(), b.HeadHash()) > 0 {
+		a, b = b, a
+	}
+	e := &Evidence{A: a, B: b}
+	if err := e.Verify(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Witness returns the witness who signed the two blocks.
+func (e *Evidence) Witness() string {
+	return e.A.Head.Witness
+}
+
+// Number returns the height of the lower block.
+func (e *Evidence) Number() int64 {
+	if e.B.Head.Number < e.A.Head.Number {
+		return e.B.Head.Number
+	}
+	return e.A.Head.Number
+}
+
+// Hash returns the hash of the evidence.
+func (e *Evidence) Hash() []byte {
+	return common.Sha3(append(append([]byte{}, e.A.HeadHash()...), e.B.HeadHash()...))
+}
+
+// Verify checks that the two blocks are different, conflicting and both signed by the witness.
+func (e *Evidence) Verify() error {
+	if e.A == nil || e.B == nil || e.A.Head == nil || e.B.Head == nil || e.A.Sign == nil || e.B.Sign == nil {
+		return errIncomplete
+	}
+	if e.A.Head.Witness != e.B.Head.Witness {
+		return errWitness
+	}
+	if bytes.Equal(e.A.HeadHash(), e.B.HeadHash()) {
+		return errSameBlock
+	}
+	if err := conflict(e.A, e.B); err != nil {
+		return err
+	}
+	pubkey := account.DecodePubkey(e.A.Head.Witness)
+	for _, blk := range []*block.Block{e.A, e.B} {
+		sign := *blk.Sign
+		sign.SetPubkey(pubkey)
+		if !sign.Verify(blk.HeadHash()) {
+			return errSignature
+		}
+	}
+	return nil
+}
+
+func slotOf(head *block.BlockHead) int64 {
+	return head.Time / (1e9 * common.SlotLength)
+}
+
+// conflict returns nil if the two different blocks of a witness can't be both produced by it honestly.
+func conflict(a, b *block.Block) error {
+	if a.Head.Number == b.Head.Number {
+		return nil
+	}
+	if slotOf(a.Head) != slotOf(b.Head) {
+		return errSlot
+	}
+	if a.Head.Number > b.Head.Number {
+		a, b = b, a
+	}
+	if b.Head.Number != a.Head.Number+1 {
+		return errNumber
+	}
+	if bytes.Equal(b.Head.ParentHash, a.HeadHash()) {
+		return errLinked
+	}
+	return nil
+}
+
+// Encode returns the bytes of the evidence.
+func (e *Evidence) Encode() ([]byte, error) {
+	se := common.NewSimpleEncoder()
+	for _, blk := range []*block.Block{e.A, e.B} {
+		b, err := blk.Encode()
+		if err != nil {
+			return nil, err
+		}
+		se.WriteBytes(b)
+	}
+	return se.Bytes(), nil
+}
+
+// Decode decodes the evidence from bytes.
+func (e *Evidence) Decode(b []byte) error {
+	sd := common.NewSimpleDecoder(b)
+	blks := make([]*block.Block, 2)
+	for i := range blks {
+		bb, err := sd.ParseBytes()
+		if err != nil {
+			return fmt.Errorf("fail to decode evidence: %v", err)
+		}
+		blks[i] = &block.Block{}
+		if err := blks[i].Decode(bb); err != nil {
+			return err
+		}
+	}
+	e.A, e.B = blks[0], blks[1]
+	return nil
+}
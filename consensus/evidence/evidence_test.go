@@ -0,0 +1,155 @@
+package evidence
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func signedBlock(t *testing.T, acc *account.KeyPair, number int64, info string) *block.Block {
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			Number:  number,
+			Witness: acc.ReadablePubkey(),
+			Time:    number * 1e9,
+			Info:    []byte(info),
+		},
+	}
+	assert.Nil(t, blk.CalculateHeadHash())
+	blk.Sign = acc.Sign(blk.HeadHash())
+	return blk
+}
+
+// childBlock returns the next block of the witness on parent, half a second later.
+func childBlock(t *testing.T, acc *account.KeyPair, parent *block.Block, info string) *block.Block {
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			ParentHash: parent.HeadHash(),
+			Number:     parent.Head.Number + 1,
+			Witness:    acc.ReadablePubkey(),
+			Time:       parent.Head.Time + 5e8,
+			Info:       []byte(info),
+		},
+	}
+	assert.Nil(t, blk.CalculateHeadHash())
+	blk.Sign = acc.Sign(blk.HeadHash())
+	return blk
+}
+
+func TestEvidence(t *testing.T) {
+	acc, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	other, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+
+	a := signedBlock(t, acc, 10, "a")
+	b := signedBlock(t, acc, 10, "b")
+
+	e, err := New(a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, acc.ReadablePubkey(), e.Witness())
+	assert.Equal(t, int64(10), e.Number())
+
+	e2, err := New(b, a)
+	assert.Nil(t, err)
+	assert.Equal(t, e.Hash(), e2.Hash())
+
+	bs, err := e.Encode()
+	assert.Nil(t, err)
+	var decoded Evidence
+	assert.Nil(t, decoded.Decode(bs))
+	assert.Nil(t, decoded.Verify())
+	assert.Equal(t, e.Hash(), decoded.Hash())
+
+	_, err = New(a, a)
+	assert.Equal(t, errSameBlock, err)
+	_, err = New(a, signedBlock(t, acc, 12, "b"))
+	assert.Equal(t, errSlot, err)
+	_, err = New(a, childBlock(t, acc, a, "b"))
+	assert.Equal(t, errLinked, err)
+	_, err = New(a, childBlock(t, acc, childBlock(t, acc, b, "b"), "b"))
+	assert.Equal(t, errNumber, err)
+
+	// Block 11 is in the same slot as block 10 but not on it.
+	e, err = New(a, childBlock(t, acc, b, "b"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), e.Number())
+	_, err = New(a, signedBlock(t, other, 10, "b"))
+	assert.Equal(t, errWitness, err)
+
+	forged := signedBlock(t, acc, 10, "c")
+	forged.Sign = other.Sign(forged.HeadHash())
+	_, err = New(a, forged)
+	assert.Equal(t, errSignature, err)
+
+	assert.NotNil(t, decoded.Decode(bs[:len(bs)-1]))
+}
+
+func TestDetector(t *testing.T) {
+	acc, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	d := NewDetector()
+	a := signedBlock(t, acc, 10, "a")
+	assert.Nil(t, d.Check(a))
+	assert.Nil(t, d.Check(a))
+	assert.Nil(t, d.Check(childBlock(t, acc, a, "a")))
+	assert.Nil(t, d.Check(signedBlock(t, acc, 12, "a")))
+	assert.NotNil(t, d.Check(signedBlock(t, acc, 10, "b")))
+
+	d.Prune(12)
+	assert.Nil(t, d.Check(signedBlock(t, acc, 10, "c")))
+}
+
+func TestDetectorSlot(t *testing.T) {
+	acc, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	d := NewDetector()
+	a := signedBlock(t, acc, 10, "a")
+	b := signedBlock(t, acc, 9, "b")
+	assert.Nil(t, d.Check(a))
+	assert.Nil(t, d.Check(childBlock(t, acc, a, "a")))
+	// The witness forks its own chain in the slot, at a height it hasn't signed.
+	e := d.Check(childBlock(t, acc, childBlock(t, acc, childBlock(t, acc, b, "b"), "b"), "b"))
+	assert.NotNil(t, e)
+	assert.Nil(t, e.Verify())
+	assert.Equal(t, int64(11), e.Number())
+}
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "evidence")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	s, err := NewStore(dir)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	acc1, _ := account.NewKeyPair(nil, crypto.Ed25519)
+	acc2, _ := account.NewKeyPair(nil, crypto.Secp256k1)
+	e1, err := New(signedBlock(t, acc1, 1, "a"), signedBlock(t, acc1, 1, "b"))
+	assert.Nil(t, err)
+	e2, err := New(signedBlock(t, acc2, 2, "a"), signedBlock(t, acc2, 2, "b"))
+	assert.Nil(t, err)
+
+	added, err := s.Add(e1)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	added, err = s.Add(e1)
+	assert.Nil(t, err)
+	assert.False(t, added)
+	added, err = s.Add(e2)
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	all, err := s.List("")
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+	list, err := s.List(acc2.ReadablePubkey())
+	assert.Nil(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, e2.Hash(), list[0].Hash())
+}
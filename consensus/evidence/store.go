@@ -0,0 +1,71 @@
+package evidence
+
+import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/db/kv"
+)
+
+// Store keeps the evidences in a kv storage, indexed by witness.
+type Store struct {
+	storage *kv.Storage
+}
+
+// NewStore returns a Store instance at the path.
+func NewStore(path string) (*Store, error) {
+	return NewStoreWithStorage(path, kv.LevelDBStorage)
+}
+
+// NewStoreWithStorage returns a Store instance at the path on the storage of the type.
+func NewStoreWithStorage(path string, storageType kv.StorageType) (*Store, error) {
+	storage, err := kv.NewStorage(path, storageType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new storage: %v", err)
+	}
+	return &Store{storage: storage}, nil
+}
+
+func storeKey(e *Evidence) []byte {
+	return append([]byte(e.Witness()+"/"), e.Hash()...)
+}
+
+// Add stores the evidence, it returns false if the evidence is already stored.
+func (s *Store) Add(e *Evidence) (bool, error) {
+	key := storeKey(e)
+	ok, err := s.storage.Has(key)
+	if err != nil || ok {
+		return false, err
+	}
+	b, err := e.Encode()
+	if err != nil {
+		return false, err
+	}
+	if err := s.storage.Put(key, b); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns the evidences of the witness, or all the evidences if witness is empty.
+func (s *Store) List(witness string) ([]*Evidence, error) {
+	prefix := []byte{}
+	if witness != "" {
+		prefix = []byte(witness + "/")
+	}
+	iter := s.storage.NewIteratorByPrefix(prefix)
+	defer iter.Release()
+	evidences := make([]*Evidence, 0)
+	for iter.Next() {
+		e := &Evidence{}
+		if err := e.Decode(append([]byte{}, iter.Value()...)); err != nil {
+			return nil, err
+		}
+		evidences = append(evidences, e)
+	}
+	return evidences, iter.Error()
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	return s.storage.Close()
+}
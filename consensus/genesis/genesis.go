@@ -63,7 +63,10 @@ func genGenesisTx(gConf *common.GenesisConfig) (*tx.Tx, *account.Account, error)
 	}
 	acts = append(acts, tx.NewAction("system.iost", "initSetCode", fmt.Sprintf(`["%v", "%v"]`, "issue.iost", code.B64Encode())))
 	tokenInfo := gConf.TokenInfo
-	tokenHolder := append(witnessInfo, adminInfo)
+	tokenHolder := make([]*common.Witness, 0, len(witnessInfo)+len(gConf.InitialAccounts)+1)
+	tokenHolder = append(tokenHolder, witnessInfo...)
+	tokenHolder = append(tokenHolder, gConf.InitialAccounts...)
+	tokenHolder = append(tokenHolder, adminInfo)
 	params := []interface{}{
 		adminInfo.ID,
 		tokenInfo,
@@ -92,6 +95,9 @@ func genGenesisTx(gConf *common.GenesisConfig) (*tx.Tx, *account.Account, error)
 	for _, v := range witnessInfo {
 		acts = append(acts, tx.NewAction("auth.iost", "signUp", fmt.Sprintf(`["%v", "%v", "%v"]`, v.ID, v.Owner, v.Active)))
 	}
+	for _, v := range gConf.InitialAccounts {
+		acts = append(acts, tx.NewAction("auth.iost", "signUp", fmt.Sprintf(`["%v", "%v", "%v"]`, v.ID, v.Owner, v.Active)))
+	}
 	invalidPubKey := "0"
 	deadAccount := account.NewAccount("deadaddr")
 	acts = append(acts, tx.NewAction("auth.iost", "signUp", fmt.Sprintf(`["%v", "%v", "%v"]`, deadAccount.ID, invalidPubKey, invalidPubKey)))
@@ -163,11 +169,26 @@ func genGenesisTx(gConf *common.GenesisConfig) (*tx.Tx, *account.Account, error)
 	for _, v := range witnessInfo {
 		acts = append(acts, tx.NewAction("ram.iost", "buy", fmt.Sprintf(`["%v", "%v", %v]`, adminInfo.ID, v.ID, adminInitialRAM)))
 	}
+	for _, v := range gConf.InitialAccounts {
+		acts = append(acts, tx.NewAction("ram.iost", "buy", fmt.Sprintf(`["%v", "%v", %v]`, adminInfo.ID, v.ID, adminInitialRAM)))
+	}
 
 	acts = append(acts, tx.NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, adminInfo.ID, foundationInfo.ID, gasPledgeAmount)))
 	for _, v := range witnessInfo {
 		acts = append(acts, tx.NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, adminInfo.ID, v.ID, gasPledgeAmount)))
 	}
+	for _, v := range gConf.InitialAccounts {
+		acts = append(acts, tx.NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, adminInfo.ID, v.ID, gasPledgeAmount)))
+	}
+
+	// deploy the extra contracts
+	for _, c := range gConf.Contracts {
+		code, err = compile(c.ID, gConf.ContractPath, c.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		acts = append(acts, tx.NewAction("system.iost", "initSetCode", fmt.Sprintf(`["%v", "%v"]`, c.ID, code.B64Encode())))
+	}
 
 	trx := tx.NewTx(acts, nil, 1000000000, 100, 0, 0, tx.ChainID)
 	trx.Time = 0
@@ -0,0 +1,200 @@
+package genesis
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the compact description of a new chain, from which the full genesis config is generated.
+type Spec struct {
+	// InitialTimestamp is in RFC3339, default is now.
+	InitialTimestamp string `yaml:"initialtimestamp"`
+	// Algorithm is the algorithm of the generated keys, default is ed25519.
+	Algorithm     string         `yaml:"algorithm"`
+	Witnesses     []*SpecAccount `yaml:"witnesses"`
+	Admin         *SpecAccount   `yaml:"admin"`
+	Foundation    *SpecAccount   `yaml:"foundation"`
+	TotalSupply   int64          `yaml:"totalsupply"`
+	Decimal       int64          `yaml:"decimal"`
+	Accounts      []*SpecAccount `yaml:"accounts"`
+	ContractFiles []*SpecFile    `yaml:"contracts"`
+}
+
+// SpecAccount is an account of the spec, a key pair is generated if the pubkey is empty.
+// The foundation must have no balance, the genesis issues none to it.
+type SpecAccount struct {
+	ID      string `yaml:"id"`
+	Pubkey  string `yaml:"pubkey"`
+	Balance int64  `yaml:"balance"`
+}
+
+// SpecFile is an extra contract of the spec, the abi is at File + ".abi".
+type SpecFile struct {
+	ID   string `yaml:"id"`
+	File string `yaml:"file"`
+}
+
+// Key is a key pair generated for an account of the spec.
+type Key struct {
+	ID     string `yaml:"id"`
+	Pubkey string `yaml:"pubkey"`
+	Seckey string `yaml:"seckey"`
+}
+
+// LoadSpec reads the spec from the yaml file.
+func LoadSpec(path string) (*Spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	err = yaml.UnmarshalStrict(b, spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis spec: %v", err)
+	}
+	return spec, nil
+}
+
+func (s *Spec) validate() error {
+	if len(s.Witnesses) == 0 {
+		return fmt.Errorf("no witness in the spec")
+	}
+	if s.Admin == nil || s.Foundation == nil {
+		return fmt.Errorf("admin and foundation are required")
+	}
+	// The genesis issues no token to the foundation, it's paid by the issue contract later.
+	if s.Foundation.Balance != 0 {
+		return fmt.Errorf("foundation can't have an initial balance")
+	}
+	if s.TotalSupply <= 0 {
+		return fmt.Errorf("invalid total supply %v", s.TotalSupply)
+	}
+	if s.InitialTimestamp != "" {
+		if _, err := time.Parse(time.RFC3339, s.InitialTimestamp); err != nil {
+			return fmt.Errorf("invalid initial timestamp: %v", err)
+		}
+	}
+	ids := make(map[string]bool)
+	var total int64
+	accounts := append(append([]*SpecAccount{s.Admin, s.Foundation}, s.Witnesses...), s.Accounts...)
+	for _, a := range accounts {
+		if a.ID == "" {
+			return fmt.Errorf("account id is required")
+		}
+		if ids[a.ID] {
+			return fmt.Errorf("duplicated account %v", a.ID)
+		}
+		ids[a.ID] = true
+		if a.Balance < 0 {
+			return fmt.Errorf("negative balance of %v", a.ID)
+		}
+		total += a.Balance
+	}
+	if total > s.TotalSupply {
+		return fmt.Errorf("initial balances %v exceed the total supply %v", total, s.TotalSupply)
+	}
+	contracts := make(map[string]bool)
+	files := make(map[string]bool)
+	for _, c := range s.ContractFiles {
+		if c.ID == "" || c.File == "" {
+			return fmt.Errorf("contract id and file are required")
+		}
+		if contracts[c.ID] {
+			return fmt.Errorf("duplicated contract %v", c.ID)
+		}
+		contracts[c.ID] = true
+		// The contracts are copied to the contract path by their base names.
+		name := filepath.Base(c.File)
+		if files[name] {
+			return fmt.Errorf("duplicated contract file name %v", name)
+		}
+		files[name] = true
+	}
+	return nil
+}
+
+// GenesisConfig generates the genesis config of the spec, and the key pairs generated for the accounts.
+// The extra contracts are expected to be copied to contractPath by their base names.
+func (s *Spec) GenesisConfig(contractPath string) (*common.GenesisConfig, []*Key, error) {
+	err := s.validate()
+	if err != nil {
+		return nil, nil, err
+	}
+	algo := crypto.Ed25519
+	if s.Algorithm != "" {
+		algo = crypto.NewAlgorithm(s.Algorithm)
+		if algo.String() != s.Algorithm {
+			return nil, nil, fmt.Errorf("invalid algorithm %v", s.Algorithm)
+		}
+	}
+	timestamp := s.InitialTimestamp
+	if timestamp == "" {
+		timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+	decimal := s.Decimal
+	if decimal == 0 {
+		decimal = 8
+	}
+
+	var keys []*Key
+	witness := func(a *SpecAccount) (*common.Witness, error) {
+		pubkey := a.Pubkey
+		if pubkey == "" {
+			kp, err := account.NewKeyPair(nil, algo)
+			if err != nil {
+				return nil, err
+			}
+			pubkey = kp.ReadablePubkey()
+			keys = append(keys, &Key{ID: a.ID, Pubkey: pubkey, Seckey: common.Base58Encode(kp.Seckey)})
+		}
+		return &common.Witness{
+			ID:             a.ID,
+			Owner:          pubkey,
+			Active:         pubkey,
+			SignatureBlock: pubkey,
+			Balance:        a.Balance,
+		}, nil
+	}
+
+	conf := &common.GenesisConfig{
+		CreateGenesis:    true,
+		InitialTimestamp: timestamp,
+		TokenInfo: &common.TokenInfo{
+			FoundationAccount: s.Foundation.ID,
+			IOSTTotalSupply:   s.TotalSupply,
+			IOSTDecimal:       decimal,
+		},
+		ContractPath: contractPath,
+	}
+	for _, a := range s.Witnesses {
+		w, err := witness(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		conf.WitnessInfo = append(conf.WitnessInfo, w)
+	}
+	if conf.AdminInfo, err = witness(s.Admin); err != nil {
+		return nil, nil, err
+	}
+	if conf.FoundationInfo, err = witness(s.Foundation); err != nil {
+		return nil, nil, err
+	}
+	for _, a := range s.Accounts {
+		w, err := witness(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		conf.InitialAccounts = append(conf.InitialAccounts, w)
+	}
+	for _, c := range s.ContractFiles {
+		conf.Contracts = append(conf.Contracts, &common.GenesisContract{ID: c.ID, File: filepath.Base(c.File)})
+	}
+	return conf, keys, nil
+}
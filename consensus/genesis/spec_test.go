@@ -0,0 +1,77 @@
+package genesis
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+initialtimestamp: "2006-01-02T15:04:05Z"
+witnesses:
+  - id: producer000
+  - id: producer001
+    balance: 100
+admin:
+  id: admin
+  balance: 1000
+foundation:
+  id: foundation
+  pubkey: Gcv8c2tH8qZrUYnKdEEdTtASsxivic2834MQW6mgxqto
+totalsupply: 10000
+accounts:
+  - id: alice
+    balance: 500
+contracts:
+  - id: Contract1
+    file: contracts/hello.js
+`
+
+func TestSpec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spec.yml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testSpec), 0644))
+
+	spec, err := LoadSpec(path)
+	assert.Nil(t, err)
+	conf, keys, err := spec.GenesisConfig("contract")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(keys))
+	assert.Equal(t, 2, len(conf.WitnessInfo))
+	assert.Equal(t, keys[0].Pubkey, conf.WitnessInfo[0].SignatureBlock)
+	assert.Equal(t, int64(100), conf.WitnessInfo[1].Balance)
+	assert.Equal(t, "Gcv8c2tH8qZrUYnKdEEdTtASsxivic2834MQW6mgxqto", conf.FoundationInfo.Owner)
+	assert.Equal(t, "foundation", conf.TokenInfo.FoundationAccount)
+	assert.Equal(t, int64(8), conf.TokenInfo.IOSTDecimal)
+	assert.Equal(t, "alice", conf.InitialAccounts[0].ID)
+	assert.Equal(t, "hello.js", conf.Contracts[0].File)
+
+	spec.Accounts[0].Balance = 9000
+	_, _, err = spec.GenesisConfig("contract")
+	assert.NotNil(t, err)
+
+	spec.Accounts[0].Balance = 0
+	spec.Accounts[0].ID = "admin"
+	_, _, err = spec.GenesisConfig("contract")
+	assert.NotNil(t, err)
+
+	spec.Accounts[0].ID = "alice"
+	spec.Foundation.Balance = 1
+	_, _, err = spec.GenesisConfig("contract")
+	assert.NotNil(t, err)
+
+	spec.Foundation.Balance = 0
+	spec.ContractFiles = append(spec.ContractFiles, &SpecFile{ID: "Contract2", File: "other/hello.js"})
+	_, _, err = spec.GenesisConfig("contract")
+	assert.NotNil(t, err)
+
+	err = ioutil.WriteFile(path, []byte("witness: []\n"), 0644)
+	assert.Nil(t, err)
+	_, err = LoadSpec(path)
+	assert.NotNil(t, err)
+}
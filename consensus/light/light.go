@@ -0,0 +1,168 @@
+// Package light verifies block headers without the state, for the clients not running a node.
+package light
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db/smt"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+// PendingListKey is the state key of the pending witness list, which becomes active once the block of the state is
+// irreversible.
+const PendingListKey = database.BasicPrefix + "vote_producer.iost-pendingProducerList"
+
+var (
+	// ErrNotScheduled is returned when the witness of the header isn't scheduled by the witness lists proved.
+	ErrNotScheduled = errors.New("witness not scheduled")
+
+	errNoWitness  = errors.New("empty witness list")
+	errNoSign     = errors.New("header without signature")
+	errNumber     = errors.New("wrong number")
+	errParentHash = errors.New("wrong parent hash")
+	errTime       = errors.New("block time before the parent")
+	errSignature  = errors.New("wrong signature")
+	errStateRoot  = errors.New("header without state root")
+	errVersion    = errors.New("the light client only follows the blocks committing the state root, which are of V1")
+)
+
+// Client verifies the headers following a trusted header one by one, as pob verifies the block heads.
+// The witness list is the active one scheduling the witness of each slot. A new list is taken as
+// pending only if it's proved against the state root of a verified header, and it becomes active
+// once a header is scheduled by it but not by the active one. So it only follows the headers of V1, the changes of
+// the witness list can't be proved on V0.
+type Client struct {
+	head    *block.Block
+	active  []string
+	pending []string
+}
+
+// NewClient returns a Client trusting the header and the active witness list scheduling its children.
+// The header must be of V1.
+func NewClient(trusted *block.Block, witnesses []string) (*Client, error) {
+	if len(witnesses) == 0 {
+		return nil, errNoWitness
+	}
+	if trusted.Head.Version < block.V1 {
+		return nil, fmt.Errorf("%v, block %v is of V%v", errVersion, trusted.Head.Number, trusted.Head.Version)
+	}
+	return &Client{
+		head:   trusted,
+		active: witnesses,
+	}, nil
+}
+
+// Head returns the last verified header.
+func (c *Client) Head() *block.Block {
+	return c.head
+}
+
+// Witnesses returns the active witness list.
+func (c *Client) Witnesses() []string {
+	return c.active
+}
+
+// Pending returns the witness list to become active, nil if there is none.
+func (c *Client) Pending() []string {
+	return c.pending
+}
+
+// SetPending takes the pending witness list of the value, proved by the proof against the state root
+// of the last verified header.
+func (c *Client) SetPending(value string, proof *smt.Proof) error {
+	witnesses, err := ParseWitnessList(value)
+	if err != nil {
+		return err
+	}
+	err = VerifyState(c.head, PendingListKey, value, true, proof)
+	if err != nil {
+		return err
+	}
+	if common.StringSliceEqual(witnesses, c.active) {
+		c.pending = nil
+	} else {
+		c.pending = witnesses
+	}
+	return nil
+}
+
+// Verify verifies the header following the last verified one, and makes it the last verified one.
+// ErrNotScheduled is returned if the witness list changed to one not proved by SetPending.
+func (c *Client) Verify(header *block.Block) error {
+	head := header.Head
+	if head.Number != c.head.Head.Number+1 {
+		return fmt.Errorf("%v: %v, expected %v", errNumber, head.Number, c.head.Head.Number+1)
+	}
+	if head.Version < block.V1 {
+		return errVersion
+	}
+	if !bytes.Equal(head.ParentHash, c.head.HeadHash()) {
+		return errParentHash
+	}
+	if head.Time <= c.head.Head.Time {
+		return errTime
+	}
+
+	active := c.active
+	if witnessOfNanoSec(head.Time, active) != head.Witness {
+		if len(c.pending) == 0 || witnessOfNanoSec(head.Time, c.pending) != head.Witness {
+			return ErrNotScheduled
+		}
+		active = c.pending
+	}
+	if header.Sign == nil {
+		return errNoSign
+	}
+	sign := *header.Sign
+	sign.SetPubkey(account.DecodePubkey(head.Witness))
+	if !sign.Verify(header.HeadHash()) {
+		return errSignature
+	}
+
+	if !common.StringSliceEqual(active, c.active) {
+		c.active = active
+		c.pending = nil
+	}
+	c.head = header
+	return nil
+}
+
+// VerifyState verifies the value of the key in the state at the header by the proof against the state root of the
+// header, the header must be verified before. exists false verifies that the key isn't in the state.
+func VerifyState(header *block.Block, key, value string, exists bool, proof *smt.Proof) error {
+	if header.Head.Version < block.V1 {
+		return errStateRoot
+	}
+	if exists {
+		return smt.VerifyMembership(header.Head.StateRoot, []byte(key), []byte(value), proof)
+	}
+	return smt.VerifyNonMembership(header.Head.StateRoot, []byte(key), proof)
+}
+
+// ParseWitnessList parses the witness list from the state value of PendingListKey.
+func ParseWitnessList(value string) ([]string, error) {
+	s, ok := database.Unmarshal(value).(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid witness list %v", value)
+	}
+	witnesses := make([]string, 0)
+	err := json.Unmarshal([]byte(s), &witnesses)
+	if err != nil {
+		return nil, err
+	}
+	if len(witnesses) == 0 {
+		return nil, errNoWitness
+	}
+	return witnesses, nil
+}
+
+func witnessOfNanoSec(nanosec int64, witnessList []string) string {
+	slot := nanosec / 1e9 / common.SlotLength
+	return witnessList[slot%int64(len(witnessList))]
+}
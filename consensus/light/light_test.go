@@ -0,0 +1,159 @@
+package light
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db/smt"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/stretchr/testify/assert"
+)
+
+var slot = int64(common.SlotLength * 1e9)
+
+func newKeys(t *testing.T, n int) ([]*account.KeyPair, []string) {
+	keys := make([]*account.KeyPair, n)
+	witnesses := make([]string, n)
+	for i := range keys {
+		kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+		assert.Nil(t, err)
+		keys[i] = kp
+		witnesses[i] = kp.ReadablePubkey()
+	}
+	return keys, witnesses
+}
+
+func newHeader(parent *block.Block, kp *account.KeyPair, time int64) *block.Block {
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			Version:    block.V1,
+			ParentHash: parent.HeadHash(),
+			Number:     parent.Head.Number + 1,
+			Witness:    kp.ReadablePubkey(),
+			Time:       time,
+		},
+	}
+	blk.CalculateHeadHash()
+	blk.Sign = kp.Sign(blk.HeadHash())
+	return blk
+}
+
+func TestClient(t *testing.T) {
+	keys, witnesses := newKeys(t, 3)
+	trusted := &block.Block{Head: &block.BlockHead{Number: 10, Time: 30 * slot}}
+	trusted.CalculateHeadHash()
+	_, err := NewClient(trusted, witnesses)
+	assert.NotNil(t, err)
+	trusted = &block.Block{Head: &block.BlockHead{Version: block.V1, Number: 10, Time: 30 * slot}}
+	trusted.CalculateHeadHash()
+	c, err := NewClient(trusted, witnesses)
+	assert.Nil(t, err)
+
+	v0 := newHeader(trusted, keys[1], 31*slot)
+	v0.Head.Version = block.V0
+	v0.CalculateHeadHash()
+	v0.Sign = keys[1].Sign(v0.HeadHash())
+	assert.Equal(t, errVersion, c.Verify(v0))
+
+	// slot 31 is scheduled to witnesses[31%3]
+	blk := newHeader(trusted, keys[1], 31*slot)
+	assert.Nil(t, c.Verify(blk))
+	assert.Equal(t, blk, c.Head())
+
+	wrong := newHeader(blk, keys[0], 32*slot)
+	assert.NotNil(t, c.Verify(wrong))
+
+	forged := newHeader(blk, keys[2], 32*slot)
+	forged.Sign = keys[0].Sign(forged.HeadHash())
+	assert.NotNil(t, c.Verify(forged))
+
+	orphan := newHeader(trusted, keys[2], 32*slot)
+	assert.NotNil(t, c.Verify(orphan))
+
+	// a new witness list is taken only if it's proved against the state root of the verified header
+	nextKeys, newWitnesses := newKeys(t, 2)
+	b, err := json.Marshal(newWitnesses)
+	assert.Nil(t, err)
+	value := database.MustMarshal(string(b))
+	tree := smt.New(mapStore{})
+	assert.Nil(t, tree.Put([]byte(PendingListKey), []byte(value)))
+	root, err := tree.Root()
+	assert.Nil(t, err)
+	proof, err := tree.Prove([]byte(PendingListKey))
+	assert.Nil(t, err)
+
+	blk = newHeader(blk, keys[2], 32*slot)
+	assert.Nil(t, c.Verify(blk))
+	assert.NotNil(t, c.SetPending(value, proof))
+	assert.Nil(t, c.Pending())
+
+	blk = &block.Block{
+		Head: &block.BlockHead{
+			Version:    block.V1,
+			ParentHash: blk.HeadHash(),
+			Number:     blk.Head.Number + 1,
+			Witness:    keys[0].ReadablePubkey(),
+			Time:       33 * slot,
+			StateRoot:  root,
+		},
+	}
+	blk.CalculateHeadHash()
+	blk.Sign = keys[0].Sign(blk.HeadHash())
+	assert.Nil(t, c.Verify(blk))
+
+	// the list from the node isn't taken without the proof
+	next := newHeader(blk, nextKeys[0], 34*slot)
+	assert.Equal(t, ErrNotScheduled, c.Verify(next))
+	assert.NotNil(t, c.SetPending(database.MustMarshal(`["`+keys[0].ReadablePubkey()+`"]`), proof))
+	assert.Nil(t, c.Pending())
+
+	assert.Nil(t, c.SetPending(value, proof))
+	assert.Equal(t, newWitnesses, c.Pending())
+	assert.Equal(t, witnesses, c.Witnesses())
+	assert.Nil(t, c.Verify(next))
+	assert.Equal(t, newWitnesses, c.Witnesses())
+	assert.Nil(t, c.Pending())
+	assert.Equal(t, ErrNotScheduled, c.Verify(newHeader(next, keys[2], 35*slot)))
+}
+
+type mapStore map[string]string
+
+func (s mapStore) Get(key string) (string, error) {
+	return s[key], nil
+}
+
+func (s mapStore) Put(key string, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s mapStore) Del(key string) error {
+	delete(s, key)
+	return nil
+}
+
+func TestVerifyState(t *testing.T) {
+	tree := smt.New(mapStore{})
+	assert.Nil(t, tree.Put([]byte("b-token-a"), []byte("100")))
+	assert.Nil(t, tree.Put([]byte("b-token-b"), []byte("200")))
+	root, err := tree.Root()
+	assert.Nil(t, err)
+	header := &block.Block{Head: &block.BlockHead{Version: block.V1, Number: 10, StateRoot: root}}
+
+	proof, err := tree.Prove([]byte("b-token-a"))
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyState(header, "b-token-a", "100", true, proof))
+	assert.NotNil(t, VerifyState(header, "b-token-a", "1000", true, proof))
+
+	proof, err = tree.Prove([]byte("b-token-c"))
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyState(header, "b-token-c", "", false, proof))
+	assert.NotNil(t, VerifyState(header, "b-token-c", "1", true, proof))
+
+	header.Head.Version = block.V0
+	assert.Equal(t, errStateRoot, VerifyState(header, "b-token-c", "", false, proof))
+}
@@ -40,7 +40,7 @@ func generateBlock(
 	topBlock := head.Block
 	blk := &block.Block{
 		Head: &block.BlockHead{
-			Version:    0,
+			Version:    block.VersionOf(topBlock.Head.Number + 1),
 			ParentHash: topBlock.HeadHash(),
 			Info:       make([]byte, 0),
 			Number:     topBlock.Head.Number + 1,
@@ -72,6 +72,10 @@ func generateBlock(
 	}
 	blk.Head.TxMerkleHash = blk.CalculateTxMerkleHash()
 	blk.Head.TxReceiptMerkleHash = blk.CalculateTxReceiptMerkleHash()
+	blk.Head.StateRoot, err = cverifier.StateRoot(blk, topBlock, db)
+	if err != nil {
+		return nil, err
+	}
 	err = blk.CalculateHeadHash()
 	if err != nil {
 		return nil, err
@@ -108,6 +112,9 @@ func verifyBlock(blk, parent, lib *block.Block, witnessList *blockcache.WitnessL
 		return errWitness
 	}
 	ilog.Debugf("[pob] start to verify block if foundchain, number: %v, hash = %v, witness = %v", blk.Head.Number, common.Base58Encode(blk.HeadHash()), blk.Head.Witness[4:6])
+	if len(blk.Txs) > 1 {
+		tx.BatchVerifySigns(blk.Txs[1:])
+	}
 	blkTxSet := make(map[string]bool, len(blk.Txs))
 	for i, t := range blk.Txs {
 		if blkTxSet[string(t.Hash())] {
@@ -143,9 +150,13 @@ func verifyBlock(blk, parent, lib *block.Block, witnessList *blockcache.WitnessL
 		}
 	}
 	v := verifier.Verifier{}
-	return v.Verify(blk, parent, witnessList, db, &verifier.Config{
+	err = v.Verify(blk, parent, witnessList, db, &verifier.Config{
 		Mode:        0,
 		Timeout:     genBlockTime,
 		TxTimeLimit: common.MaxTxTimeLimit,
 	})
+	if err != nil {
+		return err
+	}
+	return cverifier.VerifyStateRoot(blk, parent, db)
 }
@@ -0,0 +1,54 @@
+package pob
+
+import (
+	"time"
+
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/ilog"
+)
+
+var devPollInterval = 50 * time.Millisecond
+
+// devLoop produces blocks for the single-node developer chain instead of scheduleLoop.
+//
+// With a zero interval a block is produced as soon as there are pending txs,
+// otherwise a block is produced on every interval.
+func (p *PoB) devLoop(interval time.Duration) {
+	defer p.wg.Done()
+	instant := interval == 0
+	if instant {
+		interval = devPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	ilog.Infof("start dev block producing, interval: %v, instant: %v", interval, instant)
+
+	// stuckSize is the pending size after a block which packed none of the pending txs,
+	// don't produce empty blocks again until the pending txs change.
+	stuckSize := 0
+	for {
+		select {
+		case <-ticker.C:
+			if p.baseVariable.Mode() != global.ModeNormal {
+				continue
+			}
+			pTx, head := p.txPool.PendingTx()
+			size := pTx.Size()
+			if instant && (size == 0 || size == stuckSize) {
+				continue
+			}
+			p.quitGenerateMode = make(chan struct{})
+			generateTxsNum = 0
+			p.gen(0, pTx, head)
+			close(p.quitGenerateMode)
+			metricsTxSize.Set(float64(generateTxsNum), nil)
+			if generateTxsNum == 0 {
+				stuckSize = size
+			} else {
+				stuckSize = 0
+			}
+		case <-p.exitSignal:
+			return
+		}
+	}
+}
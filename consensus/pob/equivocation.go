@@ -0,0 +1,62 @@
+package pob
+
+import (
+	"encoding/json"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/evidence"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/event"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/metrics"
+	"github.com/iost-official/go-iost/p2p"
+)
+
+var metricsEquivocationCount = metrics.NewCounter("iost_pob_equivocation", nil)
+
+// detectEquivocation checks whether the witness of blk has signed another block at the same height or a conflicting one in the same slot.
+func (p *PoB) detectEquivocation(blk *block.Block) {
+	e := p.detector.Check(blk)
+	if e == nil {
+		return
+	}
+	p.handleEvidence(e)
+}
+
+// handleEvidence verifies the evidence, stores it, gossips it to peers and posts an event if it's new.
+func (p *PoB) handleEvidence(e *evidence.Evidence) {
+	if err := e.Verify(); err != nil {
+		ilog.Warnf("invalid equivocation evidence. err=%v", err)
+		return
+	}
+	added, err := p.baseVariable.EvidenceDB().Add(e)
+	if err != nil {
+		ilog.Errorf("store equivocation evidence failed. err=%v", err)
+		return
+	}
+	if !added {
+		return
+	}
+	ilog.Warnf("witness %v produced two conflicting blocks from number %v: %v, %v", e.Witness(), e.Number(),
+		common.Base58Encode(e.A.HeadHash()), common.Base58Encode(e.B.HeadHash()))
+	metricsEquivocationCount.Add(1, nil)
+
+	b, err := e.Encode()
+	if err != nil {
+		ilog.Errorf("encode equivocation evidence failed. err=%v", err)
+		return
+	}
+	p.p2pService.Broadcast(b, p2p.Evidence, p2p.NormalMessage)
+
+	data, err := json.Marshal(map[string]interface{}{
+		"hash":    common.Base58Encode(e.Hash()),
+		"witness": e.Witness(),
+		"number":  e.Number(),
+		"blocks":  []string{common.Base58Encode(e.A.HeadHash()), common.Base58Encode(e.B.HeadHash())},
+	})
+	if err != nil {
+		ilog.Errorf("marshal equivocation event failed. err=%v", err)
+		return
+	}
+	event.GetCollector().Post(event.NewEvent(event.Equivocation, string(data)), nil)
+}
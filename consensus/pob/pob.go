@@ -8,6 +8,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/checkpoint"
+	"github.com/iost-official/go-iost/consensus/evidence"
 	"github.com/iost-official/go-iost/consensus/snapshot"
 	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
 	"github.com/iost-official/go-iost/core/block"
@@ -50,6 +52,8 @@ var (
 type verifyBlockMessage struct {
 	blk     *block.Block
 	p2pType p2p.MessageType
+	// received is the time in nanoseconds when the block is received, before it waits for the verification.
+	received int64
 }
 
 //PoB is a struct that handles the consensus logic.
@@ -68,9 +72,14 @@ type PoB struct {
 	chRecvBlock      chan p2p.IncomingMessage
 	chRecvBlockHash  chan p2p.IncomingMessage
 	chQueryBlock     chan p2p.IncomingMessage
+	chRecvEvidence   chan p2p.IncomingMessage
 	chVerifyBlock    chan *verifyBlockMessage
 	wg               *sync.WaitGroup
 	mu               *sync.RWMutex
+	dev              bool
+	detector         *evidence.Detector
+	stats            *producerStats
+	checkpoints      *checkpoint.Checkpoints
 }
 
 // New init a new PoB.
@@ -90,10 +99,19 @@ func New(account *account.KeyPair, baseVariable global.BaseVariable, blockCache
 		chRecvBlock:      p2pService.Register("consensus channel", p2p.NewBlock, p2p.SyncBlockResponse),
 		chRecvBlockHash:  p2pService.Register("consensus block head", p2p.NewBlockHash),
 		chQueryBlock:     p2pService.Register("consensus query block", p2p.NewBlockRequest),
+		chRecvEvidence:   p2pService.Register("consensus evidence", p2p.Evidence),
 		chVerifyBlock:    make(chan *verifyBlockMessage, 1024),
 		wg:               new(sync.WaitGroup),
 		mu:               new(sync.RWMutex),
+		dev:              baseVariable.Config().IsDev(),
+		detector:         evidence.NewDetector(),
+		stats:            newProducerStats(baseVariable.Config().DB.LdbPath + producerStatsFile),
 	}
+	checkpoints, err := checkpoint.New(baseVariable.Config().Checkpoint)
+	if err != nil {
+		ilog.Fatalf("invalid checkpoints, stop the program! err:%v", err)
+	}
+	p.checkpoints = checkpoints
 	continuousNum = baseVariable.Continuous()
 
 	p.recoverBlockcache()
@@ -122,7 +140,11 @@ func (p *PoB) Start() error {
 	go p.messageLoop()
 	go p.blockLoop()
 	go p.verifyLoop()
-	go p.scheduleLoop()
+	if p.dev {
+		go p.devLoop(p.baseVariable.Config().Dev.BlockInterval)
+	} else {
+		go p.scheduleLoop()
+	}
 	return nil
 }
 
@@ -130,6 +152,13 @@ func (p *PoB) Start() error {
 func (p *PoB) Stop() {
 	close(p.exitSignal)
 	p.wg.Wait()
+	p.stats.flush()
+}
+
+// ProducerStats returns the block producing statistics of the witness in the last 24 hours.
+// It returns the statistics of all witnesses if witness is empty.
+func (p *PoB) ProducerStats(witness string) []*ProducerStat {
+	return p.stats.stats(witness)
 }
 
 func (p *PoB) messageLoop() {
@@ -168,6 +197,17 @@ func (p *PoB) messageLoop() {
 				}
 				p.handleBlockQuery(&rh, incomingMessage.From())
 			}
+		case incomingMessage, ok := <-p.chRecvEvidence:
+			if !ok {
+				ilog.Infof("chRecvEvidence has closed")
+				return
+			}
+			var e evidence.Evidence
+			err := e.Decode(incomingMessage.Data())
+			if err != nil {
+				continue
+			}
+			p.handleEvidence(&e)
 		case <-p.exitSignal:
 			return
 		}
@@ -255,6 +295,9 @@ func (p *PoB) doVerifyBlock(vbm *verifyBlockMessage) {
 			p.blockReqMap.Store(string(blk.HeadHash()), nil)
 		}
 		err := p.handleRecvBlock(blk)
+		if err == errSingle || err == nil {
+			p.stats.onReceived(blk, vbm.received)
+		}
 		t2 := calculateTime(blk)
 		metricsTimeCost.Set(t2, nil)
 		if err == errSingle || err == nil {
@@ -306,7 +349,7 @@ func (p *PoB) blockLoop() {
 				ilog.Error("fail to decode block")
 				continue
 			}
-			p.chVerifyBlock <- &verifyBlockMessage{blk: &blk, p2pType: incomingMessage.Type()}
+			p.chVerifyBlock <- &verifyBlockMessage{blk: &blk, p2pType: incomingMessage.Type(), received: time.Now().UnixNano()}
 		case <-p.exitSignal:
 			return
 		}
@@ -410,6 +453,10 @@ func (p *PoB) RecoverBlock(blk *block.Block) error {
 	if err != nil {
 		return err
 	}
+	err = p.checkpoints.Check(blk.Head.Number, blk.HeadHash())
+	if err != nil {
+		return err
+	}
 	parent, err := p.blockCache.Find(blk.Head.ParentHash)
 	p.blockCache.Add(blk)
 	if err == nil && parent.Type == blockcache.Linked {
@@ -430,6 +477,11 @@ func (p *PoB) handleRecvBlock(blk *block.Block) error {
 	if err != nil {
 		return err
 	}
+	err = p.checkpoints.Check(blk.Head.Number, blk.HeadHash())
+	if err != nil {
+		return err
+	}
+	p.detectEquivocation(blk)
 	parent, err := p.blockCache.Find(blk.Head.ParentHash)
 	p.blockCache.Add(blk)
 	if err == nil && parent.Type == blockcache.Linked {
@@ -448,7 +500,8 @@ func (p *PoB) addExistingBlock(blk *block.Block, parentNode *blockcache.BlockCac
 		node.SerialNum = parentNode.SerialNum + 1
 	}
 
-	if node.SerialNum >= int64(p.baseVariable.Continuous()) {
+	// The developer chain has only one witness producing blocks at any rate.
+	if node.SerialNum >= int64(p.baseVariable.Continuous()) && !p.dev {
 		return errOutOfLimit
 	}
 	ok := p.verifyDB.Checkout(string(blk.HeadHash()))
@@ -469,7 +522,14 @@ func (p *PoB) addExistingBlock(blk *block.Block, parentNode *blockcache.BlockCac
 		p.verifyDB.Commit(string(blk.HeadHash()))
 	}
 	p.blockCache.Link(node)
+	p.stats.onLinked(blk)
+	lib := p.blockCache.LinkedRoot()
 	p.blockCache.UpdateLib(node)
+	if p.blockCache.LinkedRoot() != lib {
+		lib = p.blockCache.LinkedRoot()
+		p.stats.onLib(p.blockChain, lib.Block, lib.Active())
+	}
+	p.detector.Prune(lib.Head.Number)
 	// After UpdateLib, the block head active witness list will be right
 	// So AddLinkedNode need execute after UpdateLib
 	p.txPool.AddLinkedNode(node)
@@ -0,0 +1,265 @@
+package pob
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/metrics"
+)
+
+var (
+	metricsProducerBlockCount = metrics.NewCounter("iost_pob_producer_block", []string{"witness", "type"})
+
+	statsBucketLength  = time.Hour
+	statsBucketNum     = 24
+	statsSaveInterval  = time.Minute
+	statsMaxMissedSlot = int64(10000)
+	lateBlockTime      = 2 * subSlotTime
+	producerStatsFile  = "ProducerStats.json"
+)
+
+// ProducerStat is the block producing statistics of a witness.
+type ProducerStat struct {
+	Witness string `json:"witness"`
+	// Produced is the number of blocks which became irreversible.
+	Produced int64 `json:"produced"`
+	// Missed is the number of blocks the witness should have produced in its slots but didn't.
+	Missed int64 `json:"missed"`
+	// Late is the number of blocks received later than lateBlockTime after their timestamp.
+	Late int64 `json:"late"`
+	// Orphaned is the number of linked blocks left on a fork when the lib moved on.
+	Orphaned int64 `json:"orphaned"`
+}
+
+func (s *ProducerStat) add(o *ProducerStat) {
+	s.Produced += o.Produced
+	s.Missed += o.Missed
+	s.Late += o.Late
+	s.Orphaned += o.Orphaned
+}
+
+type statsBucket struct {
+	Start int64                    `json:"start"`
+	Stats map[string]*ProducerStat `json:"stats"`
+}
+
+type linkedBlock struct {
+	witness string
+	number  int64
+	time    int64
+	hash    []byte
+}
+
+// producerStats keeps the ProducerStat of every witness in a rolling window of hourly buckets,
+// and saves them to a file so they survive restarts.
+type producerStats struct {
+	buckets []*statsBucket
+	linked  map[string]*linkedBlock
+	// the slot of the last irreversible block, its witness and the number of its blocks in the slot
+	slot     int64
+	witness  string
+	count    int64
+	path     string
+	lastSave time.Time
+	mu       sync.Mutex
+}
+
+func newProducerStats(path string) *producerStats {
+	s := &producerStats{
+		buckets: make([]*statsBucket, 0),
+		linked:  make(map[string]*linkedBlock),
+		path:    path,
+	}
+	if path == "" {
+		return s
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			ilog.Warnf("read producer stats failed. err=%v", err)
+		}
+		return s
+	}
+	if err := json.Unmarshal(b, &s.buckets); err != nil {
+		ilog.Warnf("decode producer stats failed. err=%v", err)
+		s.buckets = make([]*statsBucket, 0)
+	}
+	return s
+}
+
+// bucket returns the bucket of now, the expired buckets are dropped. It should be called with lock.
+func (s *producerStats) bucket() *statsBucket {
+	start := time.Now().Truncate(statsBucketLength).UnixNano()
+	if len(s.buckets) == 0 || s.buckets[len(s.buckets)-1].Start != start {
+		s.buckets = append(s.buckets, &statsBucket{Start: start, Stats: make(map[string]*ProducerStat)})
+	}
+	windowStart := start - int64(statsBucketNum-1)*int64(statsBucketLength)
+	for len(s.buckets) > 0 && s.buckets[0].Start < windowStart {
+		s.buckets = s.buckets[1:]
+	}
+	return s.buckets[len(s.buckets)-1]
+}
+
+func (s *producerStats) record(witness string, typ string, n int64) {
+	if n <= 0 {
+		return
+	}
+	b := s.bucket()
+	stat, ok := b.Stats[witness]
+	if !ok {
+		stat = &ProducerStat{Witness: witness}
+		b.Stats[witness] = stat
+	}
+	switch typ {
+	case "produced":
+		stat.Produced += n
+	case "missed":
+		stat.Missed += n
+	case "late":
+		stat.Late += n
+	case "orphaned":
+		stat.Orphaned += n
+	}
+	metricsProducerBlockCount.Add(float64(n), map[string]string{"witness": witness, "type": typ})
+}
+
+// onLinked records a block linked to the block cache, it's counted when the lib passes its number.
+func (s *producerStats) onLinked(blk *block.Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linked[string(blk.HeadHash())] = &linkedBlock{
+		witness: blk.Head.Witness,
+		number:  blk.Head.Number,
+		time:    blk.Head.Time,
+		hash:    blk.HeadHash(),
+	}
+}
+
+// onReceived records the delay of a block received from the network at the time received in nanoseconds.
+// It's called after the block is verified, but the delay excludes the time waiting for the verification.
+func (s *producerStats) onReceived(blk *block.Block, received int64) {
+	if received-blk.Head.Time <= int64(lateBlockTime) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.record(blk.Head.Witness, "late", 1)
+}
+
+// onLib counts the linked blocks at or below the lib as produced or orphaned,
+// and the slots skipped by the irreversible chain as missed.
+func (s *producerStats) onLib(chain block.Chain, lib *block.Block, witnessList []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	produced := make([]*linkedBlock, 0)
+	for k, lb := range s.linked {
+		if lb.number > lib.Head.Number {
+			continue
+		}
+		delete(s.linked, k)
+		hash, err := chain.GetHashByNumber(lb.number)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(hash, lb.hash) {
+			produced = append(produced, lb)
+		} else {
+			s.record(lb.witness, "orphaned", 1)
+		}
+	}
+	sort.Slice(produced, func(i, j int) bool {
+		return produced[i].number < produced[j].number
+	})
+	for _, lb := range produced {
+		s.record(lb.witness, "produced", 1)
+		s.countSlot(lb, witnessList)
+	}
+
+	if time.Since(s.lastSave) >= statsSaveInterval {
+		s.save()
+	}
+}
+
+// countSlot counts the missed blocks between the previous irreversible block and lb. It should be called with lock.
+func (s *producerStats) countSlot(lb *linkedBlock, witnessList []string) {
+	slot := slotOfSec(lb.time / second2nanosecond)
+	if s.witness != "" && slot == s.slot {
+		s.count++
+		return
+	}
+	if s.witness != "" && slot > s.slot && len(witnessList) > 0 {
+		s.record(s.witness, "missed", int64(continuousNum)-s.count)
+		start := s.slot + 1
+		if slot-start > statsMaxMissedSlot {
+			start = slot - statsMaxMissedSlot
+		}
+		for sl := start; sl < slot; sl++ {
+			s.record(witnessOfSlot(sl, witnessList), "missed", int64(continuousNum))
+		}
+	}
+	s.slot = slot
+	s.witness = lb.witness
+	s.count = 1
+}
+
+// save writes the buckets to the file. It should be called with lock.
+func (s *producerStats) save() {
+	s.lastSave = time.Now()
+	if s.path == "" {
+		return
+	}
+	b, err := json.Marshal(s.buckets)
+	if err != nil {
+		ilog.Warnf("encode producer stats failed. err=%v", err)
+		return
+	}
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		ilog.Warnf("save producer stats failed. err=%v", err)
+		return
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		ilog.Warnf("save producer stats failed. err=%v", err)
+	}
+}
+
+func (s *producerStats) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.save()
+}
+
+// stats returns the ProducerStat in the window of the witness, or of all witnesses if witness is empty.
+func (s *producerStats) stats(witness string) []*ProducerStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bucket()
+	sum := make(map[string]*ProducerStat)
+	for _, b := range s.buckets {
+		for w, stat := range b.Stats {
+			if witness != "" && w != witness {
+				continue
+			}
+			if _, ok := sum[w]; !ok {
+				sum[w] = &ProducerStat{Witness: w}
+			}
+			sum[w].add(stat)
+		}
+	}
+	ret := make([]*ProducerStat, 0, len(sum))
+	for _, stat := range sum {
+		ret = append(ret, stat)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Witness < ret[j].Witness
+	})
+	return ret
+}
@@ -0,0 +1,95 @@
+package pob
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	core_mock "github.com/iost-official/go-iost/core/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func statsBlock(witness string, number int64, slot int64) *block.Block {
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			Witness: witness,
+			Number:  number,
+			Time:    slot * common.SlotLength * second2nanosecond,
+		},
+	}
+	blk.CalculateHeadHash()
+	return blk
+}
+
+func TestProducerStats(t *testing.T) {
+	origin := continuousNum
+	continuousNum = 2
+	defer func() { continuousNum = origin }()
+
+	dir, err := ioutil.TempDir("", "producer_stats")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, producerStatsFile)
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	chain := core_mock.NewMockChain(ctl)
+
+	witnessList := []string{"a", "b", "c"}
+	blocks := []*block.Block{
+		statsBlock("a", 1, 0),
+		statsBlock("a", 2, 0),
+		statsBlock("c", 3, 2),
+		statsBlock("a", 4, 3),
+	}
+	orphan := statsBlock("b", 3, 1)
+	chain.EXPECT().GetHashByNumber(gomock.Any()).AnyTimes().DoAndReturn(func(n int64) ([]byte, error) {
+		if n < 1 || n > int64(len(blocks)) {
+			return nil, errors.New("not found")
+		}
+		return blocks[n-1].HeadHash(), nil
+	})
+
+	s := newProducerStats(path)
+	for _, blk := range blocks {
+		s.onLinked(blk)
+	}
+	s.onLinked(orphan)
+	s.onLib(chain, blocks[2], witnessList)
+	assert.Len(t, s.linked, 1)
+	s.onLib(chain, blocks[3], witnessList)
+	assert.Len(t, s.linked, 0)
+
+	late := statsBlock("b", 5, 0)
+	late.Head.Time = time.Now().Add(-time.Second).UnixNano()
+	s.onReceived(late, time.Now().UnixNano())
+	// The block received on time isn't late however long its verification takes.
+	onTime := statsBlock("c", 5, 0)
+	onTime.Head.Time = time.Now().Add(-time.Second).UnixNano()
+	s.onReceived(onTime, onTime.Head.Time)
+
+	expect := []*ProducerStat{
+		{Witness: "a", Produced: 3},
+		{Witness: "b", Missed: 2, Late: 1, Orphaned: 1},
+		{Witness: "c", Produced: 1, Missed: 1},
+	}
+	assert.Equal(t, expect, s.stats(""))
+	assert.Equal(t, expect[1:2], s.stats("b"))
+
+	s.flush()
+	assert.Equal(t, expect, newProducerStats(path).stats(""))
+}
+
+func TestProducerStatsExpire(t *testing.T) {
+	s := newProducerStats("")
+	s.record("a", "produced", 1)
+	s.buckets[0].Start -= int64(statsBucketNum) * int64(statsBucketLength)
+	s.record("a", "missed", 1)
+	assert.Equal(t, []*ProducerStat{{Witness: "a", Missed: 1}}, s.stats("a"))
+}
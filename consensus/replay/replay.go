@@ -0,0 +1,291 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/cverifier"
+	"github.com/iost-official/go-iost/consensus/genesis"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/verifier"
+)
+
+// blockTimeout is the time limit of the block base tx, as it is when the block is verified by pob.
+var blockTimeout = 400 * time.Millisecond
+
+// Options is the options of a replay.
+type Options struct {
+	From int64
+	// To is the last block to replay, default is the top of the chain.
+	To int64
+	// Snapshot is the snapshot file of the base state. Default is the latest automatic snapshot
+	// before From, or the genesis if there is none.
+	Snapshot string
+	// Progress is called after each block is replayed.
+	Progress func(number int64)
+}
+
+// Result is the result of a replay.
+type Result struct {
+	// Base is the number of the block whose state the replay starts from.
+	Base       int64       `json:"base"`
+	BaseSource string      `json:"baseSource"`
+	From       int64       `json:"from"`
+	To         int64       `json:"to"`
+	Blocks     int64       `json:"blocks"`
+	Txs        int64       `json:"txs"`
+	Duration   string      `json:"duration"`
+	Divergence *Divergence `json:"divergence,omitempty"`
+}
+
+// Divergence is the first block whose replay doesn't match the chain.
+type Divergence struct {
+	Number int64  `json:"number"`
+	Hash   string `json:"hash"`
+	Reason string `json:"reason"`
+	// TxIndex is the index of the first divergent tx in the block, -1 if no tx is found divergent.
+	TxIndex  int                     `json:"txIndex"`
+	TxHash   string                  `json:"txHash,omitempty"`
+	Expected string                  `json:"expected,omitempty"`
+	Actual   string                  `json:"actual,omitempty"`
+	Changes  []*verifier.StateChange `json:"changes,omitempty"`
+}
+
+// Run rebuilds the state before opts.From in a temporary db, and re-executes the blocks to opts.To of the chain.
+// It stops at the first block whose receipts or merkle hashes don't match.
+// The chain is opened from the db path of the config, so the node should be stopped. The temporary state db is on
+// the storage of the chain, with the cache of the config.
+func Run(conf *common.Config, opts *Options) (*Result, error) {
+	start := time.Now()
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if storageType == kv.MemoryStorage {
+		return nil, fmt.Errorf("the chain on the memory storage is lost once iserver stops, nothing to replay")
+	}
+	cacheType, err := mvcc.ParseCacheType(conf.DB.Cache)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := block.NewBlockChainWithStorage(conf.DB.LdbPath+"BlockChainDB", storageType)
+	if err != nil {
+		return nil, err
+	}
+	defer chain.Close()
+	top := chain.Length() - 1
+	if opts.To <= 0 || opts.To > top {
+		opts.To = top
+	}
+	if opts.From < 1 {
+		opts.From = 1
+	}
+	if opts.From > opts.To {
+		return nil, fmt.Errorf("invalid block range [%v, %v], the top block is %v", opts.From, opts.To, top)
+	}
+
+	dir, err := ioutil.TempDir("", "iost-replay")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	stateDB, base, source, err := baseState(conf, opts, filepath.Join(dir, "StateDB"), storageType, cacheType)
+	if err != nil {
+		return nil, err
+	}
+	defer stateDB.Close()
+
+	result := &Result{
+		Base:       base.Head.Number,
+		BaseSource: source,
+		From:       opts.From,
+		To:         opts.To,
+	}
+	expected, err := chain.GetHashByNumber(base.Head.Number)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(expected, base.HeadHash()) {
+		result.Divergence = &Divergence{
+			Number:  base.Head.Number,
+			Hash:    common.Base58Encode(base.HeadHash()),
+			Reason:  fmt.Sprintf("base state is of block %v, the chain has %v", common.Base58Encode(base.HeadHash()), common.Base58Encode(expected)),
+			TxIndex: -1,
+		}
+		result.Duration = time.Since(start).String()
+		return result, nil
+	}
+
+	parent := base
+	for number := base.Head.Number + 1; number <= opts.To; number++ {
+		blk, err := chain.GetBlockByNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("get block %v failed: %v", number, err)
+		}
+		div, err := replayBlock(blk, parent, stateDB)
+		if err != nil {
+			return nil, err
+		}
+		result.Blocks++
+		result.Txs += int64(len(blk.Txs))
+		if div != nil {
+			result.Divergence = div
+			break
+		}
+		parent = blk
+		if opts.Progress != nil {
+			opts.Progress(number)
+		}
+	}
+	result.Duration = time.Since(start).String()
+	return result, nil
+}
+
+// baseState builds the state db at path from the snapshot or the genesis, and returns the block of the state.
+func baseState(conf *common.Config, opts *Options, path string, storageType kv.StorageType, cacheType mvcc.CacheType) (db.MVCCDB, *block.Block, string, error) {
+	source := opts.Snapshot
+	if source == "" {
+		metas, err := snapshot.ListAuto(conf)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		for _, m := range metas {
+			if m.Number < opts.From {
+				source = filepath.Join(conf.DB.LdbPath, snapshot.AutoDir, m.File)
+			}
+		}
+	}
+
+	if source == "" {
+		stateDB, err := db.NewMVCCDBWithStorage(path, storageType, cacheType)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		blk, err := genesis.GenGenesisByFile(stateDB, conf.Genesis)
+		if err != nil {
+			stateDB.Close()
+			return nil, nil, "", err
+		}
+		return stateDB, blk, "genesis", nil
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer file.Close()
+	m, err := snapshot.Import(file, path, storageType, chainID(conf))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if m.Number >= opts.From {
+		return nil, nil, "", fmt.Errorf("snapshot %v is of block %v, not before block %v", source, m.Number, opts.From)
+	}
+	stateDB, err := db.NewMVCCDBWithStorage(path, storageType, cacheType)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	blk, err := snapshot.Load(stateDB)
+	if err != nil {
+		stateDB.Close()
+		return nil, nil, "", err
+	}
+	return stateDB, blk, source, nil
+}
+
+func replayBlock(blk, parent *block.Block, stateDB db.MVCCDB) (*Divergence, error) {
+	parentTag := string(parent.HeadHash())
+	if !stateDB.Checkout(parentTag) {
+		return nil, fmt.Errorf("state of block %v not found", parent.Head.Number)
+	}
+	c := &verifier.Config{
+		Mode:        0,
+		Timeout:     blockTimeout,
+		TxTimeLimit: common.MaxTxTimeLimit,
+	}
+	v := verifier.Verifier{}
+	reason := ""
+	if err := v.Verify(blk, parent, witnessList(blk), stateDB, c); err != nil {
+		reason = err.Error()
+	} else if !bytes.Equal(blk.CalculateTxMerkleHash(), blk.Head.TxMerkleHash) {
+		reason = "tx merkle hash not match"
+	} else if !bytes.Equal(blk.CalculateTxReceiptMerkleHash(), blk.Head.TxReceiptMerkleHash) {
+		reason = "tx receipt merkle hash not match"
+	} else if err := cverifier.VerifyStateRoot(blk, parent, stateDB); err != nil {
+		reason = err.Error()
+	}
+	if reason == "" {
+		tag := string(blk.HeadHash())
+		stateDB.Commit(tag)
+		return nil, stateDB.Flush(tag)
+	}
+
+	// Re-execute the block tx by tx from the parent state to find out the divergent tx.
+	if !stateDB.Checkout(parentTag) {
+		return nil, fmt.Errorf("state of block %v not found", parent.Head.Number)
+	}
+	div := &Divergence{
+		Number:  blk.Head.Number,
+		Hash:    common.Base58Encode(blk.HeadHash()),
+		Reason:  reason,
+		TxIndex: -1,
+	}
+	traces, err := v.Trace(blk, stateDB, c)
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range traces {
+		if t.Err == nil && bytes.Equal(t.Receipt.Hash(), blk.Receipts[i].Hash()) {
+			continue
+		}
+		div.TxIndex = i
+		div.TxHash = common.Base58Encode(blk.Txs[i].Hash())
+		div.Expected = blk.Receipts[i].String()
+		if t.Err != nil {
+			div.Actual = t.Err.Error()
+		} else {
+			div.Actual = t.Receipt.String()
+		}
+		div.Changes = t.Changes
+		break
+	}
+	return div, nil
+}
+
+// witnessList returns the witness list reproducing the witness changed flag of the block base tx.
+// The witness lists are decided by the block cache when the blocks are linked, which isn't replayed.
+func witnessList(blk *block.Block) *blockcache.WitnessList {
+	wl := &blockcache.WitnessList{}
+	if len(blk.Txs) == 0 || len(blk.Txs[0].Actions) == 0 {
+		return wl
+	}
+	var data []map[string][]interface{}
+	if err := json.Unmarshal([]byte(blk.Txs[0].Actions[0].Data), &data); err != nil || len(data) == 0 {
+		return wl
+	}
+	parent := data[0]["parent"]
+	if len(parent) == 3 {
+		if changed, ok := parent[2].(bool); ok && changed {
+			wl.SetPending([]string{""})
+		}
+	}
+	return wl
+}
+
+func chainID(conf *common.Config) uint32 {
+	if conf.P2P == nil {
+		return 0
+	}
+	return conf.P2P.ChainID
+}
@@ -0,0 +1,25 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWitnessList(t *testing.T) {
+	blk := &block.Block{Head: &block.BlockHead{Number: 10}}
+	assert.True(t, common.StringSliceEqual(witnessList(blk).Active(), witnessList(blk).Pending()))
+
+	for _, changed := range []bool{true, false} {
+		data := `[{"parent":["witness", "100", false]}]`
+		if changed {
+			data = `[{"parent":["witness", "100", true]}]`
+		}
+		blk.Txs = []*tx.Tx{{Actions: []*tx.Action{tx.NewAction("base.iost", "exec", data)}}}
+		wl := witnessList(blk)
+		assert.Equal(t, changed, !common.StringSliceEqual(wl.Active(), wl.Pending()))
+	}
+}
@@ -0,0 +1,295 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/metrics"
+)
+
+// AutoDir is the directory of the automatic snapshots in the db path.
+const AutoDir = "snapshots"
+
+const autoIndexFile = "index.json"
+
+var (
+	metricsAutoSnapshot = metrics.NewCounter("iost_snapshot_auto", []string{"result"})
+
+	autoCheckInterval = 3 * time.Second
+
+	errAutoStopped = errors.New("auto snapshot stopped")
+)
+
+// Meta is the metadata of an automatic snapshot.
+type Meta struct {
+	*Manifest
+	File     string `json:"file"`
+	Size     int64  `json:"size"`
+	Time     int64  `json:"time"`
+	Duration string `json:"duration"`
+}
+
+// Auto creates a snapshot of the state db every interval irreversible blocks and keeps the latest ones.
+// The snapshot is exported from a storage snapshot in the background, so block producing isn't paused.
+type Auto struct {
+	dir      string
+	interval int64
+	keep     int
+	chainID  uint32
+	stateDB  db.MVCCDB
+	lib      func() int64
+
+	metas []*Meta
+	mu    sync.RWMutex
+	exit  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewAuto returns an Auto of the config, lib returns the number of the current irreversible block.
+// It returns nil if the automatic snapshot is disabled.
+func NewAuto(conf *common.Config, stateDB db.MVCCDB, lib func() int64) *Auto {
+	if conf.Snapshot == nil || conf.Snapshot.Interval <= 0 {
+		return nil
+	}
+	keep := conf.Snapshot.Keep
+	if keep <= 0 {
+		keep = 1
+	}
+	return &Auto{
+		dir:      filepath.Join(conf.DB.LdbPath, AutoDir),
+		interval: conf.Snapshot.Interval,
+		keep:     keep,
+		chainID:  chainID(conf),
+		stateDB:  stateDB,
+		lib:      lib,
+		exit:     make(chan struct{}),
+	}
+}
+
+// Start starts creating snapshots.
+func (a *Auto) Start() error {
+	err := os.MkdirAll(a.dir, 0755)
+	if err != nil {
+		return err
+	}
+	err = a.load()
+	if err != nil {
+		return err
+	}
+	a.wg.Add(1)
+	go a.loop()
+	return nil
+}
+
+// Stop stops creating snapshots, the snapshot being created is discarded.
+func (a *Auto) Stop() {
+	close(a.exit)
+	a.wg.Wait()
+}
+
+// List returns the metadata of the kept snapshots, the latest first.
+func (a *Auto) List() []*Meta {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	metas := make([]*Meta, len(a.metas))
+	for i, m := range a.metas {
+		metas[len(a.metas)-1-i] = m
+	}
+	return metas
+}
+
+// Path returns the path of the kept snapshot file.
+func (a *Auto) Path(file string) (string, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, m := range a.metas {
+		if m.File == file {
+			return filepath.Join(a.dir, file), true
+		}
+	}
+	return "", false
+}
+
+func (a *Auto) loop() {
+	defer a.wg.Done()
+	ticker := time.NewTicker(autoCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if a.lib()/a.interval <= a.last()/a.interval {
+				continue
+			}
+			meta, err := a.create()
+			if err == errAutoStopped {
+				return
+			}
+			if err != nil {
+				ilog.Errorf("create snapshot failed. err=%v", err)
+				metricsAutoSnapshot.Add(1, map[string]string{"result": "fail"})
+				continue
+			}
+			metricsAutoSnapshot.Add(1, map[string]string{"result": "success"})
+			ilog.Infof("created snapshot of block %v in %v", meta.Number, meta.Duration)
+			err = a.add(meta)
+			if err != nil {
+				ilog.Errorf("save snapshot index failed. err=%v", err)
+			}
+		case <-a.exit:
+			return
+		}
+	}
+}
+
+func (a *Auto) last() int64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.metas) == 0 {
+		return 0
+	}
+	return a.metas[len(a.metas)-1].Number
+}
+
+// create exports the flushed state of the state db to a new snapshot file.
+func (a *Auto) create() (*Meta, error) {
+	start := time.Now()
+	snap, err := a.stateDB.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+
+	file, err := ioutil.TempFile(a.dir, "snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	m, err := export(snap, a.chainID, &stopWriter{w: file, exit: a.exit})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("snapshot-%v.iost", m.Number)
+	err = os.Rename(file.Name(), filepath.Join(a.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(filepath.Join(a.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	return &Meta{
+		Manifest: m,
+		File:     name,
+		Size:     fi.Size(),
+		Time:     start.Unix(),
+		Duration: time.Since(start).String(),
+	}, nil
+}
+
+// add records the snapshot and removes the ones out of retention.
+func (a *Auto) add(meta *Meta) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	metas := make([]*Meta, 0, len(a.metas)+1)
+	for _, m := range a.metas {
+		if m.File != meta.File {
+			metas = append(metas, m)
+		}
+	}
+	metas = append(metas, meta)
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Number < metas[j].Number })
+	for len(metas) > a.keep {
+		err := os.Remove(filepath.Join(a.dir, metas[0].File))
+		if err != nil && !os.IsNotExist(err) {
+			ilog.Warnf("remove snapshot %v failed. err=%v", metas[0].File, err)
+		}
+		metas = metas[1:]
+	}
+	a.metas = metas
+	return a.save()
+}
+
+func (a *Auto) load() error {
+	metas, err := loadIndex(a.dir)
+	if err != nil {
+		return err
+	}
+	a.metas = metas
+	return nil
+}
+
+// ListAuto returns the automatic snapshots in the db path of the config, the oldest first.
+func ListAuto(conf *common.Config) ([]*Meta, error) {
+	return loadIndex(filepath.Join(conf.DB.LdbPath, AutoDir))
+}
+
+// loadIndex reads the index of the snapshots in dir, and skips the ones whose file is missing.
+func loadIndex(dir string) ([]*Meta, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, autoIndexFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var metas []*Meta
+	err = json.Unmarshal(b, &metas)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Meta, 0, len(metas))
+	for _, m := range metas {
+		if m.Manifest == nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, m.File)); err == nil {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// save writes the index of the snapshots. It should be called with lock.
+func (a *Auto) save() error {
+	b, err := json.MarshalIndent(a.metas, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(a.dir, autoIndexFile)
+	err = ioutil.WriteFile(path+".tmp", b, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// stopWriter fails the writing once exit is closed, so a long export doesn't block stopping.
+type stopWriter struct {
+	w    io.Writer
+	exit chan struct{}
+}
+
+func (sw *stopWriter) Write(p []byte) (int, error) {
+	select {
+	case <-sw.exit:
+		return 0, errAutoStopped
+	default:
+		return sw.w.Write(p)
+	}
+}
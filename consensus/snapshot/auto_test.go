@@ -0,0 +1,85 @@
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuto(t *testing.T) {
+	origin := autoCheckInterval
+	autoCheckInterval = 10 * time.Millisecond
+	defer func() { autoCheckInterval = origin }()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	stateDB, err := db.NewMVCCDB(filepath.Join(dir, "StateDB"))
+	assert.Nil(t, err)
+	defer stateDB.Close()
+
+	var lib int64
+	flush := func(number int64) {
+		assert.Nil(t, stateDB.Put("state", randString(8), randString(8)))
+		blk := &block.Block{Head: &block.BlockHead{Number: number}}
+		assert.Nil(t, blk.CalculateHeadHash())
+		assert.Nil(t, Save(stateDB, blk))
+		stateDB.Commit(string(blk.HeadHash()))
+		assert.Nil(t, stateDB.Flush(string(blk.HeadHash())))
+		atomic.StoreInt64(&lib, number)
+	}
+	wait := func(a *Auto, number int64) {
+		for i := 0; i < 200; i++ {
+			if a.last() == number {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("no snapshot of block %v", number)
+	}
+
+	conf := &common.Config{
+		DB:       &common.DBConfig{LdbPath: dir},
+		Snapshot: &common.SnapshotConfig{Interval: 10, Keep: 2},
+	}
+	assert.Nil(t, NewAuto(&common.Config{DB: conf.DB, Snapshot: &common.SnapshotConfig{}}, stateDB, nil))
+	a := NewAuto(conf, stateDB, func() int64 { return atomic.LoadInt64(&lib) })
+	assert.Nil(t, a.Start())
+
+	flush(5)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, len(a.List()))
+	for _, number := range []int64{12, 25, 31} {
+		flush(number)
+		wait(a, number)
+	}
+	a.Stop()
+
+	metas := a.List()
+	assert.Equal(t, 2, len(metas))
+	assert.Equal(t, int64(31), metas[0].Number)
+	assert.Equal(t, int64(25), metas[1].Number)
+	_, err = os.Stat(filepath.Join(dir, AutoDir, "snapshot-12.iost"))
+	assert.True(t, os.IsNotExist(err))
+
+	path, ok := a.Path(metas[0].File)
+	assert.True(t, ok)
+	f, err := os.Open(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	m, err := Verify(f)
+	assert.Nil(t, err)
+	assert.Equal(t, metas[0].Checksum, m.Checksum)
+
+	b := NewAuto(conf, stateDB, func() int64 { return 0 })
+	assert.Nil(t, b.load())
+	assert.Equal(t, metas, b.List())
+}
@@ -0,0 +1,388 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db/kv"
+	"golang.org/x/crypto/sha3"
+)
+
+// The snapshot file is laid out as:
+//
+//	magic "IOSTSNAP" | version uint32 | header
+//	record* | end marker | trailer
+//
+// The header and the trailer are uvarint length-prefixed JSON, a record is
+// recordEntry | uvarint key length | key | uvarint value length | value.
+// The checksum in the trailer is the sha3-256 of the records and the end marker.
+const (
+	// FormatVersion is the version of the snapshot file format written by Export.
+	FormatVersion uint32 = 1
+
+	formatMagic = "IOSTSNAP"
+
+	recordEnd   byte = 0
+	recordEntry byte = 1
+
+	maxManifestSize = 1 << 20
+	maxKeySize      = 1 << 20
+	maxValueSize    = 64 << 20
+	importBatchSize = 10000
+)
+
+var headKey = []byte("snapshot/blockHead")
+
+var (
+	errInvalidFormat = errors.New("not a snapshot file")
+	errKeyCount      = errors.New("key count of snapshot mismatch")
+	errChecksum      = errors.New("checksum of snapshot mismatch")
+	errBlockHead     = errors.New("block head of snapshot mismatch")
+)
+
+// Manifest describes the content of a snapshot file.
+type Manifest struct {
+	Version   uint32           `json:"version"`
+	ChainID   uint32           `json:"chainID"`
+	Number    int64            `json:"number"`
+	Hash      string           `json:"hash"`
+	BlockHead *block.BlockHead `json:"blockHead"`
+	KeyCount  int64            `json:"keyCount"`
+	Checksum  string           `json:"checksum"`
+}
+
+type header struct {
+	ChainID   uint32           `json:"chainID"`
+	Number    int64            `json:"number"`
+	Hash      string           `json:"hash"`
+	BlockHead *block.BlockHead `json:"blockHead"`
+}
+
+type trailer struct {
+	KeyCount int64  `json:"keyCount"`
+	Checksum string `json:"checksum"`
+}
+
+// IsFile returns whether the file is in the snapshot file format.
+func IsFile(file string) bool {
+	f, err := os.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, len(formatMagic))
+	_, err = io.ReadFull(f, magic)
+	return err == nil && string(magic) == formatMagic
+}
+
+// Export streams the state db at path on the storage of storageType to w, the snapshot is of the block head saved
+// in the state db.
+func Export(path string, storageType kv.StorageType, chainID uint32, w io.Writer) (*Manifest, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	storage, err := kv.NewStorage(path, storageType)
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+	return export(storage, chainID, w)
+}
+
+// source is the state to export, which is a storage or a storage snapshot.
+type source interface {
+	Get(key []byte) ([]byte, error)
+	NewIteratorByPrefix(prefix []byte) *kv.Iterator
+}
+
+func export(src source, chainID uint32, w io.Writer) (*Manifest, error) {
+	bhJSON, err := src.Get(headKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(bhJSON) == 0 {
+		return nil, errors.New("no block head in state db")
+	}
+	blk := &block.Block{Head: &block.BlockHead{}}
+	err = json.Unmarshal(bhJSON, blk.Head)
+	if err != nil {
+		return nil, fmt.Errorf("block head decode failed. err: %v", err)
+	}
+	err = blk.CalculateHeadHash()
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{
+		Version:   FormatVersion,
+		ChainID:   chainID,
+		Number:    blk.Head.Number,
+		Hash:      common.Base58Encode(blk.HeadHash()),
+		BlockHead: blk.Head,
+	}
+
+	bw := bufio.NewWriterSize(w, 1<<20)
+	bw.WriteString(formatMagic)
+	binary.Write(bw, binary.BigEndian, FormatVersion)
+	err = writeJSON(bw, &header{ChainID: m.ChainID, Number: m.Number, Hash: m.Hash, BlockHead: m.BlockHead})
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha3.New256()
+	rw := io.MultiWriter(bw, h)
+	buf := make([]byte, binary.MaxVarintLen64)
+	iter := src.NewIteratorByPrefix([]byte{})
+	defer iter.Release()
+	for iter.Next() {
+		rw.Write([]byte{recordEntry})
+		writeBytes(rw, buf, iter.Key())
+		err = writeBytes(rw, buf, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		m.KeyCount++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	rw.Write([]byte{recordEnd})
+	m.Checksum = hex.EncodeToString(h.Sum(nil))
+
+	err = writeJSON(bw, &trailer{KeyCount: m.KeyCount, Checksum: m.Checksum})
+	if err != nil {
+		return nil, err
+	}
+	return m, bw.Flush()
+}
+
+// Import streams the snapshot from r to a new state db at path on the storage of storageType.
+// The state db is removed if the snapshot is invalid or of another chain.
+func Import(r io.Reader, path string, storageType kv.StorageType, chainID uint32) (m *Manifest, err error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("state db %v already exists", path)
+	}
+	storage, err := kv.NewStorage(path, storageType)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		storage.Close()
+		if err != nil {
+			os.RemoveAll(path)
+		}
+	}()
+
+	count := 0
+	err = storage.BeginBatch()
+	if err != nil {
+		return nil, err
+	}
+	m, err = read(r, func(m *Manifest) error {
+		if m.ChainID != chainID {
+			return fmt.Errorf("snapshot of chain %v can't be imported to chain %v", m.ChainID, chainID)
+		}
+		return nil
+	}, func(k, v []byte) error {
+		err := storage.Put(k, v)
+		if err != nil {
+			return err
+		}
+		count++
+		if count%importBatchSize == 0 {
+			err = storage.CommitBatch()
+			if err != nil {
+				return err
+			}
+			return storage.BeginBatch()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, storage.CommitBatch()
+}
+
+// Verify reads the whole snapshot from r, and checks it against its manifest.
+func Verify(r io.Reader) (*Manifest, error) {
+	return read(r, nil, nil)
+}
+
+// read parses the snapshot from r, onHeader is called before the records and onRecord on every record.
+func read(r io.Reader, onHeader func(*Manifest) error, onRecord func(k, v []byte) error) (*Manifest, error) {
+	br := bufio.NewReaderSize(r, 1<<20)
+	magic := make([]byte, len(formatMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != formatMagic {
+		return nil, errInvalidFormat
+	}
+	m := &Manifest{}
+	if err := binary.Read(br, binary.BigEndian, &m.Version); err != nil {
+		return nil, err
+	}
+	if m.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %v", m.Version)
+	}
+	var hd header
+	if err := readJSON(br, &hd); err != nil {
+		return nil, err
+	}
+	if hd.BlockHead == nil {
+		return nil, errBlockHead
+	}
+	m.ChainID, m.Number, m.Hash, m.BlockHead = hd.ChainID, hd.Number, hd.Hash, hd.BlockHead
+	blk := &block.Block{Head: m.BlockHead}
+	if err := blk.CalculateHeadHash(); err != nil {
+		return nil, err
+	}
+	if m.Number != blk.Head.Number || m.Hash != common.Base58Encode(blk.HeadHash()) {
+		return nil, errBlockHead
+	}
+	if onHeader != nil {
+		if err := onHeader(m); err != nil {
+			return nil, err
+		}
+	}
+
+	h := sha3.New256()
+	rr := &recordReader{r: br, h: h}
+	var count int64
+	var headFound bool
+	for {
+		typ, err := rr.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if typ == recordEnd {
+			break
+		}
+		if typ != recordEntry {
+			return nil, fmt.Errorf("invalid record type %v", typ)
+		}
+		k, err := rr.readBytes(maxKeySize)
+		if err != nil {
+			return nil, err
+		}
+		v, err := rr.readBytes(maxValueSize)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(k, headKey) {
+			var bh block.BlockHead
+			if err := json.Unmarshal(v, &bh); err != nil || !blockHeadEqual(&bh, m.BlockHead) {
+				return nil, errBlockHead
+			}
+			headFound = true
+		}
+		if onRecord != nil {
+			if err := onRecord(k, v); err != nil {
+				return nil, err
+			}
+		}
+		count++
+	}
+
+	var tr trailer
+	if err := readJSON(br, &tr); err != nil {
+		return nil, err
+	}
+	m.KeyCount, m.Checksum = tr.KeyCount, tr.Checksum
+	if _, err := br.ReadByte(); err != io.EOF {
+		return nil, errInvalidFormat
+	}
+	if count != m.KeyCount {
+		return nil, errKeyCount
+	}
+	if hex.EncodeToString(h.Sum(nil)) != m.Checksum {
+		return nil, errChecksum
+	}
+	if !headFound {
+		return nil, errBlockHead
+	}
+	return m, nil
+}
+
+func blockHeadEqual(a, b *block.BlockHead) bool {
+	x, y := &block.Block{Head: a}, &block.Block{Head: b}
+	if x.CalculateHeadHash() != nil || y.CalculateHeadHash() != nil {
+		return false
+	}
+	return bytes.Equal(x.HeadHash(), y.HeadHash())
+}
+
+func writeBytes(w io.Writer, buf []byte, b []byte) error {
+	n := binary.PutUvarint(buf, uint64(len(b)))
+	w.Write(buf[:n])
+	_, err := w.Write(b)
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeBytes(w, make([]byte, binary.MaxVarintLen64), b)
+}
+
+func readJSON(r *bufio.Reader, v interface{}) error {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if n > maxManifestSize {
+		return errInvalidFormat
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// recordReader reads the records and hashes the bytes read.
+type recordReader struct {
+	r *bufio.Reader
+	h hash.Hash
+}
+
+func (rr *recordReader) ReadByte() (byte, error) {
+	c, err := rr.r.ReadByte()
+	if err == nil {
+		rr.h.Write([]byte{c})
+	}
+	return c, err
+}
+
+func (rr *recordReader) readByte() (byte, error) {
+	c, err := rr.ReadByte()
+	if err == io.EOF {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return c, err
+}
+
+func (rr *recordReader) readBytes(max uint64) ([]byte, error) {
+	n, err := binary.ReadUvarint(rr)
+	if err != nil {
+		return nil, err
+	}
+	if n > max {
+		return nil, errInvalidFormat
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rr.r, b); err != nil {
+		return nil, err
+	}
+	rr.h.Write(b)
+	return b, nil
+}
@@ -0,0 +1,132 @@
+package snapshot
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStateDB(t *testing.T, path string, storageType kv.StorageType) (*block.Block, map[string]string) {
+	stateDB, err := db.NewMVCCDBWithStorage(path, storageType, mvcc.MapCache)
+	assert.Nil(t, err)
+	values := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		k, v := randString(16), randString(32)+"\n\x00"+randString(8)
+		values[k] = v
+		assert.Nil(t, stateDB.Put("state", k, v))
+	}
+	blk := &block.Block{Head: &block.BlockHead{Number: 10, Witness: "witness"}}
+	assert.Nil(t, blk.CalculateHeadHash())
+	assert.Nil(t, Save(stateDB, blk))
+	stateDB.Commit(string(blk.HeadHash()))
+	assert.Nil(t, stateDB.Flush(string(blk.HeadHash())))
+	stateDB.Close()
+	return blk, values
+}
+
+func TestFormat(t *testing.T) {
+	for _, storageType := range []kv.StorageType{kv.LevelDBStorage, kv.BboltStorage} {
+		testFormat(t, storageType)
+	}
+}
+
+func testFormat(t *testing.T, storageType kv.StorageType) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	blk, values := newStateDB(t, filepath.Join(dir, "StateDB"), storageType)
+
+	var buf bytes.Buffer
+	m, err := Export(filepath.Join(dir, "StateDB"), storageType, 1024, &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, FormatVersion, m.Version)
+	assert.Equal(t, blk.Head.Number, m.Number)
+	assert.Equal(t, common.Base58Encode(blk.HeadHash()), m.Hash)
+	assert.True(t, m.KeyCount > int64(len(values)))
+
+	vm, err := Verify(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, m, vm)
+
+	_, err = Import(bytes.NewReader(buf.Bytes()), filepath.Join(dir, "Other"), storageType, 1)
+	assert.NotNil(t, err)
+	_, err = os.Stat(filepath.Join(dir, "Other"))
+	assert.True(t, os.IsNotExist(err))
+
+	path := filepath.Join(dir, "Imported")
+	_, err = Import(bytes.NewReader(buf.Bytes()), path, storageType, 1024)
+	assert.Nil(t, err)
+	stateDB, err := db.NewMVCCDBWithStorage(path, storageType, mvcc.MapCache)
+	assert.Nil(t, err)
+	for k, v := range values {
+		got, err := stateDB.Get("state", k)
+		assert.Nil(t, err)
+		assert.Equal(t, v, got)
+	}
+	got, err := Load(stateDB)
+	assert.Nil(t, err)
+	assert.Equal(t, blk.HeadHash(), got.HeadHash())
+	stateDB.Close()
+
+	b := buf.Bytes()
+	corrupted := append([]byte{}, b...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	_, err = Verify(bytes.NewReader(corrupted))
+	assert.NotNil(t, err)
+	_, err = Verify(bytes.NewReader(b[:len(b)-10]))
+	assert.NotNil(t, err)
+	_, err = Verify(bytes.NewReader([]byte("IOSTSNAQ")))
+	assert.Equal(t, errInvalidFormat, err)
+}
+
+func TestFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	blk, _ := newStateDB(t, filepath.Join(dir, "StateDB"), kv.LevelDBStorage)
+
+	conf := &common.Config{
+		DB:       &common.DBConfig{LdbPath: dir + "/"},
+		P2P:      &common.P2PConfig{ChainID: 1024},
+		Snapshot: &common.SnapshotConfig{Enable: true, FilePath: filepath.Join(dir, "Snapshot.iost")},
+	}
+	assert.Nil(t, ToFile(conf))
+	assert.True(t, IsFile(conf.Snapshot.FilePath))
+	got, err := ReadHead(conf.Snapshot.FilePath, kv.LevelDBStorage, mvcc.MapCache)
+	assert.Nil(t, err)
+	assert.Equal(t, blk.HeadHash(), got.HeadHash())
+
+	// The state root is the same in both formats.
+	assert.Nil(t, ToSnapshot(conf))
+	root, err := StateRoot(conf.Snapshot.FilePath, kv.LevelDBStorage)
+	assert.Nil(t, err)
+	tarRoot, err := StateRoot(filepath.Join(dir, FileName), kv.LevelDBStorage)
+	assert.Nil(t, err)
+	assert.Equal(t, tarRoot, root)
+	assert.NotEqual(t, make([]byte, len(root)), root)
+
+	assert.Nil(t, os.RemoveAll(filepath.Join(dir, "StateDB")))
+	assert.Nil(t, FromSnapshot(conf))
+	stateDB, err := db.NewMVCCDB(filepath.Join(dir, "StateDB"))
+	assert.Nil(t, err)
+	defer stateDB.Close()
+	got, err = Load(stateDB)
+	assert.Nil(t, err)
+	assert.Equal(t, blk.HeadHash(), got.HeadHash())
+
+	_, ok := Imported(conf.DB.LdbPath)
+	assert.False(t, ok)
+	assert.Nil(t, MarkImported(conf.DB.LdbPath, common.Base58Encode(got.HeadHash())))
+	hash, ok := Imported(conf.DB.LdbPath)
+	assert.True(t, ok)
+	assert.Equal(t, common.Base58Encode(blk.HeadHash()), hash)
+}
@@ -2,10 +2,11 @@ package snapshot
 
 import (
 	"archive/tar"
-	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,9 +17,19 @@ import (
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/db"
-	"github.com/iost-official/go-iost/db/kv/leveldb"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/db/smt"
+	"github.com/iost-official/go-iost/vm/database"
 )
 
+// FileName is the name of the snapshot file in the db path.
+const FileName = "Snapshot.tar.gz"
+
+// ImportedFileName is the name of the file in the db path marking the state db imported from a snapshot, which has
+// the hash of the block of the snapshot.
+const ImportedFileName = "SnapshotImported"
+
 // Save the function for saving block's head from snapshot.
 func Save(db db.MVCCDB, blk *block.Block) error {
 	bhJSON, err := json.Marshal(blk.Head)
@@ -56,7 +67,7 @@ func ToSnapshot(conf *common.Config) error {
 		return fmt.Errorf("Unable to tar files - %v", err.Error())
 	}
 
-	file, err := os.Create(filepath.Join(conf.DB.LdbPath, "Snapshot.tar.gz"))
+	file, err := os.Create(filepath.Join(conf.DB.LdbPath, FileName))
 	if err != nil {
 		return err
 	}
@@ -95,6 +106,21 @@ func ToSnapshot(conf *common.Config) error {
 	})
 }
 
+// MarkImported marks the state db in the db path as imported from the snapshot of the block of hash.
+func MarkImported(ldbPath string, hash string) error {
+	return ioutil.WriteFile(filepath.Join(ldbPath, ImportedFileName), []byte(hash), 0644)
+}
+
+// Imported returns the hash of the block of the snapshot the state db in the db path is imported from, and false if
+// it isn't marked as imported.
+func Imported(ldbPath string) (string, bool) {
+	b, err := ioutil.ReadFile(filepath.Join(ldbPath, ImportedFileName))
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
 // FromSnapshot the function for loading db from snapshot.
 func FromSnapshot(conf *common.Config) error {
 	src := filepath.Join(conf.DB.LdbPath, "/StateDB")
@@ -103,11 +129,106 @@ func FromSnapshot(conf *common.Config) error {
 	if err == nil && s.IsDir() {
 		return errors.New("state db already has")
 	}
+	if IsFile(conf.Snapshot.FilePath) {
+		return FromFile(conf)
+	}
 	err = os.MkdirAll(src, os.ModePerm)
 	if err != nil {
 		return err
 	}
-	fr, err := os.Open(conf.Snapshot.FilePath)
+	return extract(conf.Snapshot.FilePath, conf.DB.LdbPath)
+}
+
+// ReadHead returns the block head saved in the state db of the snapshot file,
+// which is either a tar.gz of the state db or in the snapshot file format.
+// The state db in the tar.gz is opened on the storage of storageType.
+func ReadHead(file string, storageType kv.StorageType, cacheType mvcc.CacheType) (*block.Block, error) {
+	if IsFile(file) {
+		return readFileHead(file)
+	}
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	err = extract(file, dir)
+	if err != nil {
+		return nil, err
+	}
+	stateDB, err := db.NewMVCCDBWithStorage(filepath.Join(dir, "StateDB"), storageType, cacheType)
+	if err != nil {
+		return nil, err
+	}
+	defer stateDB.Close()
+	return Load(stateDB)
+}
+
+// StateRoot returns the root of the state tree built from all the keys of the state table in the snapshot file,
+// which is the state root of the block of the snapshot if it's of V1. The state db in a tar.gz is opened on the
+// storage of storageType.
+func StateRoot(file string, storageType kv.StorageType) ([]byte, error) {
+	prefix := []byte(database.StateTable + string(db.SEPARATOR))
+	var b smt.Builder
+	if IsFile(file) {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		_, err = read(f, nil, func(k, v []byte) error {
+			if bytes.HasPrefix(k, prefix) {
+				b.Add(k[len(prefix):], v)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return b.Root()
+	}
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	err = extract(file, dir)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := kv.NewStorage(filepath.Join(dir, "StateDB"), storageType)
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+	iter := storage.NewIteratorByPrefix(prefix)
+	defer iter.Release()
+	for iter.Next() {
+		b.Add(iter.Key()[len(prefix):], iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return b.Root()
+}
+
+func readFileHead(file string) (*block.Block, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	m, err := Verify(f)
+	if err != nil {
+		return nil, err
+	}
+	blk := &block.Block{Head: m.BlockHead}
+	return blk, blk.CalculateHeadHash()
+}
+
+func extract(file string, dst string) error {
+	fr, err := os.Open(file)
 	if err != nil {
 		return err
 	}
@@ -133,14 +254,24 @@ func FromSnapshot(conf *common.Config) error {
 		if h.Typeflag == tar.TypeDir {
 			continue
 		}
+		// The snapshot may come from other nodes, never write outside of dst.
+		name := filepath.Join(dst, h.Name)
+		if !strings.HasPrefix(name, filepath.Clean(dst)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file name in snapshot: %v", h.Name)
+		}
+		err = os.MkdirAll(filepath.Dir(name), os.ModePerm)
+		if err != nil {
+			return err
+		}
 
-		fw, err := os.OpenFile(filepath.Join(conf.DB.LdbPath, h.Name), os.O_CREATE|os.O_WRONLY, os.FileMode(h.Mode))
+		fw, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY, os.FileMode(h.Mode))
 		if err != nil {
 			return err
 		}
 
 		_, err = io.Copy(fw, tr)
 		if err != nil {
+			fw.Close()
 			return err
 		}
 
@@ -149,39 +280,55 @@ func FromSnapshot(conf *common.Config) error {
 	return nil
 }
 
-// ToFile the function for saving db to File.
+// ToFile the function for saving db to File in the snapshot file format.
 func ToFile(conf *common.Config) error {
-	var src string
-	src = filepath.Join(conf.DB.LdbPath, "StateDB")
-
+	src := filepath.Join(conf.DB.LdbPath, "StateDB")
 	if _, err := os.Stat(src); err != nil {
-		return fmt.Errorf("Unable to tar files - %v", err.Error())
+		return fmt.Errorf("Unable to export state db - %v", err.Error())
 	}
-	db, err := leveldb.NewDB(src)
+
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
 	if err != nil {
 		return err
 	}
-	defer db.Close()
 
-	file, err := os.OpenFile(filepath.Join(conf.DB.LdbPath, "Snapshot.iost"), os.O_WRONLY|os.O_CREATE, 0666)
+	path := filepath.Join(conf.DB.LdbPath, "Snapshot.iost")
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	_, err = Export(src, storageType, chainID(conf), file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
 
+// FromFile the function for loading db from File in the snapshot file format.
+func FromFile(conf *common.Config) error {
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-	iter := db.NewIteratorByPrefix([]byte("")).(*leveldb.Iter)
-	writer := bufio.NewWriterSize(file, 100000)
-	for iter.Next() {
-		k := string(iter.Key())
-		v := string(iter.Value())
-		writer.WriteString(k + "\n")
-		writer.WriteString(v + "\n")
+	file, err := os.Open(conf.Snapshot.FilePath)
+	if err != nil {
+		return err
 	}
-	writer.Flush()
-	return nil
+	defer file.Close()
+	_, err = Import(file, filepath.Join(conf.DB.LdbPath, "StateDB"), storageType, chainID(conf))
+	return err
 }
 
-// FromFile the function for loading db from File.
-func FromFile() error {
-	return nil
+func chainID(conf *common.Config) uint32 {
+	if conf.P2P == nil {
+		return 0
+	}
+	return conf.P2P.ChainID
 }
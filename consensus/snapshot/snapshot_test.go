@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/db"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -34,6 +35,8 @@ func TestSnapshot(t *testing.T) {
 				fmt.Println(err)
 			}
 		}
+		err = Save(stateDB, &block.Block{Head: &block.BlockHead{Number: 1}})
+		So(err, ShouldBeNil)
 		stateDB.Commit("abc")
 		stateDB.Flush("abc")
 		stateDB.Close()
@@ -67,6 +70,7 @@ func BenchmarkSnapshot(b *testing.B) {
 	for i := 0; i < 1000000; i++ {
 		stateDB.Put("state", randString(64), randString(32))
 	}
+	Save(stateDB, &block.Block{Head: &block.BlockHead{Number: 1}})
 	stateDB.Commit("abc")
 	stateDB.Flush("abc")
 	stateDB.Close()
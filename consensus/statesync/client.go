@@ -0,0 +1,302 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/checkpoint"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/p2p"
+)
+
+var (
+	discoverInterval         = 3 * time.Second
+	discoverTimeout          = 5 * time.Minute
+	chunkTimeout             = 20 * time.Second
+	checkInterval            = time.Second
+	maxInflightPerPeer       = 2
+	maxFailedPerPeer         = 3
+	printInterval      int64 = 100
+)
+
+var (
+	errNoSnapshot   = errors.New("no snapshot of a checkpoint is advertised by enough peers")
+	errNoPeer       = errors.New("no peer left to download the snapshot from")
+	errNoCheckpoint = errors.New("no checkpoint to trust a snapshot")
+	errStateRoot    = errors.New("state of snapshot mismatches the state root of its block")
+)
+
+type candidate struct {
+	info  *msgpb.SnapshotInfo
+	peers map[p2p.PeerID]bool
+}
+
+type chunkRequest struct {
+	peer     p2p.PeerID
+	deadline time.Time
+}
+
+// Client downloads a snapshot from the peers for a node starting with empty databases.
+type Client struct {
+	p2pService  p2p.Service
+	minPeers    int
+	checkpoints *checkpoint.Checkpoints
+	storageType kv.StorageType
+	cacheType   mvcc.CacheType
+	msgCh       chan p2p.IncomingMessage
+}
+
+// NewClient returns a Client which only trusts a snapshot of the block of a checkpoint, and downloads it once
+// it's advertised by at least minPeers peers. As anyone can join as peers, the peers agreeing on a snapshot
+// don't make its block trusted, see Fetch for its state. The state db of the snapshot is read on the storage of
+// storageType.
+func NewClient(minPeers int, checkpoints *checkpoint.Checkpoints, storageType kv.StorageType, cacheType mvcc.CacheType, p2pService p2p.Service) *Client {
+	if minPeers < 1 {
+		minPeers = 1
+	}
+	return &Client{
+		p2pService:  p2pService,
+		minPeers:    minPeers,
+		checkpoints: checkpoints,
+		storageType: storageType,
+		cacheType:   cacheType,
+	}
+}
+
+// Fetch finds the newest snapshot of a checkpoint advertised by enough peers, downloads its chunks from all of
+// them in parallel into the file at path, and verifies the file against the block of the checkpoint.
+//
+// The checkpoint only authenticates the block head. The state of the block of V1 is checked against the state root
+// in the head, the state of a V0 block can't be checked, so the peers serving it are the only trust anchor of it.
+func (c *Client) Fetch(path string) (*msgpb.SnapshotInfo, error) {
+	if len(c.checkpoints.Numbers()) == 0 {
+		return nil, errNoCheckpoint
+	}
+	c.msgCh = c.p2pService.Register("state sync client", p2p.SnapshotInfoResponse, p2p.SnapshotChunkResponse)
+	defer c.p2pService.Deregister("state sync client", p2p.SnapshotInfoResponse, p2p.SnapshotChunkResponse)
+
+	cand, err := c.discover()
+	if err != nil {
+		return nil, err
+	}
+	info := cand.info
+	ilog.Infof("state sync from %v peers, number: %v, hash: %v, size: %v",
+		len(cand.peers), info.Number, common.Base58Encode(info.BlockHash), info.Size)
+
+	err = c.download(cand, path)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := snapshot.ReadHead(path, c.storageType, c.cacheType)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot failed. err: %v", err)
+	}
+	if blk.Head.Number != info.Number || !bytes.Equal(blk.HeadHash(), info.BlockHash) ||
+		!c.checkpoints.Trusted(blk.Head.Number, blk.HeadHash()) {
+		return nil, fmt.Errorf("snapshot block mismatch, expect %v, got %v", common.Base58Encode(info.BlockHash), common.Base58Encode(blk.HeadHash()))
+	}
+	if blk.Head.Version != block.VersionOf(blk.Head.Number) {
+		return nil, fmt.Errorf("snapshot block version %v, expect %v", blk.Head.Version, block.VersionOf(blk.Head.Number))
+	}
+	if blk.Head.Version < block.V1 {
+		ilog.Warnf("state of the V0 block %v isn't checked, it's trusted as the snapshot is advertised by %v peers",
+			blk.Head.Number, len(cand.peers))
+		return info, nil
+	}
+	root, err := snapshot.StateRoot(path, c.storageType)
+	if err != nil {
+		return nil, fmt.Errorf("compute state root of snapshot failed. err: %v", err)
+	}
+	if !bytes.Equal(root, blk.Head.StateRoot) {
+		return nil, errStateRoot
+	}
+	return info, nil
+}
+
+func (c *Client) requestInfo() {
+	c.p2pService.Broadcast([]byte{}, p2p.SnapshotInfoRequest, p2p.NormalMessage)
+}
+
+// discover collects the snapshots advertised by peers until one of a checkpoint is advertised by minPeers peers.
+// A peer may advertise several snapshots.
+func (c *Client) discover() (*candidate, error) {
+	candidates := make(map[string]*candidate)
+
+	timeout := time.After(discoverTimeout)
+	ticker := time.NewTicker(discoverInterval)
+	defer ticker.Stop()
+	c.requestInfo()
+	for {
+		select {
+		case msg := <-c.msgCh:
+			if msg.Type() != p2p.SnapshotInfoResponse {
+				continue
+			}
+			var info msgpb.SnapshotInfo
+			err := proto.Unmarshal(msg.Data(), &info)
+			if err != nil || verifyInfo(&info) != nil {
+				continue
+			}
+			key := infoKey(&info)
+			if _, ok := candidates[key]; !ok {
+				candidates[key] = &candidate{info: &info, peers: make(map[p2p.PeerID]bool)}
+			}
+			candidates[key].peers[msg.From()] = true
+		case <-ticker.C:
+			if cand := c.best(candidates); cand != nil {
+				return cand, nil
+			}
+			c.requestInfo()
+		case <-timeout:
+			for _, cand := range candidates {
+				ilog.Infof("snapshot advertised by %v peers, number: %v, hash: %v", len(cand.peers),
+					cand.info.Number, common.Base58Encode(cand.info.BlockHash))
+			}
+			return nil, fmt.Errorf("%v, add the checkpoint of a snapshot above once its hash is trusted", errNoSnapshot)
+		}
+	}
+}
+
+// best returns the newest snapshot of a checkpoint advertised by at least minPeers peers.
+func (c *Client) best(candidates map[string]*candidate) *candidate {
+	var ret *candidate
+	for _, cand := range candidates {
+		if len(cand.peers) < c.minPeers || !c.checkpoints.Trusted(cand.info.Number, cand.info.BlockHash) {
+			continue
+		}
+		if ret == nil || cand.info.Number > ret.info.Number ||
+			(cand.info.Number == ret.info.Number && len(cand.peers) > len(ret.peers)) {
+			ret = cand
+		}
+	}
+	return ret
+}
+
+// download requests every chunk from the least busy peer, and retries it on other peers when
+// the peer times out. A peer sending a chunk that doesn't match the advertised hash is put to black.
+func (c *Client) download(cand *candidate, path string) error {
+	info := cand.info
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	peers := make(map[p2p.PeerID]int)
+	for p := range cand.peers {
+		peers[p] = 0
+	}
+	failed := make(map[p2p.PeerID]int)
+	pending := make([]int64, 0, len(info.ChunkHashes))
+	for i := range info.ChunkHashes {
+		pending = append(pending, int64(i))
+	}
+	inflight := make(map[int64]*chunkRequest)
+	var done int64
+
+	dropPeer := func(p p2p.PeerID) {
+		delete(peers, p)
+		for index, req := range inflight {
+			if req.peer == p {
+				delete(inflight, index)
+				pending = append(pending, index)
+			}
+		}
+	}
+	schedule := func() {
+		sort.Slice(pending, func(i, j int) bool { return pending[i] < pending[j] })
+		for len(pending) > 0 {
+			var peer p2p.PeerID
+			least := maxInflightPerPeer
+			for p, n := range peers {
+				if n < least {
+					peer, least = p, n
+				}
+			}
+			if least == maxInflightPerPeer {
+				return
+			}
+			index := pending[0]
+			pending = pending[1:]
+			b, err := proto.Marshal(&msgpb.SnapshotChunkRequest{BlockHash: info.BlockHash, Index: index})
+			if err != nil {
+				ilog.Errorf("marshal snapshot chunk request failed. err=%v", err)
+				continue
+			}
+			c.p2pService.SendToPeer(peer, b, p2p.SnapshotChunkRequest, p2p.NormalMessage)
+			inflight[index] = &chunkRequest{peer: peer, deadline: time.Now().Add(chunkTimeout)}
+			peers[peer]++
+		}
+	}
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for done < int64(len(info.ChunkHashes)) {
+		if len(peers) == 0 {
+			return errNoPeer
+		}
+		schedule()
+		select {
+		case msg := <-c.msgCh:
+			if msg.Type() != p2p.SnapshotChunkResponse {
+				continue
+			}
+			var chunk msgpb.SnapshotChunk
+			err := proto.Unmarshal(msg.Data(), &chunk)
+			if err != nil || !bytes.Equal(chunk.BlockHash, info.BlockHash) {
+				continue
+			}
+			req, ok := inflight[chunk.Index]
+			if !ok || req.peer != msg.From() {
+				continue
+			}
+			if int64(len(chunk.Data)) != chunkLength(info, chunk.Index) ||
+				!bytes.Equal(common.Sha3(chunk.Data), info.ChunkHashes[chunk.Index]) {
+				ilog.Warnf("invalid snapshot chunk from %v, index: %v", msg.From().Pretty(), chunk.Index)
+				c.p2pService.PutPeerToBlack(msg.From().Pretty())
+				dropPeer(msg.From())
+				continue
+			}
+			_, err = f.WriteAt(chunk.Data, chunk.Index*info.ChunkSize)
+			if err != nil {
+				return err
+			}
+			delete(inflight, chunk.Index)
+			peers[msg.From()]--
+			done++
+			if done%printInterval == 0 {
+				ilog.Infof("state sync downloaded %v/%v chunks", done, len(info.ChunkHashes))
+			}
+		case <-ticker.C:
+			now := time.Now()
+			for index, req := range inflight {
+				if now.Before(req.deadline) {
+					continue
+				}
+				delete(inflight, index)
+				pending = append(pending, index)
+				if _, ok := peers[req.peer]; !ok {
+					continue
+				}
+				peers[req.peer]--
+				failed[req.peer]++
+				if failed[req.peer] >= maxFailedPerPeer {
+					ilog.Infof("drop peer %v from state sync, too many timeouts", req.peer.Pretty())
+					dropPeer(req.peer)
+				}
+			}
+		}
+	}
+	return f.Sync()
+}
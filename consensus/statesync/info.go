@@ -0,0 +1,92 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+)
+
+var (
+	chunkSize    int64 = 1 << 20
+	maxChunkSize int64 = 4 << 20
+	hashLength         = 32
+)
+
+var (
+	errInvalidInfo = errors.New("invalid snapshot info")
+)
+
+// newInfo returns the SnapshotInfo of the snapshot file, the file is split into chunks of size chunkSize.
+func newInfo(path string, storageType kv.StorageType, cacheType mvcc.CacheType) (*msgpb.SnapshotInfo, error) {
+	blk, err := snapshot.ReadHead(path, storageType, cacheType)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info := &msgpb.SnapshotInfo{
+		Number:    blk.Head.Number,
+		BlockHash: blk.HeadHash(),
+		ChunkSize: chunkSize,
+	}
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if n > 0 {
+			info.ChunkHashes = append(info.ChunkHashes, common.Sha3(buf[:n]))
+			info.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// verifyInfo checks that the chunks described by info cover exactly the snapshot file.
+func verifyInfo(info *msgpb.SnapshotInfo) error {
+	if info.Number < 0 || len(info.BlockHash) != hashLength {
+		return errInvalidInfo
+	}
+	if info.Size <= 0 || info.ChunkSize <= 0 || info.ChunkSize > maxChunkSize {
+		return errInvalidInfo
+	}
+	if int64(len(info.ChunkHashes)) != (info.Size+info.ChunkSize-1)/info.ChunkSize {
+		return errInvalidInfo
+	}
+	for _, h := range info.ChunkHashes {
+		if len(h) != hashLength {
+			return errInvalidInfo
+		}
+	}
+	return nil
+}
+
+// chunkLength returns the length of the chunk at index.
+func chunkLength(info *msgpb.SnapshotInfo, index int64) int64 {
+	if index == int64(len(info.ChunkHashes))-1 {
+		return info.Size - index*info.ChunkSize
+	}
+	return info.ChunkSize
+}
+
+// infoKey identifies the snapshot, peers advertising the same key serve the same file.
+func infoKey(info *msgpb.SnapshotInfo) string {
+	return fmt.Sprintf("%d/%s/%s", info.Number, common.Base58Encode(info.BlockHash),
+		common.Base58Encode(common.Sha3(bytes.Join(info.ChunkHashes, nil))))
+}
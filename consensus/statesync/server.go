@@ -0,0 +1,207 @@
+package statesync
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/p2p"
+)
+
+var (
+	refreshInterval = time.Minute
+)
+
+// Server serves the local snapshot files to the peers doing state sync.
+type Server struct {
+	p2pService  p2p.Service
+	files       func() []string
+	storageType kv.StorageType
+	cacheType   mvcc.CacheType
+
+	served map[string]*servedFile
+	mu     sync.RWMutex
+
+	msgCh      chan p2p.IncomingMessage
+	exitSignal chan struct{}
+	wg         *sync.WaitGroup
+}
+
+// servedFile is a snapshot file served, identified by its block hash.
+type servedFile struct {
+	path    string
+	info    *msgpb.SnapshotInfo
+	modTime time.Time
+}
+
+// NewServer returns a Server serving the snapshot files returned by files, the missing ones are skipped.
+// The state db in a tar.gz snapshot is read on the storage of storageType.
+func NewServer(files func() []string, storageType kv.StorageType, cacheType mvcc.CacheType, p2pService p2p.Service) *Server {
+	return &Server{
+		p2pService:  p2pService,
+		files:       files,
+		storageType: storageType,
+		cacheType:   cacheType,
+		served:      make(map[string]*servedFile),
+		msgCh:       p2pService.Register("state sync server", p2p.SnapshotInfoRequest, p2p.SnapshotChunkRequest),
+		exitSignal:  make(chan struct{}),
+		wg:          new(sync.WaitGroup),
+	}
+}
+
+// Start starts the server.
+func (s *Server) Start() error {
+	s.wg.Add(2)
+	go s.refreshLoop()
+	go s.messageLoop()
+	return nil
+}
+
+// Stop stops the server.
+func (s *Server) Stop() {
+	close(s.exitSignal)
+	s.wg.Wait()
+	s.p2pService.Deregister("state sync server", p2p.SnapshotInfoRequest, p2p.SnapshotChunkRequest)
+}
+
+// Infos returns the infos of the served snapshots, the latest first.
+func (s *Server) Infos() []*msgpb.SnapshotInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	infos := make([]*msgpb.SnapshotInfo, 0, len(s.served))
+	for _, f := range s.served {
+		infos = append(infos, f.info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Number > infos[j].Number })
+	return infos
+}
+
+func (s *Server) get(blockHash []byte) *servedFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.served[string(blockHash)]
+}
+
+// refresh rebuilds the snapshot infos of the files changed.
+func (s *Server) refresh() {
+	old := make(map[string]*servedFile)
+	s.mu.RLock()
+	for _, f := range s.served {
+		old[f.path] = f
+	}
+	s.mu.RUnlock()
+
+	served := make(map[string]*servedFile)
+	for _, path := range s.files() {
+		fi, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		f, ok := old[path]
+		if !ok || !fi.ModTime().Equal(f.modTime) {
+			info, err := newInfo(path, s.storageType, s.cacheType)
+			if err != nil {
+				ilog.Warnf("read snapshot info failed. path=%v, err=%v", path, err)
+				continue
+			}
+			f = &servedFile{path: path, info: info, modTime: fi.ModTime()}
+			ilog.Infof("serving snapshot, number: %v, size: %v", info.Number, info.Size)
+		}
+		served[string(f.info.BlockHash)] = f
+	}
+	s.mu.Lock()
+	s.served = served
+	s.mu.Unlock()
+}
+
+func (s *Server) refreshLoop() {
+	defer s.wg.Done()
+	s.refresh()
+	for {
+		select {
+		case <-time.After(refreshInterval):
+			s.refresh()
+		case <-s.exitSignal:
+			return
+		}
+	}
+}
+
+func (s *Server) messageLoop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case req := <-s.msgCh:
+			switch req.Type() {
+			case p2p.SnapshotInfoRequest:
+				s.handleInfoRequest(req.From())
+			case p2p.SnapshotChunkRequest:
+				var cr msgpb.SnapshotChunkRequest
+				err := proto.Unmarshal(req.Data(), &cr)
+				if err != nil {
+					continue
+				}
+				s.handleChunkRequest(&cr, req.From())
+			}
+		case <-s.exitSignal:
+			return
+		}
+	}
+}
+
+func (s *Server) handleInfoRequest(peerID p2p.PeerID) {
+	for _, info := range s.Infos() {
+		b, err := proto.Marshal(info)
+		if err != nil {
+			ilog.Errorf("marshal snapshot info failed. err=%v", err)
+			return
+		}
+		s.p2pService.SendToPeer(peerID, b, p2p.SnapshotInfoResponse, p2p.NormalMessage)
+	}
+}
+
+func (s *Server) handleChunkRequest(cr *msgpb.SnapshotChunkRequest, peerID p2p.PeerID) {
+	served := s.get(cr.BlockHash)
+	if served == nil {
+		return
+	}
+	info := served.info
+	if cr.Index < 0 || cr.Index >= int64(len(info.ChunkHashes)) {
+		return
+	}
+	f, err := os.Open(served.path)
+	if err != nil {
+		ilog.Errorf("open snapshot failed. err=%v", err)
+		return
+	}
+	defer f.Close()
+	data := make([]byte, chunkLength(info, cr.Index))
+	_, err = f.ReadAt(data, cr.Index*info.ChunkSize)
+	if err != nil {
+		ilog.Errorf("read snapshot chunk failed. index=%v, err=%v", cr.Index, err)
+		return
+	}
+	// The file has been replaced, don't send chunks the peer will reject.
+	if !bytes.Equal(common.Sha3(data), info.ChunkHashes[cr.Index]) {
+		ilog.Warnf("snapshot file changed, index=%v", cr.Index)
+		return
+	}
+	b, err := proto.Marshal(&msgpb.SnapshotChunk{
+		BlockHash: cr.BlockHash,
+		Index:     cr.Index,
+		Data:      data,
+	})
+	if err != nil {
+		ilog.Errorf("marshal snapshot chunk failed. err=%v", err)
+		return
+	}
+	s.p2pService.SendToPeer(peerID, b, p2p.SnapshotChunkResponse, p2p.NormalMessage)
+}
@@ -0,0 +1,264 @@
+package statesync
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/checkpoint"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNet delivers messages between fakeServices in memory.
+type fakeNet struct {
+	nodes map[p2p.PeerID]*fakeService
+	mu    sync.Mutex
+}
+
+type fakeService struct {
+	p2p.NopService
+	id    p2p.PeerID
+	net   *fakeNet
+	subs  map[p2p.MessageType]chan p2p.IncomingMessage
+	black []string
+	mu    sync.Mutex
+}
+
+func (n *fakeNet) join(id string) *fakeService {
+	s := &fakeService{
+		id:   p2p.PeerID(id),
+		net:  n,
+		subs: make(map[p2p.MessageType]chan p2p.IncomingMessage),
+	}
+	n.mu.Lock()
+	n.nodes[s.id] = s
+	n.mu.Unlock()
+	return s
+}
+
+func (s *fakeService) deliver(from p2p.PeerID, data []byte, typ p2p.MessageType) {
+	s.mu.Lock()
+	ch, ok := s.subs[typ]
+	s.mu.Unlock()
+	if ok {
+		ch <- *p2p.NewIncomingMessage(from, data, typ)
+	}
+}
+
+func (s *fakeService) Register(id string, typs ...p2p.MessageType) chan p2p.IncomingMessage {
+	ch := make(chan p2p.IncomingMessage, 1024)
+	s.mu.Lock()
+	for _, typ := range typs {
+		s.subs[typ] = ch
+	}
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *fakeService) Deregister(id string, typs ...p2p.MessageType) {
+	s.mu.Lock()
+	for _, typ := range typs {
+		delete(s.subs, typ)
+	}
+	s.mu.Unlock()
+}
+
+func (s *fakeService) Broadcast(data []byte, typ p2p.MessageType, _ p2p.MessagePriority) {
+	s.net.mu.Lock()
+	defer s.net.mu.Unlock()
+	for id, node := range s.net.nodes {
+		if id != s.id {
+			go node.deliver(s.id, data, typ)
+		}
+	}
+}
+
+func (s *fakeService) SendToPeer(id p2p.PeerID, data []byte, typ p2p.MessageType, _ p2p.MessagePriority) {
+	s.net.mu.Lock()
+	defer s.net.mu.Unlock()
+	if node, ok := s.net.nodes[id]; ok {
+		go node.deliver(s.id, data, typ)
+	}
+}
+
+func (s *fakeService) PutPeerToBlack(id string) {
+	s.mu.Lock()
+	s.black = append(s.black, id)
+	s.mu.Unlock()
+}
+
+// newSnapshot writes the snapshot of the block of number in dir, the state of the tampered one mismatches the
+// state root of its block.
+func newSnapshot(t *testing.T, dir string, number int64, tampered bool) *block.Block {
+	stateDB, err := db.NewMVCCDB(filepath.Join(dir, "StateDB"))
+	assert.Nil(t, err)
+	for i := 0; i < 1000; i++ {
+		err = stateDB.Put("state", common.Base58Encode(common.Sha3([]byte{byte(i), byte(i >> 8)})), string(randBytes(64)))
+		assert.Nil(t, err)
+	}
+	blk := &block.Block{Head: &block.BlockHead{Version: block.VersionOf(number), Number: number, Witness: "witness", Time: time.Now().UnixNano()}}
+	if blk.Head.Version >= block.V1 {
+		blk.Head.StateRoot, err = stateDB.UpdateStateTree("state", true)
+		assert.Nil(t, err)
+	}
+	if tampered {
+		assert.Nil(t, stateDB.Put("state", "tampered", "value"))
+	}
+	assert.Nil(t, blk.CalculateHeadHash())
+	assert.Nil(t, snapshot.Save(stateDB, blk))
+	stateDB.Commit(string(blk.HeadHash()))
+	assert.Nil(t, stateDB.Flush(string(blk.HeadHash())))
+	stateDB.Close()
+	conf := &common.Config{DB: &common.DBConfig{LdbPath: dir + "/"}}
+	assert.Nil(t, snapshot.ToSnapshot(conf))
+	return blk
+}
+
+func randBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+func TestStateSync(t *testing.T) {
+	originChunkSize, originCheckInterval := chunkSize, checkInterval
+	chunkSize, discoverInterval, checkInterval = 4096, 100*time.Millisecond, 100*time.Millisecond
+	defer func() {
+		chunkSize, discoverInterval, checkInterval = originChunkSize, 3*time.Second, originCheckInterval
+	}()
+
+	dir, err := ioutil.TempDir("", "statesync")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	blk := newSnapshot(t, dir, 100, false)
+	path := filepath.Join(dir, snapshot.FileName)
+
+	// The newer snapshot isn't downloaded as it isn't of a checkpoint.
+	newerDir, err := ioutil.TempDir("", "statesync")
+	assert.Nil(t, err)
+	defer os.RemoveAll(newerDir)
+	newer := newSnapshot(t, newerDir, 200, false)
+	files := func() []string {
+		return []string{path, filepath.Join(newerDir, snapshot.FileName), filepath.Join(dir, "missing")}
+	}
+
+	net := &fakeNet{nodes: make(map[p2p.PeerID]*fakeService)}
+	for _, id := range []string{"server1", "server2"} {
+		s := NewServer(files, kv.LevelDBStorage, mvcc.MapCache, net.join(id))
+		assert.Nil(t, s.Start())
+		defer s.Stop()
+		for len(s.Infos()) < 2 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.True(t, len(s.Infos()[0].ChunkHashes) > 1)
+	}
+
+	// The liar advertises the same snapshot but sends garbage chunks.
+	liar := net.join("liar")
+	liarCh := liar.Register("liar", p2p.SnapshotInfoRequest, p2p.SnapshotChunkRequest)
+	info, err := newInfo(path, kv.LevelDBStorage, mvcc.MapCache)
+	assert.Nil(t, err)
+	go func() {
+		for msg := range liarCh {
+			switch msg.Type() {
+			case p2p.SnapshotInfoRequest:
+				b, _ := proto.Marshal(info)
+				liar.SendToPeer(msg.From(), b, p2p.SnapshotInfoResponse, p2p.NormalMessage)
+			case p2p.SnapshotChunkRequest:
+				var cr msgpb.SnapshotChunkRequest
+				proto.Unmarshal(msg.Data(), &cr)
+				b, _ := proto.Marshal(&msgpb.SnapshotChunk{BlockHash: cr.BlockHash, Index: cr.Index, Data: randBytes(int(chunkLength(info, cr.Index)))})
+				liar.SendToPeer(msg.From(), b, p2p.SnapshotChunkResponse, p2p.NormalMessage)
+			}
+		}
+	}()
+
+	client := net.join("client")
+	target := filepath.Join(dir, "download.tar.gz")
+	_, err = NewClient(3, nil, kv.LevelDBStorage, mvcc.MapCache, client).Fetch(target)
+	assert.Equal(t, errNoCheckpoint, err)
+
+	checkpoints, err := checkpoint.New(&common.CheckpointConfig{Blocks: []*common.Checkpoint{
+		{Number: blk.Head.Number, Hash: common.Base58Encode(blk.HeadHash())},
+		{Number: newer.Head.Number + 1, Hash: common.Base58Encode(newer.HeadHash())},
+	}})
+	assert.Nil(t, err)
+	got, err := NewClient(3, checkpoints, kv.LevelDBStorage, mvcc.MapCache, client).Fetch(target)
+	assert.Nil(t, err)
+	assert.Equal(t, blk.Head.Number, got.Number)
+	assert.Equal(t, blk.HeadHash(), got.BlockHash)
+	assert.Equal(t, []string{p2p.PeerID("liar").Pretty()}, client.black)
+
+	b1, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	b2, err := ioutil.ReadFile(target)
+	assert.Nil(t, err)
+	assert.True(t, bytes.Equal(b1, b2))
+}
+
+func TestVerifyInfo(t *testing.T) {
+	info := &msgpb.SnapshotInfo{
+		Number:      1,
+		BlockHash:   make([]byte, 32),
+		Size:        10,
+		ChunkSize:   4,
+		ChunkHashes: [][]byte{make([]byte, 32), make([]byte, 32), make([]byte, 32)},
+	}
+	assert.Nil(t, verifyInfo(info))
+	assert.Equal(t, int64(2), chunkLength(info, 2))
+
+	info.Size = 13
+	assert.Equal(t, errInvalidInfo, verifyInfo(info))
+	info.Size = 10
+	info.ChunkSize = maxChunkSize + 1
+	assert.Equal(t, errInvalidInfo, verifyInfo(info))
+}
+
+func TestStateSyncStateRoot(t *testing.T) {
+	originCheckInterval := checkInterval
+	discoverInterval, checkInterval = 100*time.Millisecond, 100*time.Millisecond
+	block.StateRootNumber = 50
+	defer func() {
+		discoverInterval, checkInterval = 3*time.Second, originCheckInterval
+		block.StateRootNumber = 0
+	}()
+
+	net := &fakeNet{nodes: make(map[p2p.PeerID]*fakeService)}
+	client := net.join("client")
+	for _, tampered := range []bool{false, true} {
+		dir, err := ioutil.TempDir("", "statesync")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dir)
+		blk := newSnapshot(t, dir, 100, tampered)
+		s := NewServer(func() []string {
+			return []string{filepath.Join(dir, snapshot.FileName)}
+		}, kv.LevelDBStorage, mvcc.MapCache, net.join(dir))
+		assert.Nil(t, s.Start())
+		defer s.Stop()
+
+		checkpoints, err := checkpoint.New(&common.CheckpointConfig{Blocks: []*common.Checkpoint{
+			{Number: blk.Head.Number, Hash: common.Base58Encode(blk.HeadHash())},
+		}})
+		assert.Nil(t, err)
+		_, err = NewClient(1, checkpoints, kv.LevelDBStorage, mvcc.MapCache, client).Fetch(filepath.Join(dir, "download.tar.gz"))
+		if tampered {
+			assert.Equal(t, errStateRoot, err)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
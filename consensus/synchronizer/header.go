@@ -0,0 +1,201 @@
+package synchronizer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/p2p"
+)
+
+var (
+	errHeaderDecode    = errors.New("fail to decode header")
+	errHeaderSignature = errors.New("header signature error")
+	errHeaderParent    = errors.New("header parent hash error")
+	errHeaderRange     = errors.New("header not requested")
+)
+
+// encodeHeader returns the bytes of the block with only the head and the sign.
+func encodeHeader(blk *block.Block) ([]byte, error) {
+	return (&block.Block{Head: blk.Head, Sign: blk.Sign}).Encode()
+}
+
+// verifyHeaders checks the headers sorted by number: the witness scheduled in the slot of each header, the witness
+// signature, and the parent hash of consecutive headers. The witness lists change as pob does, the pending list
+// becomes active at the first header scheduled by it but not by the active one. It returns the number of the leading
+// headers verified, the headers scheduled by neither list are left until the lists are known from the blocks before.
+func verifyHeaders(headers []*block.Block, active, pending []string) (int, error) {
+	n := 0
+	for _, blk := range headers {
+		if len(active) == 0 {
+			break
+		}
+		if witnessOfNanoSec(blk.Head.Time, active) != blk.Head.Witness {
+			if len(pending) == 0 || witnessOfNanoSec(blk.Head.Time, pending) != blk.Head.Witness {
+				break
+			}
+			active, pending = pending, nil
+		}
+		n++
+	}
+	headers = headers[:n]
+
+	sigs := make([]*crypto.Signature, 0, len(headers))
+	infos := make([][]byte, 0, len(headers))
+	for _, blk := range headers {
+		sign := *blk.Sign
+		sign.SetPubkey(account.DecodePubkey(blk.Head.Witness))
+		sigs = append(sigs, &sign)
+		infos = append(infos, blk.HeadHash())
+	}
+	for _, ok := range crypto.BatchVerify(sigs, infos) {
+		if !ok {
+			return 0, errHeaderSignature
+		}
+	}
+	for i, blk := range headers {
+		if i > 0 && headers[i-1].Head.Number+1 == blk.Head.Number &&
+			!bytes.Equal(headers[i-1].HeadHash(), blk.Head.ParentHash) {
+			return 0, errHeaderParent
+		}
+	}
+	return n, nil
+}
+
+func witnessOfNanoSec(nanosec int64, witnessList []string) string {
+	slot := nanosec / 1e9 / common.SlotLength
+	return witnessList[slot%int64(len(witnessList))]
+}
+
+func (sy *SyncImpl) queryBlockHeader(hr *msgpb.BlockHashQuery) {
+	bytes, err := proto.Marshal(hr)
+	if err != nil {
+		ilog.Errorf("marshal blockhashquery failed. err=%v", err)
+		return
+	}
+	ilog.Debugf("[sync] request block header. reqtype=%v, start=%v, end=%v, nums size=%v", hr.ReqType, hr.Start, hr.End, len(hr.Nums))
+	sy.p2pService.Broadcast(bytes, p2p.SyncHeaderRequest, p2p.UrgentMessage)
+}
+
+func (sy *SyncImpl) getBlockHeader(num int64) (*block.Block, error) {
+	blk, err := sy.blockCache.GetBlockByNumber(num)
+	if err == nil {
+		return blk, nil
+	}
+	return sy.baseVariable.BlockChain().GetBlockHeadByNumber(num)
+}
+
+func (sy *SyncImpl) handleHeaderQuery(rh *msgpb.BlockHashQuery, peerID p2p.PeerID) {
+	if rh.End < rh.Start || rh.Start < 0 {
+		return
+	}
+	var nums []int64
+	switch rh.ReqType {
+	case msgpb.RequireType_GETBLOCKHASHES:
+		if rh.End-rh.Start >= maxBlockHashQueryNumber {
+			return
+		}
+		for i := rh.Start; i <= rh.End; i++ {
+			nums = append(nums, i)
+		}
+	case msgpb.RequireType_GETBLOCKHASHESBYNUMBER:
+		nums = rh.Nums
+	}
+
+	resp := &msgpb.BlockHeaders{}
+	for _, num := range nums {
+		// The peer downloads the bodies from the peers sending the headers.
+		if sy.isPruned(num) {
+			continue
+		}
+		blk, err := sy.getBlockHeader(num)
+		if err != nil {
+			continue
+		}
+		b, err := encodeHeader(blk)
+		if err != nil {
+			ilog.Errorf("encode block header failed. number=%v, err=%v", num, err)
+			continue
+		}
+		resp.Headers = append(resp.Headers, b)
+	}
+	if len(resp.Headers) == 0 {
+		return
+	}
+	bytes, err := proto.Marshal(resp)
+	if err != nil {
+		ilog.Errorf("marshal BlockHeaders failed. err=%v", err)
+		return
+	}
+	sy.p2pService.SendToPeer(peerID, bytes, p2p.SyncHeaderResponse, p2p.NormalMessage)
+}
+
+// handleHeaderResp verifies the headers against the witness lists of the LIB before creating the missions of
+// downloading their bodies. A peer sending an invalid header is penalized and none of its headers are used.
+func (sy *SyncImpl) handleHeaderResp(bh *msgpb.BlockHeaders, peerID p2p.PeerID) {
+	ilog.Debugf("receive block headers: len=%v", len(bh.Headers))
+	headers := make([]*block.Block, 0, len(bh.Headers))
+	for _, b := range bh.Headers {
+		blk := &block.Block{}
+		err := blk.Decode(b)
+		if err != nil {
+			sy.scores.penalize(peerID, errHeaderDecode.Error())
+			return
+		}
+		headers = append(headers, blk)
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Head.Number < headers[j].Head.Number
+	})
+	root := sy.blockCache.LinkedRoot()
+	n, err := verifyHeaders(headers, root.Active(), root.Pending())
+	if err != nil {
+		sy.scores.penalize(peerID, err.Error())
+		return
+	}
+	if n < len(headers) {
+		ilog.Debugf("[sync] %v headers from %v aren't scheduled by the known witness lists", len(headers)-n, headers[n].Head.Number)
+	}
+	headers = headers[:n]
+	for _, blk := range headers {
+		if err := sy.checkpoints.Check(blk.Head.Number, blk.HeadHash()); err != nil {
+			sy.scores.penalize(peerID, err.Error())
+			return
+		}
+		if _, ok := sy.reqMap.Load(blk.Head.Number); !ok && blk.Head.Number > sy.syncEnd.Load() {
+			sy.scores.penalize(peerID, fmt.Sprintf("%v: %v", errHeaderRange, blk.Head.Number))
+			return
+		}
+	}
+	for _, blk := range headers {
+		if blk.Head.Number > sy.blockCache.LinkedRoot().Head.Number {
+			sy.dc.CreateMission(string(blk.HeadHash()), blk.Head.Number, peerID)
+		}
+		sy.reqMap.Delete(blk.Head.Number)
+	}
+}
+
+// handleSyncBlock checks the block body sent by a peer against its verified header.
+func (sy *SyncImpl) handleSyncBlock(data []byte, peerID p2p.PeerID) {
+	var blk block.Block
+	err := blk.Decode(data)
+	if err != nil {
+		sy.scores.penalize(peerID, err.Error())
+		return
+	}
+	if !sy.scores.response(peerID, string(blk.HeadHash())) {
+		return
+	}
+	if !bytes.Equal(blk.CalculateTxMerkleHash(), blk.Head.TxMerkleHash) ||
+		!bytes.Equal(blk.CalculateTxReceiptMerkleHash(), blk.Head.TxReceiptMerkleHash) {
+		sy.scores.penalize(peerID, "block body doesn't match the header")
+	}
+}
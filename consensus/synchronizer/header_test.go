@@ -0,0 +1,123 @@
+package synchronizer
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/p2p"
+	p2p_mock "github.com/iost-official/go-iost/p2p/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func witnessKeys(t *testing.T, n int) (map[string]*account.KeyPair, []string) {
+	keys := make(map[string]*account.KeyPair)
+	witnesses := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		acc, err := account.NewKeyPair(nil, crypto.Ed25519)
+		assert.Nil(t, err)
+		keys[acc.ReadablePubkey()] = acc
+		witnesses = append(witnesses, acc.ReadablePubkey())
+	}
+	return keys, witnesses
+}
+
+// signedHeaders returns the headers of the consecutive slots, each one signed by the witness scheduled by its list.
+func signedHeaders(t *testing.T, keys map[string]*account.KeyPair, lists ...[]string) []*block.Block {
+	headers := make([]*block.Block, 0, len(lists))
+	parent := []byte("parent")
+	for i, list := range lists {
+		slotTime := int64(i+1) * common.SlotLength * 1e9
+		blk := &block.Block{
+			Head: &block.BlockHead{
+				ParentHash: parent,
+				Number:     int64(i + 1),
+				Witness:    witnessOfNanoSec(slotTime, list),
+				Time:       slotTime,
+			},
+		}
+		assert.Nil(t, blk.CalculateHeadHash())
+		blk.Sign = keys[blk.Head.Witness].Sign(blk.HeadHash())
+		b, err := encodeHeader(blk)
+		assert.Nil(t, err)
+		header := &block.Block{}
+		assert.Nil(t, header.Decode(b))
+		headers = append(headers, header)
+		parent = blk.HeadHash()
+	}
+	return headers
+}
+
+func TestVerifyHeaders(t *testing.T) {
+	keys, witnesses := witnessKeys(t, 4)
+	active, pending := witnesses[:2], witnesses[2:]
+
+	headers := signedHeaders(t, keys, active, active, active, active, active)
+	n, err := verifyHeaders(headers, active, active)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, n)
+	n, err = verifyHeaders(append(headers[:2:2], headers[3:]...), active, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+
+	fork := signedHeaders(t, keys, active, active)
+	fork[1].Head.ParentHash = []byte("fork")
+	assert.Nil(t, fork[1].CalculateHeadHash())
+	fork[1].Sign = keys[fork[1].Head.Witness].Sign(fork[1].HeadHash())
+	_, err = verifyHeaders([]*block.Block{headers[0], fork[1]}, active, nil)
+	assert.Equal(t, errHeaderParent, err)
+
+	// A header signed by a key out of the lists isn't verified, even if it names the key as the witness.
+	outsiders, others := witnessKeys(t, 1)
+	self := signedHeaders(t, outsiders, others, others)
+	n, err = verifyHeaders(self, active, pending)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	// The pending list becomes active at the first header scheduled by it.
+	changed := signedHeaders(t, keys, active, active, pending, pending, active)
+	n, err = verifyHeaders(changed, active, pending)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+	n, err = verifyHeaders(changed, active, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	headers[3].Sign.Sig = []byte("invalid")
+	_, err = verifyHeaders(headers, active, nil)
+	assert.Equal(t, errHeaderSignature, err)
+
+	assert.NotNil(t, (&block.Block{}).Decode([]byte{}))
+}
+
+func TestPeerScores(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	service := p2p_mock.NewMockService(ctl)
+	bad := p2p.PeerID("bad")
+	service.EXPECT().PutPeerToBlack(bad.Pretty()).Times(1)
+
+	s := newPeerScores(service)
+	good, slow := p2p.PeerID("good"), p2p.PeerID("slow")
+	assert.Equal(t, peerConNum/2, s.get(good).capacity())
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, s.request(good, string(rune('a'+i))))
+		assert.True(t, s.response(good, string(rune('a'+i))))
+		assert.True(t, s.request(slow, string(rune('a'+i))))
+	}
+	assert.False(t, s.response(good, "unknown"))
+	s.expire(0)
+	assert.True(t, s.get(good).capacity() > s.get(slow).capacity())
+	assert.Equal(t, 1, s.get(slow).capacity())
+	assert.True(t, s.request(slow, "x"))
+	assert.False(t, s.request(slow, "y"))
+
+	for i := int64(0); i < maxInvalid+1; i++ {
+		s.penalize(bad, "test")
+	}
+	assert.False(t, s.request(bad, "z"))
+}
@@ -194,6 +194,45 @@ func (m *BlockHashResponse) GetBlockInfos() []*BlockInfo {
 	return nil
 }
 
+type BlockHeaders struct {
+	Headers              [][]byte `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeaders) Reset()         { *m = BlockHeaders{} }
+func (m *BlockHeaders) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaders) ProtoMessage()    {}
+func (*BlockHeaders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e960d3736d18fa7, []int{3}
+}
+
+func (m *BlockHeaders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaders.Unmarshal(m, b)
+}
+func (m *BlockHeaders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaders.Marshal(b, m, deterministic)
+}
+func (m *BlockHeaders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaders.Merge(m, src)
+}
+func (m *BlockHeaders) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaders.Size(m)
+}
+func (m *BlockHeaders) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaders.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaders proto.InternalMessageInfo
+
+func (m *BlockHeaders) GetHeaders() [][]byte {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 type SyncHeight struct {
 	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
@@ -206,7 +245,7 @@ func (m *SyncHeight) Reset()         { *m = SyncHeight{} }
 func (m *SyncHeight) String() string { return proto.CompactTextString(m) }
 func (*SyncHeight) ProtoMessage()    {}
 func (*SyncHeight) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1e960d3736d18fa7, []int{3}
+	return fileDescriptor_1e960d3736d18fa7, []int{4}
 }
 
 func (m *SyncHeight) XXX_Unmarshal(b []byte) error {
@@ -241,12 +280,189 @@ func (m *SyncHeight) GetTime() int64 {
 	return 0
 }
 
+type SnapshotInfo struct {
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ChunkSize            int64    `protobuf:"varint,4,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	ChunkHashes          [][]byte `protobuf:"bytes,5,rep,name=chunkHashes,proto3" json:"chunkHashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotInfo) Reset()         { *m = SnapshotInfo{} }
+func (m *SnapshotInfo) String() string { return proto.CompactTextString(m) }
+func (*SnapshotInfo) ProtoMessage()    {}
+func (*SnapshotInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e960d3736d18fa7, []int{5}
+}
+
+func (m *SnapshotInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotInfo.Unmarshal(m, b)
+}
+func (m *SnapshotInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotInfo.Marshal(b, m, deterministic)
+}
+func (m *SnapshotInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotInfo.Merge(m, src)
+}
+func (m *SnapshotInfo) XXX_Size() int {
+	return xxx_messageInfo_SnapshotInfo.Size(m)
+}
+func (m *SnapshotInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotInfo proto.InternalMessageInfo
+
+func (m *SnapshotInfo) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *SnapshotInfo) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *SnapshotInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *SnapshotInfo) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *SnapshotInfo) GetChunkHashes() [][]byte {
+	if m != nil {
+		return m.ChunkHashes
+	}
+	return nil
+}
+
+type SnapshotChunkRequest struct {
+	BlockHash            []byte   `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Index                int64    `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotChunkRequest) Reset()         { *m = SnapshotChunkRequest{} }
+func (m *SnapshotChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunkRequest) ProtoMessage()    {}
+func (*SnapshotChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e960d3736d18fa7, []int{6}
+}
+
+func (m *SnapshotChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotChunkRequest.Unmarshal(m, b)
+}
+func (m *SnapshotChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotChunkRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunkRequest.Merge(m, src)
+}
+func (m *SnapshotChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotChunkRequest.Size(m)
+}
+func (m *SnapshotChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunkRequest proto.InternalMessageInfo
+
+func (m *SnapshotChunkRequest) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *SnapshotChunkRequest) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type SnapshotChunk struct {
+	BlockHash            []byte   `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Index                int64    `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
+func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunk) ProtoMessage()    {}
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e960d3736d18fa7, []int{7}
+}
+
+func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotChunk.Unmarshal(m, b)
+}
+func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotChunk.Marshal(b, m, deterministic)
+}
+func (m *SnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunk.Merge(m, src)
+}
+func (m *SnapshotChunk) XXX_Size() int {
+	return xxx_messageInfo_SnapshotChunk.Size(m)
+}
+func (m *SnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+
+func (m *SnapshotChunk) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("msgpb.RequireType", RequireType_name, RequireType_value)
 	proto.RegisterType((*BlockInfo)(nil), "msgpb.BlockInfo")
 	proto.RegisterType((*BlockHashQuery)(nil), "msgpb.BlockHashQuery")
 	proto.RegisterType((*BlockHashResponse)(nil), "msgpb.BlockHashResponse")
+	proto.RegisterType((*BlockHeaders)(nil), "msgpb.BlockHeaders")
 	proto.RegisterType((*SyncHeight)(nil), "msgpb.SyncHeight")
+	proto.RegisterType((*SnapshotInfo)(nil), "msgpb.SnapshotInfo")
+	proto.RegisterType((*SnapshotChunkRequest)(nil), "msgpb.SnapshotChunkRequest")
+	proto.RegisterType((*SnapshotChunk)(nil), "msgpb.SnapshotChunk")
 }
 
 func init() {
@@ -254,25 +470,32 @@ func init() {
 }
 
 var fileDescriptor_1e960d3736d18fa7 = []byte{
-	// 308 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xcd, 0x4f, 0x83, 0x40,
-	0x10, 0xc5, 0xc5, 0x6d, 0x6b, 0x9c, 0x9a, 0x06, 0x37, 0xa6, 0x21, 0x9e, 0x08, 0x17, 0x89, 0x31,
-	0xad, 0xa9, 0x07, 0xbd, 0x78, 0x10, 0x43, 0xc4, 0xf8, 0x15, 0xb7, 0xf5, 0xe0, 0x11, 0x70, 0x2c,
-	0x44, 0x59, 0xe8, 0x0e, 0x1c, 0xe8, 0x5f, 0x6f, 0x76, 0xfb, 0x91, 0x7a, 0x7b, 0x6f, 0x98, 0xe1,
-	0xbd, 0x5f, 0x16, 0xce, 0xd2, 0x52, 0x12, 0x4a, 0x6a, 0x68, 0x4c, 0xad, 0x4c, 0x33, 0x55, 0xca,
-	0x7c, 0x89, 0x6a, 0x5c, 0x25, 0xe3, 0x02, 0x89, 0xe2, 0x39, 0x8e, 0x2a, 0x55, 0xd6, 0x25, 0xef,
-	0x16, 0x34, 0xaf, 0x12, 0xef, 0x1a, 0x0e, 0x83, 0xdf, 0x32, 0xfd, 0x79, 0x94, 0xdf, 0x25, 0x1f,
-	0x42, 0x4f, 0x36, 0x45, 0x82, 0xca, 0xb1, 0x5c, 0xcb, 0x67, 0x62, 0xed, 0x38, 0x87, 0x4e, 0x16,
-	0x53, 0xe6, 0xec, 0xbb, 0x96, 0x7f, 0x24, 0x8c, 0xf6, 0x96, 0x30, 0x30, 0x87, 0x51, 0x4c, 0xd9,
-	0x7b, 0x83, 0xaa, 0xe5, 0x17, 0x70, 0xa0, 0x70, 0x31, 0x6b, 0x2b, 0x34, 0xe7, 0x83, 0x09, 0x1f,
-	0x99, 0x8c, 0x91, 0xc0, 0x45, 0x93, 0x2b, 0xd4, 0x5f, 0xc4, 0x66, 0x85, 0x9f, 0x40, 0x97, 0xea,
-	0x58, 0xd5, 0xe6, 0xa7, 0x4c, 0xac, 0x0c, 0xb7, 0x81, 0xa1, 0xfc, 0x72, 0x98, 0x99, 0x69, 0xa9,
-	0xb3, 0x65, 0x53, 0x90, 0xd3, 0x71, 0x99, 0xcf, 0x84, 0xd1, 0x5e, 0x08, 0xc7, 0xdb, 0x6c, 0x81,
-	0x54, 0x69, 0x64, 0x7e, 0x09, 0x90, 0x6c, 0x48, 0xc8, 0xb1, 0x5c, 0xe6, 0xf7, 0x27, 0xf6, 0xba,
-	0xc1, 0x16, 0x51, 0xec, 0xec, 0x78, 0x37, 0x00, 0xd3, 0x56, 0xa6, 0x11, 0xe6, 0xf3, 0xac, 0xd6,
-	0xf0, 0x99, 0x51, 0x1b, 0xf8, 0x95, 0xd3, 0x05, 0xea, 0xbc, 0xc0, 0x75, 0x4f, 0xa3, 0xcf, 0x6f,
-	0xa1, 0xbf, 0x03, 0xc5, 0x39, 0x0c, 0x1e, 0xc2, 0x59, 0xf0, 0xfc, 0x76, 0xff, 0x14, 0xdd, 0x4d,
-	0xa3, 0x70, 0x6a, 0xef, 0xf1, 0x53, 0x18, 0xfe, 0x9f, 0x05, 0x9f, 0xaf, 0x1f, 0x2f, 0x41, 0x28,
-	0x6c, 0x2b, 0xe9, 0x99, 0x27, 0xb8, 0xfa, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x3f, 0x0f, 0x48, 0xd4,
-	0xad, 0x01, 0x00, 0x00,
+	// 432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xc5, 0x38, 0x69, 0xd5, 0x89, 0x89, 0xc2, 0x2a, 0xaa, 0x2c, 0xc4, 0xc1, 0xf2, 0x05, 0x0b,
+	0xa1, 0x04, 0x95, 0x03, 0x5c, 0x38, 0x90, 0x2a, 0x22, 0x7c, 0x8b, 0x75, 0x11, 0xe2, 0x68, 0x3b,
+	0x43, 0xd6, 0x2a, 0x5e, 0xbb, 0x3b, 0xb6, 0x44, 0xf2, 0x4b, 0xf8, 0xb9, 0x68, 0xc7, 0x76, 0x93,
+	0x70, 0xe0, 0xd0, 0xdb, 0x7b, 0x6f, 0x77, 0x3e, 0xde, 0xdb, 0x85, 0x27, 0x59, 0xa9, 0x09, 0x35,
+	0x35, 0x34, 0xa7, 0xad, 0xce, 0x94, 0x29, 0x75, 0xbe, 0x43, 0x33, 0xaf, 0xd2, 0x79, 0x81, 0x44,
+	0xc9, 0x06, 0x67, 0x95, 0x29, 0xeb, 0x52, 0x0c, 0x0b, 0xda, 0x54, 0x69, 0xf8, 0x12, 0xce, 0x16,
+	0xbf, 0xca, 0xec, 0xfa, 0x9d, 0xfe, 0x59, 0x8a, 0x73, 0x38, 0xd1, 0x4d, 0x91, 0xa2, 0xf1, 0x9d,
+	0xc0, 0x89, 0x5c, 0xd9, 0x31, 0x21, 0x60, 0xa0, 0x12, 0x52, 0xfe, 0xfd, 0xc0, 0x89, 0x3c, 0xc9,
+	0x38, 0xdc, 0xc1, 0x98, 0x0b, 0x57, 0x09, 0xa9, 0xaf, 0x0d, 0x9a, 0xad, 0x78, 0x06, 0xa7, 0x06,
+	0x6f, 0xae, 0xb6, 0x15, 0x72, 0xf9, 0xf8, 0x42, 0xcc, 0x78, 0xc6, 0x4c, 0xe2, 0x4d, 0x93, 0x1b,
+	0xb4, 0x27, 0xb2, 0xbf, 0x22, 0xa6, 0x30, 0xa4, 0x3a, 0x31, 0x35, 0x37, 0x75, 0x65, 0x4b, 0xc4,
+	0x04, 0x5c, 0xd4, 0x6b, 0xdf, 0x65, 0xcd, 0x42, 0x3b, 0x5b, 0x37, 0x05, 0xf9, 0x83, 0xc0, 0x8d,
+	0x5c, 0xc9, 0x38, 0x5c, 0xc2, 0xc3, 0xdb, 0xd9, 0x12, 0xa9, 0xb2, 0x96, 0xc5, 0x73, 0x80, 0xb4,
+	0x77, 0x42, 0xbe, 0x13, 0xb8, 0xd1, 0xe8, 0x62, 0xd2, 0x6d, 0x70, 0x6b, 0x51, 0x1e, 0xdc, 0x09,
+	0x23, 0xf0, 0xda, 0x36, 0x98, 0xac, 0xd1, 0x90, 0xf0, 0xe1, 0x54, 0xb5, 0x90, 0xcb, 0x3d, 0xd9,
+	0xd3, 0xf0, 0x15, 0x40, 0xbc, 0xd5, 0xd9, 0x0a, 0xf3, 0x8d, 0xaa, 0x6d, 0x4c, 0x8a, 0x51, 0x1f,
+	0x53, 0xcb, 0xec, 0xaa, 0x75, 0x5e, 0x60, 0xe7, 0x88, 0x71, 0xf8, 0xc7, 0x01, 0x2f, 0xd6, 0x49,
+	0x45, 0xaa, 0xac, 0xff, 0x9b, 0xf1, 0x63, 0x38, 0x4b, 0x7b, 0x4f, 0x5d, 0xd0, 0x7b, 0xc1, 0xb6,
+	0xa6, 0x7c, 0x87, 0x5d, 0x30, 0x8c, 0x6d, 0x45, 0xa6, 0x1a, 0x7d, 0x1d, 0xdb, 0x83, 0x01, 0x1f,
+	0xec, 0x05, 0x11, 0xc0, 0x88, 0x89, 0x2d, 0x47, 0xf2, 0x87, 0x6c, 0xe8, 0x50, 0x0a, 0xdf, 0xc3,
+	0xb4, 0xdf, 0xec, 0xd2, 0xca, 0xf6, 0x99, 0x90, 0xea, 0xe3, 0x4d, 0x9c, 0x7f, 0x37, 0x99, 0xc2,
+	0x30, 0xd7, 0x6b, 0xfc, 0xdd, 0xbf, 0x1b, 0x93, 0xf0, 0x3b, 0x3c, 0x38, 0xea, 0x75, 0x97, 0x26,
+	0xd6, 0xe4, 0x3a, 0xa9, 0x13, 0x36, 0xe9, 0x49, 0xc6, 0x4f, 0x5f, 0xc3, 0xe8, 0xe0, 0xfb, 0x08,
+	0x01, 0xe3, 0xb7, 0xcb, 0xab, 0xc5, 0xc7, 0x2f, 0x97, 0x1f, 0x56, 0x6f, 0xe2, 0xd5, 0x32, 0x9e,
+	0xdc, 0x13, 0x8f, 0xe0, 0xfc, 0x58, 0x5b, 0xfc, 0xf8, 0xfc, 0xed, 0xd3, 0x62, 0x29, 0x27, 0x4e,
+	0x7a, 0xc2, 0x9f, 0xfd, 0xc5, 0xdf, 0x01, 0x00, 0xb1, 0x40, 0xab, 0x7f, 0x17, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,142 @@
+package synchronizer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/metrics"
+	"github.com/iost-official/go-iost/p2p"
+)
+
+var (
+	metricsSyncPeerEvent = metrics.NewCounter("iost_sync_peer_event", []string{"type"})
+
+	targetLatency        = 500 * time.Millisecond
+	latencyWeight        = 0.2
+	invalidPenalty int64 = 10
+	maxInvalid     int64 = 3
+)
+
+// peerScore is the download record of a peer.
+type peerScore struct {
+	success  int64
+	failure  int64
+	invalid  int64
+	latency  time.Duration
+	inflight map[string]time.Time
+}
+
+// reliability is the estimated probability that a request to the peer succeeds.
+func (ps *peerScore) reliability() float64 {
+	return float64(ps.success+1) / float64(ps.success+ps.failure+2)
+}
+
+// capacity is the number of requests the peer may have in flight, slow and failing peers get fewer.
+func (ps *peerScore) capacity() int {
+	c := float64(peerConNum) * ps.reliability()
+	if ps.latency > targetLatency {
+		c = c * float64(targetLatency) / float64(ps.latency)
+	}
+	if c < 1 {
+		return 1
+	}
+	return int(c)
+}
+
+// peerScores scores peers on the latency and the failures of block requests,
+// and puts peers serving invalid headers or blocks to black.
+type peerScores struct {
+	p2pService p2p.Service
+	scores     map[p2p.PeerID]*peerScore
+	mu         sync.Mutex
+}
+
+func newPeerScores(p2pService p2p.Service) *peerScores {
+	return &peerScores{
+		p2pService: p2pService,
+		scores:     make(map[p2p.PeerID]*peerScore),
+	}
+}
+
+// get returns the score of the peer. It should be called with lock.
+func (s *peerScores) get(peerID p2p.PeerID) *peerScore {
+	ps, ok := s.scores[peerID]
+	if !ok {
+		ps = &peerScore{inflight: make(map[string]time.Time)}
+		s.scores[peerID] = ps
+	}
+	return ps
+}
+
+// request records a block request to the peer, it returns false if the peer is banned or busy.
+func (s *peerScores) request(peerID p2p.PeerID, hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps := s.get(peerID)
+	if ps.invalid >= maxInvalid || len(ps.inflight) >= ps.capacity() {
+		return false
+	}
+	ps.inflight[hash] = time.Now()
+	return true
+}
+
+// response records that the peer has sent the requested block, it returns false if the block wasn't requested from the peer.
+func (s *peerScores) response(peerID p2p.PeerID, hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps := s.get(peerID)
+	t, ok := ps.inflight[hash]
+	if !ok {
+		return false
+	}
+	delete(ps.inflight, hash)
+	ps.success++
+	latency := time.Since(t)
+	if ps.latency == 0 {
+		ps.latency = latency
+	} else {
+		ps.latency = time.Duration(float64(ps.latency)*(1-latencyWeight) + float64(latency)*latencyWeight)
+	}
+	metricsSyncPeerEvent.Add(1, map[string]string{"type": "success"})
+	return true
+}
+
+// expire counts the requests which have been in flight longer than timeout as failures.
+func (s *peerScores) expire(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ps := range s.scores {
+		for hash, t := range ps.inflight {
+			if time.Since(t) > timeout {
+				delete(ps.inflight, hash)
+				ps.failure++
+				metricsSyncPeerEvent.Add(1, map[string]string{"type": "timeout"})
+			}
+		}
+	}
+}
+
+// penalize records that the peer has sent invalid data, the peer is put to black after maxInvalid times.
+func (s *peerScores) penalize(peerID p2p.PeerID, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps := s.get(peerID)
+	ps.invalid++
+	ps.failure += invalidPenalty
+	metricsSyncPeerEvent.Add(1, map[string]string{"type": "invalid"})
+	ilog.Warnf("peer %v sent invalid data: %v", peerID.Pretty(), reason)
+	if ps.invalid == maxInvalid {
+		ilog.Warnf("put peer %v to black, too many invalid data", peerID.Pretty())
+		s.p2pService.PutPeerToBlack(peerID.Pretty())
+	}
+}
+
+// reset drops the records of the requests in flight.
+func (s *peerScores) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ps := range s.scores {
+		ps.inflight = make(map[string]time.Time)
+	}
+}
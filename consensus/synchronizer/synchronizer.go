@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/consensus/checkpoint"
 	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
@@ -26,6 +27,9 @@ var (
 	continuousNum           int
 	syncNumber              int64
 	printInterval           int64 = 1000
+	// headerRetries is the number of the header requests of a block number before falling back to the block hash
+	// request, which the peers of the versions before the header sync answer.
+	headerRetries = 2
 )
 
 // Synchronizer defines the functions of synchronizer module
@@ -44,12 +48,15 @@ type SyncImpl struct {
 	lastBcn         *blockcache.BlockCacheNode
 	baseVariable    global.BaseVariable
 	dc              DownloadController
+	scores          *peerScores
+	checkpoints     *checkpoint.Checkpoints
 	reqMap          *sync.Map
 	heightMap       *sync.Map
 	syncEnd         atomic.Int64
 	lastPrintHeight atomic.Int64
 
 	messageChan    chan p2p.IncomingMessage
+	blockChan      chan p2p.IncomingMessage
 	syncHeightChan chan p2p.IncomingMessage
 	exitSignal     chan struct{}
 	wg             *sync.WaitGroup
@@ -61,12 +68,17 @@ func NewSynchronizer(basevariable global.BaseVariable, blkcache blockcache.Block
 		p2pService:   p2pserv,
 		blockCache:   blkcache,
 		baseVariable: basevariable,
+		scores:       newPeerScores(p2pserv),
 		reqMap:       new(sync.Map),
 		heightMap:    new(sync.Map),
 		lastBcn:      nil,
 		wg:           new(sync.WaitGroup),
 	}
 	var err error
+	sy.checkpoints, err = checkpoint.New(basevariable.Config().Checkpoint)
+	if err != nil {
+		return nil, err
+	}
 	sy.dc, err = NewDownloadController(sy.checkHasBlock, sy.reqSyncBlock)
 	if err != nil {
 		return nil, err
@@ -76,7 +88,10 @@ func NewSynchronizer(basevariable global.BaseVariable, blkcache blockcache.Block
 		p2p.SyncBlockRequest,
 		p2p.SyncBlockHashRequest,
 		p2p.SyncBlockHashResponse,
+		p2p.SyncHeaderRequest,
+		p2p.SyncHeaderResponse,
 	)
+	sy.blockChan = sy.p2pService.Register("sync block", p2p.SyncBlockResponse)
 
 	sy.syncHeightChan = sy.p2pService.Register("sync height", p2p.SyncHeight)
 	sy.exitSignal = make(chan struct{})
@@ -160,6 +175,7 @@ func (sy *SyncImpl) syncHeightLoop() {
 			//ilog.Infof("sync height from: %s, height: %v, time:%v", req.From().Pretty(), sh.Height, sh.Time)
 			sy.heightMap.Store(req.From(), &sh)
 		case <-checkTicker.C:
+			sy.scores.expire(syncBlockTimeout)
 			sy.checkSync()
 			sy.checkGenBlock()
 			sy.CheckSyncProcess()
@@ -265,16 +281,16 @@ func (sy *SyncImpl) syncBlocks(startNumber int64, endNumber int64) error {
 			time.Sleep(500 * time.Millisecond)
 		}
 		for i := startNumber; i < startNumber+maxBlockHashQueryNumber; i++ {
-			sy.reqMap.Store(i, true)
+			sy.reqMap.Store(i, 0)
 		}
-		sy.queryBlockHash(&msgpb.BlockHashQuery{ReqType: 0, Start: startNumber, End: startNumber + maxBlockHashQueryNumber - 1, Nums: nil})
+		sy.queryBlockHeader(&msgpb.BlockHashQuery{ReqType: 0, Start: startNumber, End: startNumber + maxBlockHashQueryNumber - 1, Nums: nil})
 		startNumber += maxBlockHashQueryNumber
 	}
 	if startNumber <= endNumber {
 		for i := startNumber; i <= endNumber; i++ {
-			sy.reqMap.Store(i, true)
+			sy.reqMap.Store(i, 0)
 		}
-		sy.queryBlockHash(&msgpb.BlockHashQuery{ReqType: 0, Start: startNumber, End: endNumber, Nums: nil})
+		sy.queryBlockHeader(&msgpb.BlockHashQuery{ReqType: 0, Start: startNumber, End: endNumber, Nums: nil})
 	}
 	return nil
 }
@@ -285,6 +301,7 @@ func (sy *SyncImpl) CheckSyncProcess() {
 	if sy.syncEnd.Load() <= sy.blockCache.Head().Head.Number {
 		sy.baseVariable.SetMode(global.ModeNormal)
 		sy.dc.ReStart()
+		sy.scores.reset()
 	}
 }
 
@@ -310,6 +327,22 @@ func (sy *SyncImpl) messageLoop() {
 					break
 				}
 				go sy.handleHashResp(&rh, req.From())
+			case p2p.SyncHeaderRequest:
+				var rh msgpb.BlockHashQuery
+				err := proto.Unmarshal(req.Data(), &rh)
+				if err != nil {
+					ilog.Errorf("unmarshal BlockHashQuery failed:%v", err)
+					break
+				}
+				go sy.handleHeaderQuery(&rh, req.From())
+			case p2p.SyncHeaderResponse:
+				var bh msgpb.BlockHeaders
+				err := proto.Unmarshal(req.Data(), &bh)
+				if err != nil {
+					ilog.Errorf("unmarshal BlockHeaders failed:%v", err)
+					break
+				}
+				go sy.handleHeaderResp(&bh, req.From())
 			case p2p.SyncBlockRequest:
 				var rh msgpb.BlockInfo
 				err := proto.Unmarshal(req.Data(), &rh)
@@ -318,6 +351,8 @@ func (sy *SyncImpl) messageLoop() {
 				}
 				go sy.handleBlockQuery(&rh, req.From())
 			}
+		case req := <-sy.blockChan:
+			go sy.handleSyncBlock(req.Data(), req.From())
 		case <-sy.exitSignal:
 			return
 		}
@@ -344,6 +379,9 @@ func (sy *SyncImpl) getBlockHashes(start int64, end int64) *msgpb.BlockHashRespo
 		if node != nil {
 			hash = node.Block.HeadHash()
 		} else {
+			if sy.isPruned(i) {
+				continue
+			}
 			hash, err = sy.baseVariable.BlockChain().GetHashByNumber(i)
 			if err != nil {
 				ilog.Errorf("get hash by number from db failed. err=%v, number=%v", err, i)
@@ -375,6 +413,9 @@ func (sy *SyncImpl) getBlockHashesByNums(nums []int64) *msgpb.BlockHashResponse
 		if err == nil {
 			hash = blk.HeadHash()
 		} else {
+			if sy.isPruned(num) {
+				continue
+			}
 			hash, err = sy.baseVariable.BlockChain().GetHashByNumber(num)
 			if err != nil {
 				continue
@@ -389,6 +430,12 @@ func (sy *SyncImpl) getBlockHashesByNums(nums []int64) *msgpb.BlockHashResponse
 	return resp
 }
 
+// isPruned returns whether the txs of the block of the number are pruned. Such blocks aren't offered to the peers,
+// so that they download them from the others.
+func (sy *SyncImpl) isPruned(num int64) bool {
+	return num > 0 && num < sy.baseVariable.BlockChain().PrunedLength()
+}
+
 func (sy *SyncImpl) handleHashQuery(rh *msgpb.BlockHashQuery, peerID p2p.PeerID) {
 	if rh.End < rh.Start || rh.Start < 0 {
 		return
@@ -413,9 +460,18 @@ func (sy *SyncImpl) handleHashQuery(rh *msgpb.BlockHashQuery, peerID p2p.PeerID)
 	sy.p2pService.SendToPeer(peerID, bytes, p2p.SyncBlockHashResponse, p2p.NormalMessage)
 }
 
+// handleHashResp creates the missions of the block hashes from the peers not answering the header requests, the
+// blocks downloaded are verified in full as they aren't checked by the headers.
 func (sy *SyncImpl) handleHashResp(rh *msgpb.BlockHashResponse, peerID p2p.PeerID) {
 	ilog.Debugf("receive block hashes: len=%v", len(rh.BlockInfos))
 	for _, blkInfo := range rh.BlockInfos {
+		if _, ok := sy.reqMap.Load(blkInfo.Number); !ok {
+			continue
+		}
+		if err := sy.checkpoints.Check(blkInfo.Number, blkInfo.Hash); err != nil {
+			sy.scores.penalize(peerID, err.Error())
+			return
+		}
 		if blkInfo.Number > sy.blockCache.LinkedRoot().Head.Number {
 			sy.dc.CreateMission(string(blkInfo.Hash), blkInfo.Number, peerID)
 		}
@@ -429,21 +485,33 @@ func (sy *SyncImpl) retryDownloadLoop() {
 		select {
 		case <-time.After(retryTime):
 			hq := &msgpb.BlockHashQuery{ReqType: 1, Start: 0, End: 0, Nums: make([]int64, 0)}
+			hashQuery := &msgpb.BlockHashQuery{ReqType: 1, Start: 0, End: 0, Nums: make([]int64, 0)}
 			sy.reqMap.Range(func(k, v interface{}) bool {
 				num, ok := k.(int64)
 				if !ok {
 					sy.reqMap.Delete(k)
 					return true
 				}
-				hq.Nums = append(hq.Nums, num)
+				retries, _ := v.(int)
+				if retries >= headerRetries {
+					hashQuery.Nums = append(hashQuery.Nums, num)
+				} else {
+					hq.Nums = append(hq.Nums, num)
+				}
+				sy.reqMap.Store(num, retries+1)
 				return true
 			})
 			if len(hq.Nums) > 0 {
-				//ilog.Info("retry download ", hq.Nums)
 				sort.Slice(hq.Nums, func(i int, j int) bool {
 					return hq.Nums[i] < hq.Nums[j]
 				})
-				sy.queryBlockHash(hq)
+				sy.queryBlockHeader(hq)
+			}
+			if len(hashQuery.Nums) > 0 {
+				sort.Slice(hashQuery.Nums, func(i int, j int) bool {
+					return hashQuery.Nums[i] < hashQuery.Nums[j]
+				})
+				sy.queryBlockHash(hashQuery)
 			}
 		case <-sy.exitSignal:
 			return
@@ -456,6 +524,10 @@ func (sy *SyncImpl) handleBlockQuery(rh *msgpb.BlockInfo, peerID p2p.PeerID) {
 	blk, err := sy.blockCache.GetBlockByHash(rh.Hash)
 	if err != nil {
 		blk, err = sy.baseVariable.BlockChain().GetBlockByHash(rh.Hash)
+		if err == block.ErrPruned {
+			ilog.Debugf("handle block query for the pruned block %v.", rh.Number)
+			return
+		}
 		if err != nil {
 			ilog.Errorf("handle block query failed to get block.")
 			return
@@ -515,6 +587,9 @@ func (sy *SyncImpl) reqSyncBlock(hash string, p interface{}, peerID interface{})
 	if !ok {
 		return false, false
 	}
+	if !sy.scores.request(pid, hash) {
+		return false, false
+	}
 	sy.p2pService.SendToPeer(pid, bytes, p2p.SyncBlockRequest, p2p.UrgentMessage)
 	return true, false
 }
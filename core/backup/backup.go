@@ -0,0 +1,590 @@
+// Package backup takes the hot backups of the node, the copies of the dbs and the block cache wal at the last
+// irreversible block taken while the node is running, and restores the node from them.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/db/wal"
+	"golang.org/x/crypto/sha3"
+)
+
+// The names in the backup directory.
+const (
+	ManifestName  = "manifest.json"
+	BlockChainDir = "BlockChainDB"
+	StateDir      = "StateDB"
+	WALDir        = "BlockCacheWAL"
+)
+
+// FormatVersion is the version of the backup written by Create.
+const FormatVersion uint32 = 1
+
+const copyBatchKeys = 10000
+
+var (
+	errManifest = errors.New("invalid backup manifest")
+	errFiles    = errors.New("files of backup mismatch")
+	errDBs      = errors.New("dbs of backup mismatch")
+)
+
+// File is a file in the backup.
+type File struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// Manifest describes the content of a backup.
+type Manifest struct {
+	Version uint32 `json:"version"`
+	ChainID uint32 `json:"chainID"`
+	// Number and Hash are of the last irreversible block the backup is taken at.
+	Number int64  `json:"number"`
+	Hash   string `json:"hash"`
+	Time   int64  `json:"time"`
+	// Storage is the storage type of the dbs, the dbs of the backups without it are leveldb.
+	Storage string           `json:"storage,omitempty"`
+	Schemas map[string]int64 `json:"schemas"`
+	// Files are the files in the backup except the manifest, with their sha3-256 checksums.
+	Files []*File `json:"files"`
+}
+
+// Create writes the backup of the running node to dir, which must not exist. The blockchain db and the state db
+// are copied from their snapshots taken at the linked root of the block cache, when no block is being flushed,
+// and the wal from its segments pinned with them. Only the snapshots and the pin hold back the flushes, the
+// copies are made after. The copies are written on the storage of storageType, which is the one of the node.
+func Create(dir string, chainID uint32, storageType kv.StorageType, bc blockcache.BlockCache, chain block.Chain, stateDB db.MVCCDB) (*Manifest, error) {
+	if storageType == kv.MemoryStorage {
+		return nil, errors.New("the dbs on the memory storage can't be backed up")
+	}
+	dir = filepath.Clean(dir)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%v already exists", dir)
+	}
+	tmp := dir + ".tmp"
+	err := os.RemoveAll(tmp)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	err = os.MkdirAll(tmp, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	var lib *block.Block
+	var chainSnap, stateSnap *kv.Snapshot
+	var pin *wal.Pin
+	defer func() {
+		if chainSnap != nil {
+			chainSnap.Release()
+		}
+		if stateSnap != nil {
+			stateSnap.Release()
+		}
+		if pin != nil {
+			pin.Release()
+		}
+	}()
+	pinDir := dir + ".wal"
+	err = os.RemoveAll(pinDir)
+	if err != nil {
+		return nil, err
+	}
+	err = bc.Checkpoint(func(root *blockcache.BlockCacheNode) error {
+		if root.Block == nil {
+			return errors.New("linked root without block")
+		}
+		lib = root.Block
+		var err error
+		chainSnap, err = chain.Snapshot()
+		if err != nil {
+			return err
+		}
+		stateSnap, err = stateDB.Snapshot()
+		if err != nil {
+			return err
+		}
+		pin, err = bc.PinWAL(pinDir)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = pin.CopyTo(filepath.Join(tmp, WALDir))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		Version: FormatVersion,
+		ChainID: chainID,
+		Number:  lib.Head.Number,
+		Hash:    common.Base58Encode(lib.HeadHash()),
+		Time:    time.Now().Unix(),
+		Storage: storageType.String(),
+		Schemas: make(map[string]int64),
+	}
+	err = copySnapshot(chainSnap, filepath.Join(tmp, BlockChainDir), storageType)
+	if err != nil {
+		return nil, err
+	}
+	err = copySnapshot(stateSnap, filepath.Join(tmp, StateDir), storageType)
+	if err != nil {
+		return nil, err
+	}
+	m.Schemas[block.Schema.Name], err = schemaVersion(filepath.Join(tmp, BlockChainDir), storageType, block.Schema.Get)
+	if err != nil {
+		return nil, err
+	}
+	m.Schemas[db.Schema.Name], err = schemaVersion(filepath.Join(tmp, StateDir), storageType, db.Schema.Get)
+	if err != nil {
+		return nil, err
+	}
+	m.Files, err = listFiles(tmp)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(tmp, ManifestName), b, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return m, os.Rename(tmp, dir)
+}
+
+// copySnapshot copies all keys of the storage snapshot to a new storage of storageType at path.
+func copySnapshot(snap *kv.Snapshot, path string, storageType kv.StorageType) error {
+	to, err := kv.NewStorage(path, storageType)
+	if err != nil {
+		return err
+	}
+	defer to.Close()
+
+	iter := snap.NewIteratorByPrefix([]byte{})
+	defer iter.Release()
+	if err := to.BeginBatch(); err != nil {
+		return err
+	}
+	keys := 0
+	for iter.Next() {
+		if err := to.Put(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+		keys++
+		if keys%copyBatchKeys == 0 {
+			if err := to.CommitBatch(); err != nil {
+				return err
+			}
+			if err := to.BeginBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return to.CommitBatch()
+}
+
+func schemaVersion(path string, storageType kv.StorageType, get func(s *kv.Storage) (int64, bool, error)) (int64, error) {
+	s, err := kv.NewStorage(path, storageType)
+	if err != nil {
+		return 0, err
+	}
+	defer s.Close()
+	version, _, err := get(s)
+	return version, err
+}
+
+// listFiles returns the files in dir except the manifest, sorted by their names.
+func listFiles(dir string) ([]*File, error) {
+	files := make([]*File, 0)
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if name == ManifestName {
+			return nil
+		}
+		checksum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, &File{Name: name, Size: fi.Size(), Checksum: checksum})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
+}
+
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha3.New256()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// StorageType returns the storage type of the dbs in the backup.
+func (m *Manifest) StorageType() (kv.StorageType, error) {
+	t, err := kv.ParseStorageType(m.Storage)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %v", errManifest, err)
+	}
+	return t, nil
+}
+
+// ReadManifest reads the manifest of the backup in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("%v: %v", errManifest, err)
+	}
+	if m.Version != FormatVersion {
+		return nil, fmt.Errorf("%v: unsupported version %v", errManifest, m.Version)
+	}
+	return m, nil
+}
+
+// VerifyFiles checks that the files in dir are the ones of the manifest without opening the dbs.
+func VerifyFiles(dir string) (*Manifest, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) != len(m.Files) {
+		return nil, fmt.Errorf("%v: %v files, expected %v", errFiles, len(files), len(m.Files))
+	}
+	for i, f := range files {
+		if *f != *m.Files[i] {
+			return nil, fmt.Errorf("%v: %v", errFiles, m.Files[i].Name)
+		}
+	}
+	return m, nil
+}
+
+// Verify checks the files in dir and the dbs restored from them. The blockchain db and the state db must
+// end at the block of the manifest with the same state digest, and the wal must be readable. The dbs are
+// opened, so it's run on the copy to use.
+func Verify(dir string, chainID uint32) (*Manifest, error) {
+	m, err := VerifyFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainID != chainID {
+		return nil, fmt.Errorf("%v: chain id %v, expected %v", errManifest, m.ChainID, chainID)
+	}
+	hash := common.Base58Decode(m.Hash)
+	storageType, err := m.StorageType()
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := block.NewBlockChainWithStorage(filepath.Join(dir, BlockChainDir), storageType)
+	if err != nil {
+		return nil, err
+	}
+	defer chain.Close()
+	if chain.Length() != m.Number+1 {
+		return nil, fmt.Errorf("%v: blockchain length %v, expected %v", errDBs, chain.Length(), m.Number+1)
+	}
+	h, err := chain.GetHashByNumber(m.Number)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(h, hash) {
+		return nil, fmt.Errorf("%v: block %v is %v, expected %v", errDBs, m.Number, common.Base58Encode(h), m.Hash)
+	}
+
+	stateDB, err := db.NewMVCCDBWithStorage(filepath.Join(dir, StateDir), storageType, mvcc.MapCache)
+	if err != nil {
+		return nil, err
+	}
+	defer stateDB.Close()
+	if tag := stateDB.CurrentTag(); tag != string(hash) {
+		return nil, fmt.Errorf("%v: state db is at %v, expected %v", errDBs, common.Base58Encode([]byte(tag)), m.Hash)
+	}
+	digest, err := chain.GetStateDigest(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) > 0 {
+		d, err := stateDB.Digest()
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(d.Encode(), digest) {
+			return nil, fmt.Errorf("%v: state digest of block %v mismatch", errDBs, m.Number)
+		}
+	}
+
+	segments, err := inspectWAL(filepath.Join(dir, WALDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range segments {
+		if s.Corrupted() && !(s.Tail && s.Torn) {
+			return nil, fmt.Errorf("%v: %v is corrupted at byte %v: %v", errFiles, s.Name, s.ValidBytes, s.Err)
+		}
+	}
+	return m, nil
+}
+
+// inspectWAL inspects the wal in dir, the empty wal isn't in the backup.
+func inspectWAL(dir string) ([]*wal.Segment, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	segments, err := wal.Inspect(dir, nil)
+	if err == wal.ErrFileNotFound {
+		return nil, nil
+	}
+	return segments, err
+}
+
+// Archive writes the backup in dir to w as a tar.gz, the manifest first.
+func Archive(w io.Writer, dir string) error {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	names := []string{ManifestName}
+	for _, f := range m.Files {
+		names = append(names, f.Name)
+	}
+	for _, name := range names {
+		if err := archiveFile(tw, dir, name); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+func archiveFile(tw *tar.Writer, dir, name string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Extract extracts the backup archived by Archive from r to dir.
+func Extract(r io.Reader, dir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		// The backup may come from other hosts, never write outside of dir.
+		name := filepath.Join(dir, filepath.FromSlash(h.Name))
+		if !strings.HasPrefix(name, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file name in backup: %v", h.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		fw, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(fw, tr)
+		if cerr := fw.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Restore restores the node from the backup at src, a directory or a tar.gz file. The backup is copied next to
+// the dbs and verified before it replaces them, the dbs and the wal of the node must not exist. The dbs of the
+// backup must be on the storage of storageType, which is the one of the node.
+func Restore(src string, ldbPath string, walDir string, storageType kv.StorageType, chainID uint32) (*Manifest, error) {
+	targets := map[string]string{
+		BlockChainDir: ldbPath + "BlockChainDB",
+		StateDir:      ldbPath + "StateDB",
+		WALDir:        walDir,
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target); err == nil {
+			return nil, fmt.Errorf("%v already exists", target)
+		}
+	}
+	fi, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := ldbPath + "Restore.tmp"
+	err = os.RemoveAll(tmp)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	if fi.IsDir() {
+		err = copyDir(src, tmp)
+	} else {
+		err = extractFile(src, tmp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	m, err := ReadManifest(tmp)
+	if err != nil {
+		return nil, err
+	}
+	t, err := m.StorageType()
+	if err != nil {
+		return nil, err
+	}
+	if t != storageType {
+		return nil, fmt.Errorf("the dbs of the backup are on the %v storage, but the node is configured with %v, "+
+			"restore it to a node configured with %v", t, storageType, t)
+	}
+	m, err = Verify(tmp, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, target := range targets {
+		if _, err := os.Stat(filepath.Join(tmp, name)); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(filepath.Clean(target)), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(filepath.Join(tmp, name), target); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func extractFile(src, dir string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return Extract(f, dir)
+}
+
+// copyDir copies the backup in src to dir, only the manifest and the files of it.
+func copyDir(src, dir string) error {
+	m, err := ReadManifest(src)
+	if err != nil {
+		return err
+	}
+	names := []string{ManifestName}
+	for _, f := range m.Files {
+		names = append(names, f.Name)
+	}
+	for _, name := range names {
+		if err := copyFile(filepath.Join(src, filepath.FromSlash(name)), filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/db/wal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCache is the block cache pinned at the root, with the wal in walDir.
+type fakeCache struct {
+	blockcache.BlockCache
+	root *blockcache.BlockCacheNode
+	wal  *wal.WAL
+}
+
+func (c *fakeCache) Checkpoint(fn func(root *blockcache.BlockCacheNode) error) error {
+	return fn(c.root)
+}
+
+func (c *fakeCache) PinWAL(dir string) (*wal.Pin, error) {
+	return c.wal.Pin(dir)
+}
+
+func newBlock(number int64, parent []byte) *block.Block {
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			ParentHash: parent,
+			Number:     number,
+			Time:       number + 1,
+		},
+		Sign: &crypto.Signature{},
+	}
+	blk.CalculateHeadHash()
+	return blk
+}
+
+func TestBackup(t *testing.T) {
+	for _, storageType := range []kv.StorageType{kv.LevelDBStorage, kv.BboltStorage} {
+		t.Run(storageType.String(), func(t *testing.T) {
+			testBackup(t, storageType)
+		})
+	}
+}
+
+func testBackup(t *testing.T, storageType kv.StorageType) {
+	root, err := ioutil.TempDir("", "backup")
+	require.Nil(t, err)
+	defer os.RemoveAll(root)
+	node := filepath.Join(root, "node") + "/"
+
+	chain, err := block.NewBlockChainWithStorage(node+"BlockChainDB", storageType)
+	require.Nil(t, err)
+	stateDB, err := db.NewMVCCDBWithStorage(node+"StateDB", storageType, mvcc.MapCache)
+	require.Nil(t, err)
+	w, err := wal.Create(node+"BlockCacheWAL", []byte("block_cache_wal"))
+	require.Nil(t, err)
+
+	var parent []byte
+	var lib *block.Block
+	for i := int64(0); i < 3; i++ {
+		lib = newBlock(i, parent)
+		parent = lib.HeadHash()
+		require.Nil(t, chain.Push(lib))
+		stateDB.Put("state", "number", string(rune('0'+i)))
+		stateDB.Commit(string(lib.HeadHash()))
+		require.Nil(t, stateDB.Flush(string(lib.HeadHash())))
+		d, err := stateDB.Digest()
+		require.Nil(t, err)
+		require.Nil(t, chain.PutStateDigest(lib.HeadHash(), d.Encode()))
+	}
+	_, err = w.SaveSingle(wal.Entry{Data: []byte("entry")})
+	require.Nil(t, err)
+
+	cache := &fakeCache{root: &blockcache.BlockCacheNode{Block: lib}, wal: w}
+	dir := filepath.Join(root, "backup")
+	m, err := Create(dir, 1024, storageType, cache, chain, stateDB)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), m.Number)
+	assert.Equal(t, int64(1), m.Schemas["StateDB"])
+	assert.Equal(t, storageType.String(), m.Storage)
+	_, err = Create(dir, 1024, storageType, cache, chain, stateDB)
+	assert.NotNil(t, err)
+	w.Close()
+	chain.Close()
+	stateDB.Close()
+
+	vm, err := VerifyFiles(dir)
+	require.Nil(t, err)
+	assert.Equal(t, m, vm)
+
+	var buf bytes.Buffer
+	require.Nil(t, Archive(&buf, dir))
+	file := filepath.Join(root, "backup.tar.gz")
+	require.Nil(t, ioutil.WriteFile(file, buf.Bytes(), 0644))
+
+	// The backup is restored from the archive, the copy is verified before it's used.
+	restored := filepath.Join(root, "restored") + "/"
+	other := kv.BboltStorage
+	if storageType == kv.BboltStorage {
+		other = kv.LevelDBStorage
+	}
+	_, err = Restore(file, restored, restored+"BlockCacheWAL", other, 1024)
+	assert.NotNil(t, err)
+	_, err = Restore(file, restored, restored+"BlockCacheWAL", storageType, 1)
+	assert.NotNil(t, err)
+	rm, err := Restore(file, restored, restored+"BlockCacheWAL", storageType, 1024)
+	require.Nil(t, err)
+	assert.Equal(t, m, rm)
+	_, err = Restore(file, restored, restored+"BlockCacheWAL", storageType, 1024)
+	assert.NotNil(t, err)
+
+	chain, err = block.NewBlockChainWithStorage(restored+"BlockChainDB", storageType)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), chain.Length())
+	chain.Close()
+	stateDB, err = db.NewMVCCDBWithStorage(restored+"StateDB", storageType, mvcc.MapCache)
+	require.Nil(t, err)
+	assert.Equal(t, string(lib.HeadHash()), stateDB.CurrentTag())
+	v, err := stateDB.Get("state", "number")
+	require.Nil(t, err)
+	assert.Equal(t, "2", v)
+	stateDB.Close()
+	segments, err := wal.Inspect(restored+"BlockCacheWAL", nil)
+	require.Nil(t, err)
+	assert.Equal(t, 1, segments[len(segments)-1].Entries)
+
+	// The backup directory is restored the same, and the modified one is refused.
+	otherNode := filepath.Join(root, "other") + "/"
+	_, err = Restore(dir, otherNode, otherNode+"BlockCacheWAL", storageType, 1024)
+	require.Nil(t, err)
+	f := m.Files[len(m.Files)-1]
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, f.Name), []byte("modified"), 0644))
+	_, err = VerifyFiles(dir)
+	assert.NotNil(t, err)
+	_, err = Restore(dir, filepath.Join(root, "third")+"/", "", storageType, 1024)
+	assert.NotNil(t, err)
+}
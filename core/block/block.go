@@ -11,6 +11,26 @@ import (
 	"github.com/iost-official/go-iost/crypto"
 )
 
+// The versions of the block head.
+const (
+	// V0 is the version of the blocks before the state root is committed.
+	V0 int64 = iota
+	// V1 commits the root of the state tree after the txs of the block in StateRoot.
+	V1
+)
+
+// StateRootNumber is the number of the first block of V1, the blocks are V0 if it's 0.
+// It must be the same on all nodes of a chain, it's set from the config at startup.
+var StateRootNumber int64
+
+// VersionOf returns the version of the block of the number.
+func VersionOf(number int64) int64 {
+	if StateRootNumber > 0 && number >= StateRootNumber {
+		return V1
+	}
+	return V0
+}
+
 // BlockHead is the struct of block head.
 type BlockHead struct { // nolint
 	Version             int64
@@ -22,6 +42,7 @@ type BlockHead struct { // nolint
 	Witness             string
 	Time                int64
 	GasUsage            int64
+	StateRoot           []byte
 }
 
 // ToPb convert BlockHead to proto buf data structure.
@@ -35,6 +56,7 @@ func (b *BlockHead) ToPb() *blockpb.BlockHead {
 		Number:              b.Number,
 		Witness:             b.Witness,
 		Time:                b.Time,
+		StateRoot:           b.StateRoot,
 	}
 }
 
@@ -49,6 +71,10 @@ func (b *BlockHead) ToBytes() []byte {
 	se.WriteInt64(b.Number)
 	se.WriteString(b.Witness)
 	se.WriteInt64(b.Time)
+	// The hash of the V0 blocks doesn't change.
+	if b.Version >= V1 {
+		se.WriteBytes(b.StateRoot)
+	}
 	return se.Bytes()
 }
 
@@ -62,6 +88,7 @@ func (b *BlockHead) FromPb(bh *blockpb.BlockHead) *BlockHead {
 	b.Number = bh.Number
 	b.Witness = bh.Witness
 	b.Time = bh.Time
+	b.StateRoot = bh.StateRoot
 	return b
 }
 
@@ -158,6 +185,9 @@ func (b *Block) Decode(blockByte []byte) error {
 	if err != nil {
 		return errors.New("fail to decode blockraw")
 	}
+	if br.Head == nil || br.Sign == nil {
+		return errors.New("fail to decode blockraw, miss head or sign")
+	}
 	h := &BlockHead{}
 	h.FromPb(br.Head)
 	b.Head = h
@@ -10,6 +10,7 @@ import (
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/schema"
 )
 
 // BlockChain is the implementation of chain
@@ -18,6 +19,7 @@ type BlockChain struct { //nolint:golint
 	rw           sync.RWMutex
 	length       int64
 	txTotal      int64
+	prunedLength int64
 }
 
 var (
@@ -31,14 +33,31 @@ var (
 	receiptPrefix     = []byte("r")      // receiptPrefix + receipt hash -> block hash + receipt hash
 	bReceiptPrefix    = []byte("b")      // bReceiptPrefix + block hash + receipt hash -> receipt data
 	delaytxPrefix     = []byte("delay-") // delaytxPrefix + tx hash -> tx data
+	stateDigestPrefix = []byte("s")      // stateDigestPrefix + block hash -> digest of the state writes
+	prunedLength      = []byte("PrunedLength")
+	prunedPrefix      = []byte("p") // prunedPrefix + block hash -> block number, the txs of the block are pruned
 )
 
+// Schema is the schema of the blockchain db, the db created before has the length.
+var Schema = schema.New("BlockChainDB", []byte("SchemaVersion"), schema.BaseVersion, func(s *kv.Storage) (bool, error) {
+	return s.Has(blockLength)
+})
+
 // NewBlockChain returns a Chain instance
 func NewBlockChain(path string) (Chain, error) {
-	levelDB, err := kv.NewStorage(path, kv.LevelDBStorage)
+	return NewBlockChainWithStorage(path, kv.LevelDBStorage)
+}
+
+// NewBlockChainWithStorage returns a Chain instance on the storage of the specify type
+func NewBlockChainWithStorage(path string, storageType kv.StorageType) (Chain, error) {
+	levelDB, err := kv.NewStorage(path, storageType)
 	if err != nil {
 		return nil, fmt.Errorf("fail to init blockchaindb, %v", err)
 	}
+	if err := Schema.Open(levelDB); err != nil {
+		levelDB.Close()
+		return nil, err
+	}
 	var length int64
 	var txTotal int64
 	ok, err := levelDB.Has(blockLength)
@@ -66,11 +85,18 @@ func NewBlockChain(path string) (Chain, error) {
 			return nil, errors.New("fail to put tx total")
 		}
 	}
+	prunedLengthByte, err := levelDB.Get(prunedLength)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get pruned length, %v", err)
+	}
 	BC := &BlockChain{
 		blockChainDB: levelDB,
 		length:       length,
 		txTotal:      txTotal,
 	}
+	if len(prunedLengthByte) > 0 {
+		BC.prunedLength = common.BytesToInt64(prunedLengthByte)
+	}
 	BC.CheckLength()
 	return BC, err
 }
@@ -185,6 +211,20 @@ func (bc *BlockChain) GetHashByNumber(number int64) ([]byte, error) {
 	return hash, nil
 }
 
+// PutStateDigest saves the digest of the state writes of the block.
+func (bc *BlockChain) PutStateDigest(hash []byte, digest []byte) error {
+	return bc.blockChainDB.Put(append(stateDigestPrefix, hash...), digest)
+}
+
+// GetStateDigest returns the digest of the state writes of the block.
+func (bc *BlockChain) GetStateDigest(hash []byte) ([]byte, error) {
+	digest, err := bc.blockChainDB.Get(append(stateDigestPrefix, hash...))
+	if err != nil || len(digest) == 0 {
+		return nil, errors.New("fail to get state digest by hash")
+	}
+	return digest, nil
+}
+
 // getBlockByteByHash is get block byte by hash
 func (bc *BlockChain) getBlockByteByHash(hash []byte) ([]byte, error) {
 	blockByte, err := bc.blockChainDB.Get(append(blockPrefix, hash...))
@@ -206,6 +246,9 @@ func (bc *BlockChain) GetBlockByHash(hash []byte) (*Block, error) {
 		return nil, errors.New("fail to decode blockByte")
 	}
 	if blk.TxHashes != nil {
+		if bc.isPruned(blk.Head.Number) {
+			return nil, ErrPruned
+		}
 		blk.Txs = make([]*tx.Tx, len(blk.TxHashes))
 		txsMap, err := bc.getBlockTxsMap(hash)
 		if err != nil {
@@ -219,20 +262,67 @@ func (bc *BlockChain) GetBlockByHash(hash []byte) (*Block, error) {
 			}
 		}
 	}
+	err = bc.loadReceipts(&blk, hash)
+	if err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
+
+// GetBlockWithReceiptsByHash is get block by hash with the receipts and the tx hashes, the txs are not loaded,
+// so it works for the block whose txs are pruned
+func (bc *BlockChain) GetBlockWithReceiptsByHash(hash []byte) (*Block, error) {
+	blockByte, err := bc.getBlockByteByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	var blk Block
+	err = blk.Decode(blockByte)
+	if err != nil {
+		return nil, errors.New("fail to decode blockByte")
+	}
+	err = bc.loadReceipts(&blk, hash)
+	if err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
+
+func (bc *BlockChain) loadReceipts(blk *Block, hash []byte) error {
 	if blk.ReceiptHashes != nil {
 		blk.Receipts = make([]*tx.TxReceipt, len(blk.ReceiptHashes))
 		receiptMap, err := bc.getBlockReceiptMap(hash)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for i, hash := range blk.ReceiptHashes {
 			if tr, ok := receiptMap[string(hash)]; ok {
 				blk.Receipts[i] = tr
 			} else {
-				return nil, fmt.Errorf("miss the tx receipt, tx receipt hash: %s", hash)
+				return fmt.Errorf("miss the tx receipt, tx receipt hash: %s", hash)
 			}
 		}
 	}
+	return nil
+}
+
+// GetBlockHeadByNumber is get block by number with only the head and the sign, txs and receipts are not loaded
+func (bc *BlockChain) GetBlockHeadByNumber(number int64) (*Block, error) {
+	hash, err := bc.GetHashByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	blockByte, err := bc.getBlockByteByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	var blk Block
+	err = blk.Decode(blockByte)
+	if err != nil {
+		return nil, errors.New("fail to decode blockByte")
+	}
+	blk.TxHashes = nil
+	blk.ReceiptHashes = nil
 	return &blk, nil
 }
 
@@ -296,6 +386,16 @@ func (bc *BlockChain) GetTx(hash []byte) (*tx.Tx, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to Get the tx: %v", err)
 	}
+	if len(txData) == 0 && len(bTx) > len(hash) && bc.isPrunedBlock(bTx[:len(bTx)-len(hash)]) {
+		// The delay tx not deferred yet is kept for the defer tx referring to it.
+		txData, err = bc.blockChainDB.Get(append(delaytxPrefix, hash...))
+		if err != nil {
+			return nil, fmt.Errorf("failed to Get the tx: %v", err)
+		}
+		if len(txData) == 0 {
+			return nil, ErrPruned
+		}
+	}
 	if len(txData) == 0 {
 		return nil, fmt.Errorf("failed to Get the tx: not found")
 	}
@@ -363,6 +463,11 @@ func (bc *BlockChain) Size() (int64, error) {
 	return bc.blockChainDB.Size()
 }
 
+// Snapshot returns a read-only view of the current blockchain db
+func (bc *BlockChain) Snapshot() (*kv.Snapshot, error) {
+	return bc.blockChainDB.NewSnapshot()
+}
+
 // Close is close database
 func (bc *BlockChain) Close() {
 	bc.blockChainDB.Close()
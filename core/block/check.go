@@ -0,0 +1,240 @@
+package block
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iost-official/go-iost/common"
+)
+
+// kinds of the problems found by Check
+const (
+	ProblemLength       = "length"
+	ProblemHashIndex    = "hashIndex"
+	ProblemBlock        = "block"
+	ProblemTx           = "tx"
+	ProblemTxIndex      = "txIndex"
+	ProblemReceipt      = "receipt"
+	ProblemReceiptIndex = "receiptIndex"
+	ProblemTxTotal      = "txTotal"
+)
+
+// Problem is an inconsistency of the blockchain db found by Check.
+type Problem struct {
+	Number   int64  `json:"number"`
+	Kind     string `json:"kind"`
+	Detail   string `json:"detail"`
+	Repaired bool   `json:"repaired,omitempty"`
+}
+
+// CheckResult is the result of checking the blockchain db.
+type CheckResult struct {
+	Length   int64      `json:"length"`
+	TxTotal  int64      `json:"txTotal"`
+	Blocks   int64      `json:"blocks"`
+	Txs      int64      `json:"txs"`
+	Problems []*Problem `json:"problems"`
+}
+
+// Unrepaired returns the number of the problems not repaired.
+func (r *CheckResult) Unrepaired() int {
+	n := 0
+	for _, p := range r.Problems {
+		if !p.Repaired {
+			n++
+		}
+	}
+	return n
+}
+
+// Check cross-validates the blocks from the number from to the top: the hash index of each number, the block
+// under it, the chain of the parent hashes, and the tx and receipt lookups of the txs in the block. The tx total
+// is checked only if from is 0, it counts the txs of the pruned blocks too. If repair is true, the missing or
+// wrong index entries are rewritten from the blocks, the missing blocks, txs and receipts can't be repaired.
+func (bc *BlockChain) Check(from int64, repair bool) (*CheckResult, error) {
+	result := &CheckResult{
+		Length:   bc.Length(),
+		TxTotal:  bc.TxTotal(),
+		Problems: make([]*Problem, 0),
+	}
+	report := func(number int64, kind string, repairable bool, repairFn func() error, format string, args ...interface{}) error {
+		p := &Problem{Number: number, Kind: kind, Detail: fmt.Sprintf(format, args...)}
+		result.Problems = append(result.Problems, p)
+		if repair && repairable {
+			if err := repairFn(); err != nil {
+				return err
+			}
+			p.Repaired = true
+		}
+		return nil
+	}
+	put := func(key, value []byte) func() error {
+		return func() error {
+			return bc.blockChainDB.Put(key, value)
+		}
+	}
+
+	var hashes map[int64][]byte
+	var parent []byte
+	var txTotal int64
+	for number := from; number < result.Length; number++ {
+		numberKey := append(blockNumberPrefix, common.Int64ToBytes(number)...)
+		hash, err := bc.blockChainDB.Get(numberKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(hash) == 0 || !bc.hasBlock(hash) {
+			if hashes == nil {
+				hashes, err = bc.blockHashes()
+				if err != nil {
+					return nil, err
+				}
+			}
+			found, ok := hashes[number]
+			err = report(number, ProblemHashIndex, ok, put(numberKey, found), "no block under the hash index %v", common.Base58Encode(hash))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				parent = nil
+				continue
+			}
+			hash = found
+		}
+
+		blk, err := bc.checkBlock(number, hash, parent, report)
+		if err != nil {
+			return nil, err
+		}
+		parent = hash
+		if blk == nil {
+			continue
+		}
+		result.Blocks++
+		txTotal += int64(len(blk.TxHashes))
+		result.Txs += int64(len(blk.TxHashes))
+		if err := bc.checkTxs(blk, hash, report, put); err != nil {
+			return nil, err
+		}
+	}
+
+	has, err := bc.blockChainDB.Has(append(blockNumberPrefix, common.Int64ToBytes(result.Length)...))
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		err = report(result.Length, ProblemLength, false, nil, "block after the length %v", result.Length)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if from == 0 && txTotal != result.TxTotal {
+		err = report(result.Length, ProblemTxTotal, true, put(blockTxTotal, common.Int64ToBytes(txTotal)), "tx total %v, expected %v", result.TxTotal, txTotal)
+		if err != nil {
+			return nil, err
+		}
+		if repair {
+			bc.SetTxTotal(txTotal)
+		}
+	}
+	return result, nil
+}
+
+type reportFunc func(number int64, kind string, repairable bool, repairFn func() error, format string, args ...interface{}) error
+
+func (bc *BlockChain) hasBlock(hash []byte) bool {
+	has, err := bc.blockChainDB.Has(append(blockPrefix, hash...))
+	return err == nil && has
+}
+
+// blockHashes returns the hashes of all blocks in the db by their numbers.
+func (bc *BlockChain) blockHashes() (map[int64][]byte, error) {
+	hashes := make(map[int64][]byte)
+	iter := bc.blockChainDB.NewIteratorByPrefix(blockPrefix)
+	defer iter.Release()
+	for iter.Next() {
+		var blk Block
+		if err := blk.Decode(iter.Value()); err != nil {
+			continue
+		}
+		hash := append([]byte{}, iter.Key()[len(blockPrefix):]...)
+		hashes[blk.Head.Number] = hash
+	}
+	return hashes, iter.Error()
+}
+
+// checkBlock checks the block under the hash, it returns nil if the block is broken.
+func (bc *BlockChain) checkBlock(number int64, hash, parent []byte, report reportFunc) (*Block, error) {
+	blockByte, err := bc.getBlockByteByHash(hash)
+	if err != nil {
+		return nil, report(number, ProblemBlock, false, nil, "block %v is missing", common.Base58Encode(hash))
+	}
+	var blk Block
+	if err := blk.Decode(blockByte); err != nil {
+		return nil, report(number, ProblemBlock, false, nil, "block %v can't be decoded: %v", common.Base58Encode(hash), err)
+	}
+	if err := blk.CalculateHeadHash(); err != nil {
+		return nil, err
+	}
+	switch {
+	case blk.Head.Number != number:
+		return nil, report(number, ProblemBlock, false, nil, "block %v is of number %v", common.Base58Encode(hash), blk.Head.Number)
+	case !bytes.Equal(blk.HeadHash(), hash):
+		return nil, report(number, ProblemBlock, false, nil, "block %v is of hash %v", common.Base58Encode(hash), common.Base58Encode(blk.HeadHash()))
+	case parent != nil && !bytes.Equal(blk.Head.ParentHash, parent):
+		return nil, report(number, ProblemBlock, false, nil, "parent hash %v, expected %v", common.Base58Encode(blk.Head.ParentHash), common.Base58Encode(parent))
+	case len(blk.TxHashes) != len(blk.ReceiptHashes):
+		return nil, report(number, ProblemBlock, false, nil, "%v txs with %v receipts", len(blk.TxHashes), len(blk.ReceiptHashes))
+	}
+	return &blk, nil
+}
+
+// checkTxs checks the txs and the receipts of the block, and their lookups. The txs of the pruned blocks aren't checked.
+func (bc *BlockChain) checkTxs(blk *Block, hash []byte, report reportFunc, put func(key, value []byte) func() error) error {
+	number := blk.Head.Number
+	for i, tHash := range blk.TxHashes {
+		rHash := blk.ReceiptHashes[i]
+		has, err := bc.blockChainDB.Has(append(bTxPrefix, append(hash, tHash...)...))
+		if err != nil {
+			return err
+		}
+		if !has && !bc.isPruned(number) {
+			if err := report(number, ProblemTx, false, nil, "tx %v is missing", common.Base58Encode(tHash)); err != nil {
+				return err
+			}
+		}
+		has, err = bc.blockChainDB.Has(append(bReceiptPrefix, append(hash, rHash...)...))
+		if err != nil {
+			return err
+		}
+		if !has {
+			if err := report(number, ProblemReceipt, false, nil, "receipt %v is missing", common.Base58Encode(rHash)); err != nil {
+				return err
+			}
+		}
+
+		lookups := []struct {
+			kind  string
+			key   []byte
+			value []byte
+		}{
+			{ProblemTxIndex, append(txPrefix, tHash...), append(append([]byte{}, hash...), tHash...)},
+			{ProblemReceiptIndex, append(txReceiptPrefix, tHash...), append(append([]byte{}, hash...), rHash...)},
+			{ProblemReceiptIndex, append(receiptPrefix, rHash...), append(append([]byte{}, hash...), rHash...)},
+		}
+		for _, l := range lookups {
+			v, err := bc.blockChainDB.Get(l.key)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(v, l.value) {
+				continue
+			}
+			err = report(number, l.kind, true, put(l.key, l.value), "lookup %v of tx %v is wrong", string(l.key[:1]), common.Base58Encode(tHash))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package block
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "check")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	chain, err := NewBlockChain(dir)
+	require.Nil(t, err)
+	defer chain.Close()
+	bc := chain.(*BlockChain)
+
+	var parent []byte
+	var blocks []*Block
+	for i := int64(0); i < 4; i++ {
+		blk := &Block{
+			Head: &BlockHead{
+				ParentHash: parent,
+				Number:     i,
+				Time:       i + 1,
+			},
+			Sign: &crypto.Signature{},
+		}
+		for j := int64(0); j < i; j++ {
+			tt := tx.NewTx(nil, nil, 1000, 100, i*10+j, 0, 0)
+			blk.Txs = append(blk.Txs, tt)
+			blk.Receipts = append(blk.Receipts, tx.NewTxReceipt(tt.Hash()))
+		}
+		require.Nil(t, blk.CalculateHeadHash())
+		require.Nil(t, chain.Push(blk))
+		parent = blk.HeadHash()
+		blocks = append(blocks, blk)
+	}
+
+	result, err := chain.Check(0, false)
+	require.Nil(t, err)
+	assert.Equal(t, int64(4), result.Blocks)
+	assert.Equal(t, int64(6), result.Txs)
+	assert.Empty(t, result.Problems)
+
+	// The broken indexes are found, and repaired from the blocks.
+	b2, b3 := blocks[2], blocks[3]
+	require.Nil(t, bc.blockChainDB.Delete(append(blockNumberPrefix, common.Int64ToBytes(2)...)))
+	require.Nil(t, bc.blockChainDB.Delete(append(txPrefix, b3.Txs[0].Hash()...)))
+	require.Nil(t, bc.blockChainDB.Put(append(receiptPrefix, b3.Receipts[1].Hash()...), []byte("wrong")))
+	require.Nil(t, bc.blockChainDB.Put(blockTxTotal, common.Int64ToBytes(1)))
+	bc.SetTxTotal(1)
+
+	result, err = chain.Check(0, false)
+	require.Nil(t, err)
+	kinds := make([]string, 0)
+	for _, p := range result.Problems {
+		kinds = append(kinds, p.Kind)
+		assert.False(t, p.Repaired)
+	}
+	assert.Equal(t, []string{ProblemHashIndex, ProblemTxIndex, ProblemReceiptIndex, ProblemTxTotal}, kinds)
+	_, err = chain.GetBlockByNumber(2)
+	assert.NotNil(t, err)
+
+	result, err = chain.Check(0, true)
+	require.Nil(t, err)
+	assert.Len(t, result.Problems, 4)
+	assert.Equal(t, 0, result.Unrepaired())
+	result, err = chain.Check(0, false)
+	require.Nil(t, err)
+	assert.Empty(t, result.Problems)
+	blk, err := chain.GetBlockByNumber(2)
+	require.Nil(t, err)
+	assert.Equal(t, b2.HeadHash(), blk.HeadHash())
+	_, err = chain.GetTx(b3.Txs[0].Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), chain.TxTotal())
+
+	// The missing tx data can't be repaired.
+	require.Nil(t, bc.blockChainDB.Delete(append(bTxPrefix, append(b2.HeadHash(), b2.Txs[1].Hash()...)...)))
+	result, err = chain.Check(1, true)
+	require.Nil(t, err)
+	require.Len(t, result.Problems, 1)
+	assert.Equal(t, ProblemTx, result.Problems[0].Kind)
+	assert.Equal(t, int64(2), result.Problems[0].Number)
+	assert.Equal(t, 1, result.Unrepaired())
+}
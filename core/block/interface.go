@@ -1,6 +1,9 @@
 package block
 
-import "github.com/iost-official/go-iost/core/tx"
+import (
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/db/kv"
+)
 
 //go:generate mockgen -destination ../mocks/mock_blockchain.go -package core_mock github.com/iost-official/go-iost/core/block Chain
 
@@ -14,13 +17,21 @@ type Chain interface {
 	Top() (*Block, error)
 	GetHashByNumber(number int64) ([]byte, error)
 	GetBlockByNumber(number int64) (*Block, error)
+	GetBlockHeadByNumber(number int64) (*Block, error)
 	GetBlockByHash(blockHash []byte) (*Block, error)
+	GetBlockWithReceiptsByHash(blockHash []byte) (*Block, error)
 	GetTx(hash []byte) (*tx.Tx, error)
 	HasTx(hash []byte) (bool, error)
 	GetReceipt(Hash []byte) (*tx.TxReceipt, error)
 	GetReceiptByTxHash(Hash []byte) (*tx.TxReceipt, error)
 	HasReceipt(hash []byte) (bool, error)
+	PutStateDigest(hash []byte, digest []byte) error
+	GetStateDigest(hash []byte) ([]byte, error)
 	Size() (int64, error)
+	Snapshot() (*kv.Snapshot, error)
+	Check(from int64, repair bool) (*CheckResult, error)
+	PrunedLength() int64
+	PruneBodies(to int64, limit int64) (int64, error)
 	Close()
 	AllDelaytx() ([]*tx.Tx, error)
 	Draw(int64, int64) string
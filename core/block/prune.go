@@ -0,0 +1,104 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iost-official/go-iost/common"
+)
+
+// ErrPruned is returned when the txs of the block are pruned.
+var ErrPruned = errors.New("txs of the block are pruned")
+
+// PrunedLength returns the length of the blocks whose txs are pruned, the txs of the genesis are never pruned.
+func (bc *BlockChain) PrunedLength() int64 {
+	bc.rw.RLock()
+	defer bc.rw.RUnlock()
+	return bc.prunedLength
+}
+
+func (bc *BlockChain) isPruned(number int64) bool {
+	return number > 0 && number < bc.PrunedLength()
+}
+
+// isPrunedBlock returns whether the txs of the block of the hash are pruned.
+func (bc *BlockChain) isPrunedBlock(hash []byte) bool {
+	ok, err := bc.blockChainDB.Has(append(prunedPrefix, hash...))
+	return err == nil && ok
+}
+
+// lowestNumber returns the number of the lowest block stored above the genesis, the chain started from a snapshot
+// has no block below the snapshot.
+func (bc *BlockChain) lowestNumber() (int64, error) {
+	iter := bc.blockChainDB.NewIteratorByRange(append(blockNumberPrefix, common.Int64ToBytes(1)...),
+		append(blockNumberPrefix, common.Int64ToBytes(math.MaxInt64)...), false)
+	defer iter.Release()
+	if !iter.Next() {
+		if err := iter.Error(); err != nil {
+			return 0, err
+		}
+		return bc.Length(), nil
+	}
+	return common.BytesToInt64(iter.Key()[len(blockNumberPrefix):]), nil
+}
+
+// PruneBodies deletes the txs of at most limit blocks from the pruned length, or the lowest block stored, to the
+// number to, and returns the number of the blocks pruned. The heads, the hashes, the receipts and the tx lookups are
+// kept, the txs and the receipts are still located by the tx hashes, and ErrPruned is returned when the txs are read,
+// except the delay txs not deferred yet.
+func (bc *BlockChain) PruneBodies(to int64, limit int64) (int64, error) {
+	from := bc.PrunedLength()
+	if from == 0 {
+		lowest, err := bc.lowestNumber()
+		if err != nil {
+			return 0, err
+		}
+		from = lowest
+	}
+	if to > bc.Length()-1 {
+		to = bc.Length() - 1
+	}
+	if to-from > limit {
+		to = from + limit
+	}
+	if to <= from {
+		return 0, nil
+	}
+
+	if err := bc.blockChainDB.BeginBatch(); err != nil {
+		return 0, err
+	}
+	for number := from; number < to; number++ {
+		hash, err := bc.GetHashByNumber(number)
+		if err != nil {
+			return 0, err
+		}
+		blockByte, err := bc.getBlockByteByHash(hash)
+		if err != nil {
+			return 0, err
+		}
+		var blk Block
+		if err := blk.Decode(blockByte); err != nil {
+			return 0, fmt.Errorf("fail to decode block %v: %v", number, err)
+		}
+		for _, tHash := range blk.TxHashes {
+			if err := bc.blockChainDB.Delete(append(bTxPrefix, append(append([]byte{}, hash...), tHash...)...)); err != nil {
+				return 0, err
+			}
+		}
+		if err := bc.blockChainDB.Put(append(prunedPrefix, hash...), common.Int64ToBytes(number)); err != nil {
+			return 0, err
+		}
+	}
+	if err := bc.blockChainDB.Put(prunedLength, common.Int64ToBytes(to)); err != nil {
+		return 0, err
+	}
+	if err := bc.blockChainDB.CommitBatch(); err != nil {
+		return 0, err
+	}
+	bc.rw.Lock()
+	bc.prunedLength = to
+	bc.rw.Unlock()
+	return to - from, nil
+}
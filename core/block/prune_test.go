@@ -0,0 +1,132 @@
+package block
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushBlocks pushes the blocks of the numbers [from, to) to the chain, each with two txs.
+func pushBlocks(t *testing.T, chain Chain, from, to int64) []*Block {
+	var parent []byte
+	var blocks []*Block
+	for i := from; i < to; i++ {
+		blk := &Block{
+			Head: &BlockHead{
+				ParentHash: parent,
+				Number:     i,
+				Time:       i + 1,
+			},
+			Sign: &crypto.Signature{},
+		}
+		for j := int64(0); j < 2; j++ {
+			// The first tx of each block is a delay tx.
+			tt := tx.NewTx(nil, nil, 1000, 100, i*10+j, 1-j, 0)
+			blk.Txs = append(blk.Txs, tt)
+			blk.Receipts = append(blk.Receipts, tx.NewTxReceipt(tt.Hash()))
+		}
+		require.Nil(t, blk.CalculateHeadHash())
+		require.Nil(t, chain.Push(blk))
+		parent = blk.HeadHash()
+		blocks = append(blocks, blk)
+	}
+	return blocks
+}
+
+func TestPruneBodies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prune")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	chain, err := NewBlockChain(dir)
+	require.Nil(t, err)
+
+	blocks := pushBlocks(t, chain, 0, 6)
+
+	// The genesis is never pruned, and the limit caps the blocks pruned at once.
+	n, err := chain.PruneBodies(4, 2)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, int64(3), chain.PrunedLength())
+	n, err = chain.PruneBodies(4, 2)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, int64(4), chain.PrunedLength())
+	n, err = chain.PruneBodies(3, 2)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	_, err = chain.GetBlockByNumber(0)
+	assert.Nil(t, err)
+	_, err = chain.GetTx(blocks[0].Txs[0].Hash())
+	assert.Nil(t, err)
+	for _, blk := range blocks[1:4] {
+		_, err = chain.GetBlockByNumber(blk.Head.Number)
+		assert.Equal(t, ErrPruned, err)
+		_, err = chain.GetTx(blk.Txs[1].Hash())
+		assert.Equal(t, ErrPruned, err)
+
+		head, err := chain.GetBlockHeadByNumber(blk.Head.Number)
+		require.Nil(t, err)
+		assert.Equal(t, blk.HeadHash(), head.HeadHash())
+		b, err := chain.GetBlockWithReceiptsByHash(blk.HeadHash())
+		require.Nil(t, err)
+		assert.Nil(t, b.Txs)
+		assert.Equal(t, blk.Txs[1].Hash(), b.TxHashes[1])
+		assert.Len(t, b.Receipts, 2)
+		r, err := chain.GetReceiptByTxHash(blk.Txs[1].Hash())
+		require.Nil(t, err)
+		assert.Equal(t, blk.Receipts[1].Hash(), r.Hash())
+		has, err := chain.HasTx(blk.Txs[1].Hash())
+		require.Nil(t, err)
+		assert.True(t, has)
+	}
+	// The delay tx is kept until the defer tx referring to it.
+	dt, err := chain.GetTx(blocks[2].Txs[0].Hash())
+	require.Nil(t, err)
+	assert.Equal(t, blocks[2].Txs[0].Hash(), dt.Hash())
+
+	blk, err := chain.GetBlockByNumber(4)
+	require.Nil(t, err)
+	assert.Len(t, blk.Txs, 2)
+
+	// The pruned length is kept after reopening, and the pruned txs aren't reported by Check.
+	chain.Close()
+	chain, err = NewBlockChain(dir)
+	require.Nil(t, err)
+	defer chain.Close()
+	assert.Equal(t, int64(4), chain.PrunedLength())
+	_, err = chain.GetBlockByNumber(2)
+	assert.Equal(t, ErrPruned, err)
+	result, err := chain.Check(0, false)
+	require.Nil(t, err)
+	assert.Empty(t, result.Problems)
+	assert.Equal(t, int64(12), result.Txs)
+}
+
+func TestPruneBodiesFromSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prune")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	chain, err := NewBlockChain(dir)
+	require.Nil(t, err)
+	defer chain.Close()
+
+	// The chain started from the snapshot of block 9 has no block below 10.
+	chain.SetLength(10)
+	blocks := pushBlocks(t, chain, 10, 16)
+	n, err := chain.PruneBodies(14, 10)
+	require.Nil(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.Equal(t, int64(14), chain.PrunedLength())
+	for _, blk := range blocks[:4] {
+		_, err = chain.GetTx(blk.Txs[1].Hash())
+		assert.Equal(t, ErrPruned, err)
+	}
+	_, err = chain.GetTx(blocks[4].Txs[1].Hash())
+	assert.Nil(t, err)
+}
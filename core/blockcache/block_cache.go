@@ -36,6 +36,11 @@ type conAlgo interface {
 const (
 	// DelSingleBlockTime ...
 	DelSingleBlockTime int64 = 10
+	// MinBlockPruneDepth is the min depth below the LIB of the blocks whose txs are pruned, the txs of the recent
+	// blocks are read by the tx pool.
+	MinBlockPruneDepth int64 = 1000
+	// pruneBlocksPerFlush is the max number of the blocks whose txs are pruned in a flush.
+	pruneBlocksPerFlush int64 = 100
 )
 
 // BCNType type of BlockCacheNode
@@ -239,10 +244,13 @@ type BlockCache interface {
 	LinkedRoot() *BlockCacheNode
 	Head() *BlockCacheNode
 	Draw() string
+	Tree() *Tree
 	CleanDir() error
 	Recover(p conAlgo) (err error)
 	NewWAL(config *common.Config) (err error)
 	AddNodeToWAL(bcn *BlockCacheNode)
+	Checkpoint(fn func(root *BlockCacheNode) error) error
+	PinWAL(dir string) (*wal.Pin, error)
 }
 
 // BlockCacheImpl is the implementation of BlockCache
@@ -260,6 +268,8 @@ type BlockCacheImpl struct { //nolint:golint
 	blockChain  block.Chain
 	stateDB     db.MVCCDB
 	wal         *wal.WAL
+	flushMu     sync.Mutex
+	pruneDepth  int64
 }
 
 // CleanDir used in test to clean dir
@@ -314,7 +324,7 @@ func (bc *BlockCacheImpl) nmdel(num int64) {
 
 // NewBlockCache return a new BlockCache instance
 func NewBlockCache(baseVariable global.BaseVariable) (*BlockCacheImpl, error) {
-	w, err := wal.Create(baseVariable.Config().DB.LdbPath+blockCacheWALDir, []byte("block_cache_wal"))
+	w, err := wal.Create(WALDir(baseVariable.Config()), []byte("block_cache_wal"))
 	if err != nil {
 		return nil, err
 	}
@@ -329,6 +339,13 @@ func NewBlockCache(baseVariable global.BaseVariable) (*BlockCacheImpl, error) {
 		wal:         w,
 	}
 	bc.linkedRoot.Head.Number = -1
+	if depth := baseVariable.Config().DB.BlockPruneDepth; depth > 0 {
+		if depth < MinBlockPruneDepth {
+			ilog.Warnf("block prune depth %v is less than %v, use %v", depth, MinBlockPruneDepth, MinBlockPruneDepth)
+			depth = MinBlockPruneDepth
+		}
+		bc.pruneDepth = depth
+	}
 
 	var lib *block.Block
 	if baseVariable.Config().Snapshot.Enable {
@@ -367,8 +384,8 @@ func NewBlockCache(baseVariable global.BaseVariable) (*BlockCacheImpl, error) {
 
 // NewWAL New wal when old one is not recoverable. Move Old File into Corrupted for later analysis.
 func (bc *BlockCacheImpl) NewWAL(config *common.Config) (err error) {
-	walPath := config.DB.LdbPath + blockCacheWALDir
-	corruptWalPath := config.DB.LdbPath + blockCacheWALDir + "Corrupted"
+	walPath := WALDir(config)
+	corruptWalPath := walPath + "Corrupted"
 	os.Rename(walPath, corruptWalPath)
 	bc.wal, err = wal.Create(walPath, []byte("block_cache_wal"))
 	return
@@ -638,6 +655,8 @@ func (bc *BlockCacheImpl) delSingle() {
 
 // Flush is save a block
 func (bc *BlockCacheImpl) Flush(bcn *BlockCacheNode) {
+	bc.flushMu.Lock()
+	defer bc.flushMu.Unlock()
 	parent := bcn.GetParent()
 	if parent != bc.LinkedRoot() {
 		ilog.Errorf("block isn't blockcache root's child")
@@ -664,6 +683,13 @@ func (bc *BlockCacheImpl) Flush(bcn *BlockCacheNode) {
 
 	if err != nil {
 		ilog.Errorf("flush mvcc error: %v", err)
+	} else if err := bc.saveStateDigest(bcn.HeadHash()); err != nil {
+		ilog.Errorf("save state digest error: %v", err)
+	}
+	if bc.pruneDepth > 0 {
+		if _, err := bc.blockChain.PruneBodies(bcn.Head.Number-bc.pruneDepth, pruneBlocksPerFlush); err != nil {
+			ilog.Errorf("prune block txs error: %v", err)
+		}
 	}
 
 	bcn.removeValidWitness(bcn)
@@ -701,6 +727,28 @@ func (bc *BlockCacheImpl) Flush(bcn *BlockCacheNode) {
 	bc.flushWAL(bcn)
 }
 
+// Checkpoint calls fn with the linked root, no block is flushed to the dbs until fn returns.
+func (bc *BlockCacheImpl) Checkpoint(fn func(root *BlockCacheNode) error) error {
+	bc.flushMu.Lock()
+	defer bc.flushMu.Unlock()
+	return fn(bc.LinkedRoot())
+}
+
+// PinWAL pins the segments of the wal in dir, see wal.Pin. It's called in Checkpoint to pin the wal at the
+// linked root, the pinned segments are copied after fn returns.
+func (bc *BlockCacheImpl) PinWAL(dir string) (*wal.Pin, error) {
+	return bc.wal.Pin(dir)
+}
+
+// saveStateDigest saves the digest of the state writes just flushed with the block.
+func (bc *BlockCacheImpl) saveStateDigest(hash []byte) error {
+	digest, err := bc.stateDB.Digest()
+	if err != nil {
+		return err
+	}
+	return bc.blockChain.PutStateDigest(hash, digest.Encode())
+}
+
 func (bc *BlockCacheImpl) flushWAL(h *BlockCacheNode) error {
 	err := bc.writeUpdateActiveWAL(h)
 	if err != nil {
@@ -846,6 +894,48 @@ func (bcn *BlockCacheNode) drawChildren(root treeprint.Tree) {
 	}
 }
 
+// WALDir returns the directory of the block cache wal of the config.
+func WALDir(conf *common.Config) string {
+	return conf.DB.LdbPath + blockCacheWALDir
+}
+
+// WALRecord is the decoded entry of the block cache wal.
+type WALRecord struct {
+	Type BcMessageType
+	// Block and SerialNum are of the LinkType record, the block linked to the cache.
+	Block     *block.Block
+	SerialNum int64
+	// BlockHash is of the UpdateActiveType record, the block whose active witness list is updated.
+	BlockHash   []byte
+	WitnessList *WitnessList
+}
+
+// DecodeWALEntry decodes the data of an entry of the block cache wal.
+func DecodeWALEntry(data []byte) (*WALRecord, error) {
+	var bcMessage BcMessage
+	if err := proto.Unmarshal(data, &bcMessage); err != nil {
+		return nil, err
+	}
+	r := &WALRecord{Type: bcMessage.Type}
+	switch bcMessage.Type {
+	case BcMessageType_LinkType:
+		blk, witnessList, serialNum, err := decodeBCN(bcMessage.Data)
+		if err != nil {
+			return nil, err
+		}
+		r.Block, r.WitnessList, r.SerialNum = &blk, &witnessList, serialNum
+	case BcMessageType_UpdateActiveType:
+		hash, witnessList, err := decodeUpdateActive(bcMessage.Data)
+		if err != nil {
+			return nil, err
+		}
+		r.BlockHash, r.WitnessList = hash, &witnessList
+	default:
+		return nil, fmt.Errorf("unknown block cache message type %v", bcMessage.Type)
+	}
+	return r, nil
+}
+
 // CleanBlockCacheWAL used in test to clean dir
 func CleanBlockCacheWAL() error {
 	return os.RemoveAll(blockCacheWALDir)
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/golang/mock/gomock"
 	core_mock "github.com/iost-official/go-iost/core/mocks"
+	"github.com/iost-official/go-iost/db"
 	db_mock "github.com/iost-official/go-iost/db/mocks"
 
 	"github.com/iost-official/go-iost/common"
@@ -54,6 +55,7 @@ func TestBlockCache(t *testing.T) {
 	s3 := genBlock(s2, "w4", 4)
 	statedb := db_mock.NewMockMVCCDB(ctl)
 	statedb.EXPECT().Flush(Any()).AnyTimes().Return(nil)
+	statedb.EXPECT().Digest().AnyTimes().Return(&db.Digest{}, nil)
 	statedb.EXPECT().Fork().AnyTimes().Return(statedb)
 	statedb.EXPECT().Checkout(Any()).AnyTimes().Return(true)
 	statedb.EXPECT().Size().AnyTimes().Return(int64(10000), nil)
@@ -76,6 +78,7 @@ func TestBlockCache(t *testing.T) {
 	base := core_mock.NewMockChain(ctl)
 	base.EXPECT().Top().AnyTimes().Return(b0, nil)
 	base.EXPECT().Push(Any()).AnyTimes().Return(nil)
+	base.EXPECT().PutStateDigest(Any(), Any()).AnyTimes().Return(nil)
 	base.EXPECT().TxTotal().AnyTimes().Return(int64(10))
 	base.EXPECT().Size().AnyTimes().Return(int64(10000), nil)
 	global := core_mock.NewMockBaseVariable(ctl)
@@ -198,6 +201,7 @@ func TestVote(t *testing.T) {
 
 	statedb := db_mock.NewMockMVCCDB(ctl)
 	statedb.EXPECT().Flush(Any()).AnyTimes().Return(nil)
+	statedb.EXPECT().Digest().AnyTimes().Return(&db.Digest{}, nil)
 	statedb.EXPECT().Fork().AnyTimes().Return(statedb)
 	statedb.EXPECT().Checkout(Any()).AnyTimes().Return(true)
 
@@ -220,6 +224,7 @@ func TestVote(t *testing.T) {
 	base := core_mock.NewMockChain(ctl)
 	base.EXPECT().Top().AnyTimes().Return(b0, nil)
 	base.EXPECT().Push(Any()).AnyTimes().Return(nil)
+	base.EXPECT().PutStateDigest(Any(), Any()).AnyTimes().Return(nil)
 	global := core_mock.NewMockBaseVariable(ctl)
 	global.EXPECT().BlockChain().AnyTimes().Return(base)
 	global.EXPECT().StateDB().AnyTimes().Return(statedb)
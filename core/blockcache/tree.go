@@ -0,0 +1,93 @@
+package blockcache
+
+import (
+	"sort"
+
+	"github.com/iost-official/go-iost/common"
+)
+
+// String returns the name of the BlockCacheNode type.
+func (t BCNType) String() string {
+	switch t {
+	case Linked:
+		return "linked"
+	case Single:
+		return "single"
+	case Virtual:
+		return "virtual"
+	default:
+		return "unknown"
+	}
+}
+
+// TreeNode is a block in the structured view of the block cache.
+type TreeNode struct {
+	Number        int64       `json:"number"`
+	Hash          string      `json:"hash"`
+	ParentHash    string      `json:"parentHash"`
+	Witness       string      `json:"witness"`
+	Time          int64       `json:"time"`
+	TxCount       int         `json:"txCount"`
+	Type          string      `json:"type"`
+	Confirmations int         `json:"confirmations"`
+	Children      []*TreeNode `json:"children,omitempty"`
+}
+
+// Tree is the structured view of the block cache.
+// The linked root is the last irreversible block, and the single roots are the trees not linked to it yet.
+type Tree struct {
+	HeadNumber  int64       `json:"headNumber"`
+	HeadHash    string      `json:"headHash"`
+	LinkedRoot  *TreeNode   `json:"linkedRoot"`
+	SingleRoots []*TreeNode `json:"singleRoots"`
+}
+
+// Tree returns the structured view of the linkedroot's and singleroot's trees.
+func (bc *BlockCacheImpl) Tree() *Tree {
+	head := bc.Head()
+	tree := &Tree{
+		HeadNumber:  head.Head.Number,
+		HeadHash:    common.Base58Encode(head.HeadHash()),
+		LinkedRoot:  bc.LinkedRoot().treeNode(),
+		SingleRoots: make([]*TreeNode, 0),
+	}
+	for c := range bc.singleRoot.Children {
+		tree.SingleRoots = append(tree.SingleRoots, c.treeNode())
+	}
+	sortTreeNodes(tree.SingleRoots)
+	return tree
+}
+
+func (bcn *BlockCacheNode) treeNode() *TreeNode {
+	n := &TreeNode{
+		Number:        bcn.Head.Number,
+		Witness:       bcn.Head.Witness,
+		Time:          bcn.Head.Time,
+		TxCount:       len(bcn.Txs),
+		Type:          bcn.Type.String(),
+		Confirmations: len(bcn.ValidWitness),
+	}
+	for c := range bcn.Children {
+		n.Children = append(n.Children, c.treeNode())
+	}
+	sortTreeNodes(n.Children)
+	if bcn.Type == Virtual {
+		// A virtual node stands for a missing block, whose hash is only known by its children.
+		if len(n.Children) > 0 {
+			n.Hash = n.Children[0].ParentHash
+		}
+		return n
+	}
+	n.Hash = common.Base58Encode(bcn.HeadHash())
+	n.ParentHash = common.Base58Encode(bcn.Head.ParentHash)
+	return n
+}
+
+func sortTreeNodes(nodes []*TreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Number != nodes[j].Number {
+			return nodes[i].Number < nodes[j].Number
+		}
+		return nodes[i].Hash < nodes[j].Hash
+	})
+}
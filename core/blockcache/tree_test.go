@@ -0,0 +1,46 @@
+package blockcache
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	root := NewBCN(nil, genBlock(nil, "w0", 0))
+	root.Type = Linked
+	b1 := NewBCN(root, genBlock(root.Block, "w1", 1))
+	b2 := NewBCN(b1, genBlock(b1.Block, "w2", 2))
+	b2a := NewBCN(b1, genBlock(b1.Block, "w3", 2))
+	for _, n := range []*BlockCacheNode{b1, b2, b2a} {
+		n.Type = Linked
+		n.updateValidWitness(n.GetParent(), n.Head.Witness)
+	}
+
+	singleRoot := NewBCN(nil, nil)
+	s3 := genBlock(&block.Block{Head: &block.BlockHead{Number: 2}}, "w4", 3)
+	virtual := NewVirtualBCN(singleRoot, s3)
+	NewBCN(virtual, s3)
+
+	bc := &BlockCacheImpl{linkedRoot: root, singleRoot: singleRoot, head: b2}
+	tree := bc.Tree()
+	assert.Equal(t, int64(2), tree.HeadNumber)
+	assert.Equal(t, common.Base58Encode(b2.HeadHash()), tree.HeadHash)
+
+	assert.Equal(t, int64(0), tree.LinkedRoot.Number)
+	assert.Equal(t, 1, len(tree.LinkedRoot.Children))
+	n1 := tree.LinkedRoot.Children[0]
+	assert.Equal(t, "w1", n1.Witness)
+	assert.Equal(t, "linked", n1.Type)
+	assert.Equal(t, common.Base58Encode(root.HeadHash()), n1.ParentHash)
+	assert.Equal(t, 2, len(n1.Children))
+	assert.Equal(t, 2, n1.Children[0].Confirmations)
+
+	assert.Equal(t, 1, len(tree.SingleRoots))
+	v := tree.SingleRoots[0]
+	assert.Equal(t, "virtual", v.Type)
+	assert.Equal(t, common.Base58Encode(s3.Head.ParentHash), v.Hash)
+	assert.Equal(t, "single", v.Children[0].Type)
+}
@@ -18,6 +18,7 @@ type Topic int
 const (
 	ContractReceipt Topic = iota
 	ContractEvent
+	Equivocation
 )
 
 func (t Topic) String() string {
@@ -26,6 +27,8 @@ func (t Topic) String() string {
 		return "ContractReceipt"
 	case ContractEvent:
 		return "ContractEvent"
+	case Equivocation:
+		return "Equivocation"
 	default:
 		return "unknown_topic:" + strconv.Itoa(int(t))
 	}
@@ -6,9 +6,12 @@ import (
 	"sync"
 
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/evidence"
 	"github.com/iost-official/go-iost/consensus/snapshot"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
 	"github.com/iost-official/go-iost/ilog"
 )
 
@@ -42,6 +45,7 @@ func (m TMode) String() string {
 type BaseVariableImpl struct {
 	blockChain    block.Chain
 	stateDB       db.MVCCDB
+	evidenceDB    *evidence.Store
 	mode          TMode
 	modeMutex     *sync.RWMutex
 	continuousNum int
@@ -50,15 +54,24 @@ type BaseVariableImpl struct {
 
 // New return a BaseVariable instance
 func New(conf *common.Config) (*BaseVariableImpl, error) {
+	var imported string
 	if conf.Snapshot.Enable {
 		conf.Snapshot.Enable = false
 		s, err := os.Stat(conf.DB.LdbPath + "BlockChainDB")
 		if err == nil && s.IsDir() {
 			ilog.Warnln("start iserver with the snapshot failed, blockchain db already has.")
 		} else {
-			s, err = os.Stat(conf.DB.LdbPath + "StateDB")
-			if err == nil && s.IsDir() {
-				ilog.Warnln("start iserver with the snapshot failed, state db already has.")
+			_, err = os.Stat(conf.DB.LdbPath + "StateDB")
+			if err == nil {
+				// Only the state db imported by `iserver snapshot import` is started from, the one left by a
+				// failed start isn't.
+				hash, ok := snapshot.Imported(conf.DB.LdbPath)
+				if !ok {
+					return nil, fmt.Errorf("state db %v isn't imported from a snapshot, remove it to start with the snapshot", conf.DB.LdbPath+"StateDB")
+				}
+				ilog.Infoln("start iserver with the snapshot in the state db.")
+				imported = hash
+				conf.Snapshot.Enable = true
 			} else {
 				err = snapshot.FromSnapshot(conf)
 				if err != nil {
@@ -69,19 +82,47 @@ func New(conf *common.Config) (*BaseVariableImpl, error) {
 		}
 	}
 
-	blockChain, err := block.NewBlockChain(conf.DB.LdbPath + "BlockChainDB")
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	blockChain, err := block.NewBlockChainWithStorage(conf.DB.LdbPath+"BlockChainDB", storageType)
 	if err != nil {
 		return nil, fmt.Errorf("new blockchain failed, stop the program. err: %v", err)
 	}
 
-	stateDB, err := db.NewMVCCDB(conf.DB.LdbPath + "StateDB")
+	cacheType, err := mvcc.ParseCacheType(conf.DB.Cache)
+	if err != nil {
+		return nil, err
+	}
+	stateDB, err := db.NewMVCCDBWithStorage(conf.DB.LdbPath+"StateDB", storageType, cacheType)
 	if err != nil {
 		return nil, fmt.Errorf("new statedb failed, stop the program. err: %v", err)
 	}
+	if imported != "" {
+		blk, err := snapshot.Load(stateDB)
+		if err != nil {
+			return nil, err
+		}
+		if common.Base58Encode(blk.HeadHash()) != imported {
+			return nil, fmt.Errorf("state db is of block %v, but the snapshot imported is of block %v", common.Base58Encode(blk.HeadHash()), imported)
+		}
+		// The node starts from the blockchain db from now on.
+		err = os.Remove(conf.DB.LdbPath + snapshot.ImportedFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	evidenceDB, err := evidence.NewStoreWithStorage(conf.DB.LdbPath+"EvidenceDB", storageType)
+	if err != nil {
+		return nil, fmt.Errorf("new evidencedb failed, stop the program. err: %v", err)
+	}
 	return &BaseVariableImpl{
 		blockChain:    blockChain,
 		stateDB:       stateDB,
+		evidenceDB:    evidenceDB,
 		mode:          ModeInit,
 		modeMutex:     new(sync.RWMutex),
 		continuousNum: 6,
@@ -94,6 +135,11 @@ func (g *BaseVariableImpl) StateDB() db.MVCCDB {
 	return g.stateDB
 }
 
+// EvidenceDB return the evidence database
+func (g *BaseVariableImpl) EvidenceDB() *evidence.Store {
+	return g.evidenceDB
+}
+
 // BlockChain return the block chain
 func (g *BaseVariableImpl) BlockChain() block.Chain {
 	return g.blockChain
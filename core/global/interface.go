@@ -2,6 +2,7 @@ package global
 
 import (
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/evidence"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/db"
 )
@@ -11,6 +12,7 @@ import (
 // BaseVariable defines BaseVariable's API.
 type BaseVariable interface {
 	StateDB() db.MVCCDB
+	EvidenceDB() *evidence.Store
 	Config() *common.Config
 	BlockChain() block.Chain
 	Mode() TMode
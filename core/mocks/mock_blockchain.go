@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iost-official/go-iost/core/block"
 	tx "github.com/iost-official/go-iost/core/tx"
+	kv "github.com/iost-official/go-iost/db/kv"
 	reflect "reflect"
 )
 
@@ -47,6 +48,19 @@ func (mr *MockChainMockRecorder) AllDelaytx() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllDelaytx", reflect.TypeOf((*MockChain)(nil).AllDelaytx))
 }
 
+// Check mocks base method
+func (m *MockChain) Check(arg0 int64, arg1 bool) (*block.CheckResult, error) {
+	ret := m.ctrl.Call(m, "Check", arg0, arg1)
+	ret0, _ := ret[0].(*block.CheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check
+func (mr *MockChainMockRecorder) Check(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockChain)(nil).Check), arg0, arg1)
+}
+
 // CheckLength mocks base method
 func (m *MockChain) CheckLength() {
 	m.ctrl.Call(m, "CheckLength")
@@ -105,6 +119,32 @@ func (mr *MockChainMockRecorder) GetBlockByNumber(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockChain)(nil).GetBlockByNumber), arg0)
 }
 
+// GetBlockHeadByNumber mocks base method
+func (m *MockChain) GetBlockHeadByNumber(arg0 int64) (*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeadByNumber", arg0)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeadByNumber indicates an expected call of GetBlockHeadByNumber
+func (mr *MockChainMockRecorder) GetBlockHeadByNumber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeadByNumber", reflect.TypeOf((*MockChain)(nil).GetBlockHeadByNumber), arg0)
+}
+
+// GetBlockWithReceiptsByHash mocks base method
+func (m *MockChain) GetBlockWithReceiptsByHash(arg0 []byte) (*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetBlockWithReceiptsByHash", arg0)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockWithReceiptsByHash indicates an expected call of GetBlockWithReceiptsByHash
+func (mr *MockChainMockRecorder) GetBlockWithReceiptsByHash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockWithReceiptsByHash", reflect.TypeOf((*MockChain)(nil).GetBlockWithReceiptsByHash), arg0)
+}
+
 // GetHashByNumber mocks base method
 func (m *MockChain) GetHashByNumber(arg0 int64) ([]byte, error) {
 	ret := m.ctrl.Call(m, "GetHashByNumber", arg0)
@@ -144,6 +184,19 @@ func (mr *MockChainMockRecorder) GetReceiptByTxHash(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByTxHash", reflect.TypeOf((*MockChain)(nil).GetReceiptByTxHash), arg0)
 }
 
+// GetStateDigest mocks base method
+func (m *MockChain) GetStateDigest(arg0 []byte) ([]byte, error) {
+	ret := m.ctrl.Call(m, "GetStateDigest", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateDigest indicates an expected call of GetStateDigest
+func (mr *MockChainMockRecorder) GetStateDigest(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateDigest", reflect.TypeOf((*MockChain)(nil).GetStateDigest), arg0)
+}
+
 // GetTx mocks base method
 func (m *MockChain) GetTx(arg0 []byte) (*tx.Tx, error) {
 	ret := m.ctrl.Call(m, "GetTx", arg0)
@@ -195,6 +248,31 @@ func (mr *MockChainMockRecorder) Length() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Length", reflect.TypeOf((*MockChain)(nil).Length))
 }
 
+// PruneBodies mocks base method
+func (m *MockChain) PruneBodies(arg0, arg1 int64) (int64, error) {
+	ret := m.ctrl.Call(m, "PruneBodies", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneBodies indicates an expected call of PruneBodies
+func (mr *MockChainMockRecorder) PruneBodies(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBodies", reflect.TypeOf((*MockChain)(nil).PruneBodies), arg0, arg1)
+}
+
+// PrunedLength mocks base method
+func (m *MockChain) PrunedLength() int64 {
+	ret := m.ctrl.Call(m, "PrunedLength")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// PrunedLength indicates an expected call of PrunedLength
+func (mr *MockChainMockRecorder) PrunedLength() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrunedLength", reflect.TypeOf((*MockChain)(nil).PrunedLength))
+}
+
 // Push mocks base method
 func (m *MockChain) Push(arg0 *block.Block) error {
 	ret := m.ctrl.Call(m, "Push", arg0)
@@ -207,6 +285,18 @@ func (mr *MockChainMockRecorder) Push(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockChain)(nil).Push), arg0)
 }
 
+// PutStateDigest mocks base method
+func (m *MockChain) PutStateDigest(arg0, arg1 []byte) error {
+	ret := m.ctrl.Call(m, "PutStateDigest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutStateDigest indicates an expected call of PutStateDigest
+func (mr *MockChainMockRecorder) PutStateDigest(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutStateDigest", reflect.TypeOf((*MockChain)(nil).PutStateDigest), arg0, arg1)
+}
+
 // SetLength mocks base method
 func (m *MockChain) SetLength(arg0 int64) {
 	m.ctrl.Call(m, "SetLength", arg0)
@@ -230,6 +320,19 @@ func (mr *MockChainMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockChain)(nil).Size))
 }
 
+// Snapshot mocks base method
+func (m *MockChain) Snapshot() (*kv.Snapshot, error) {
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(*kv.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockChainMockRecorder) Snapshot() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockChain)(nil).Snapshot))
+}
+
 // Top mocks base method
 func (m *MockChain) Top() (*block.Block, error) {
 	ret := m.ctrl.Call(m, "Top")
@@ -7,6 +7,7 @@ package core_mock
 import (
 	gomock "github.com/golang/mock/gomock"
 	common "github.com/iost-official/go-iost/common"
+	evidence "github.com/iost-official/go-iost/consensus/evidence"
 	block "github.com/iost-official/go-iost/core/block"
 	global "github.com/iost-official/go-iost/core/global"
 	db "github.com/iost-official/go-iost/db"
@@ -72,6 +73,18 @@ func (mr *MockBaseVariableMockRecorder) Continuous() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Continuous", reflect.TypeOf((*MockBaseVariable)(nil).Continuous))
 }
 
+// EvidenceDB mocks base method
+func (m *MockBaseVariable) EvidenceDB() *evidence.Store {
+	ret := m.ctrl.Call(m, "EvidenceDB")
+	ret0, _ := ret[0].(*evidence.Store)
+	return ret0
+}
+
+// EvidenceDB indicates an expected call of EvidenceDB
+func (mr *MockBaseVariableMockRecorder) EvidenceDB() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvidenceDB", reflect.TypeOf((*MockBaseVariable)(nil).EvidenceDB))
+}
+
 // Mode mocks base method
 func (m *MockBaseVariable) Mode() global.TMode {
 	ret := m.ctrl.Call(m, "Mode")
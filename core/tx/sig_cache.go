@@ -0,0 +1,80 @@
+package tx
+
+import (
+	"sync"
+
+	"github.com/hashicorp/golang-lru"
+	"github.com/iost-official/go-iost/metrics"
+)
+
+var (
+	sigCacheSize = 100000
+
+	metricsSigCacheCount = metrics.NewCounter("iost_tx_sig_cache_count", []string{"result"})
+
+	sigCache = NewSigCache(sigCacheSize)
+)
+
+// SigCache is a bounded cache of tx hashes whose signatures have been verified.
+//
+// It is shared by the tx pool and block verification, so a tx checked when it
+// enters the pool is not checked again when a block containing it arrives.
+// All entries are dropped when the chain id changes.
+type SigCache struct {
+	cache   *lru.Cache
+	chainID uint32
+	mu      sync.Mutex
+}
+
+// NewSigCache returns a SigCache holding at most size hashes.
+func NewSigCache(size int) *SigCache {
+	c, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &SigCache{
+		cache:   c,
+		chainID: ChainID,
+	}
+}
+
+func (c *SigCache) checkChainID() {
+	c.mu.Lock()
+	if c.chainID != ChainID {
+		c.cache.Purge()
+		c.chainID = ChainID
+	}
+	c.mu.Unlock()
+}
+
+func (c *SigCache) contains(hash []byte) bool {
+	c.checkChainID()
+	return c.cache.Contains(string(hash))
+}
+
+// Has returns whether the signatures of the tx with the given hash have been verified.
+func (c *SigCache) Has(hash []byte) bool {
+	ok := c.contains(hash)
+	if ok {
+		metricsSigCacheCount.Add(1, map[string]string{"result": "hit"})
+	} else {
+		metricsSigCacheCount.Add(1, map[string]string{"result": "miss"})
+	}
+	return ok
+}
+
+// Add records that the signatures of the tx with the given hash are valid.
+func (c *SigCache) Add(hash []byte) {
+	c.checkChainID()
+	c.cache.Add(string(hash), struct{}{})
+}
+
+// Len returns the number of hashes in the cache.
+func (c *SigCache) Len() int {
+	return c.cache.Len()
+}
+
+// Purge removes all hashes from the cache.
+func (c *SigCache) Purge() {
+	c.cache.Purge()
+}
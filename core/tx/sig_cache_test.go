@@ -0,0 +1,46 @@
+package tx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigCache(t *testing.T) {
+	c := NewSigCache(2)
+	c.Add([]byte("a"))
+	c.Add([]byte("b"))
+	assert.True(t, c.Has([]byte("a")))
+	assert.True(t, c.Has([]byte("b")))
+
+	c.Add([]byte("c"))
+	assert.Equal(t, 2, c.Len())
+	assert.False(t, c.Has([]byte("a")))
+
+	origin := ChainID
+	ChainID = origin + 1
+	defer func() { ChainID = origin }()
+	assert.False(t, c.Has([]byte("c")))
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestVerifySelfSigCache(t *testing.T) {
+	sigCache.Purge()
+	a1, err := account.NewKeyPair(nil, crypto.Ed25519)
+	assert.Nil(t, err)
+	actions := []*Action{{Contract: "contract1", ActionName: "actionname1", Data: "[]"}}
+	tx := NewTx(actions, nil, 1000000, 100, time.Now().Add(time.Minute).UnixNano(), 0, ChainID)
+	tx, err = SignTx(tx, "publisher", []*account.KeyPair{a1})
+	assert.Nil(t, err)
+
+	assert.Nil(t, tx.VerifySelf())
+	assert.True(t, sigCache.Has(common.Sha3(tx.ToBytes(Full))))
+	assert.Nil(t, tx.VerifySelf())
+
+	tx.PublishSigns[0].Sig = []byte("hello")
+	assert.EqualError(t, tx.VerifySelf(), "publisher error")
+}
@@ -346,6 +346,12 @@ func (t *Tx) VerifySelf() error { // nolint
 	if t.IsDefer() {
 		return nil
 	}
+	// The hash is recomputed rather than taken from t.Hash(), which is memoized
+	// and would not reflect fields changed after the first call.
+	hash := common.Sha3(t.ToBytes(Full))
+	if sigCache.Has(hash) {
+		return nil
+	}
 	baseHash := t.baseHash()
 	//signerSet := make(map[string]bool)
 	for _, sign := range t.Signs {
@@ -369,9 +375,58 @@ func (t *Tx) VerifySelf() error { // nolint
 			return fmt.Errorf("publisher error")
 		}
 	}
+	sigCache.Add(hash)
 	return nil
 }
 
+// BatchVerifySigns verifies the signatures of txs in one batch.
+//
+// Txs whose signatures are all valid are recorded in the signature cache, so
+// the following VerifySelf calls skip the signature checks for them. Invalid
+// txs are left out and get a detailed error from VerifySelf.
+func BatchVerifySigns(txs []*Tx) {
+	hashes := make([][]byte, len(txs))
+	sigs := make([]*crypto.Signature, 0)
+	infos := make([][]byte, 0)
+	owners := make([]int, 0)
+	for i, t := range txs {
+		if t.IsDefer() || len(t.PublishSigns) == 0 {
+			continue
+		}
+		hash := common.Sha3(t.ToBytes(Full))
+		if sigCache.contains(hash) {
+			continue
+		}
+		hashes[i] = hash
+		baseHash := t.baseHash()
+		for _, sign := range t.Signs {
+			sigs = append(sigs, sign)
+			infos = append(infos, baseHash)
+			owners = append(owners, i)
+		}
+		publishHash := t.publishHash()
+		for _, sign := range t.PublishSigns {
+			sigs = append(sigs, sign)
+			infos = append(infos, publishHash)
+			owners = append(owners, i)
+		}
+	}
+	if len(sigs) == 0 {
+		return
+	}
+	rets := crypto.BatchVerify(sigs, infos)
+	for k, i := range owners {
+		if !rets[k] {
+			hashes[i] = nil
+		}
+	}
+	for _, hash := range hashes {
+		if hash != nil {
+			sigCache.Add(hash)
+		}
+	}
+}
+
 // VerifySigner verify signer's signature
 func (t *Tx) VerifySigner(sig *crypto.Signature) bool {
 	return sig.Verify(t.baseHash())
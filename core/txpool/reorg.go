@@ -0,0 +1,81 @@
+package txpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/metrics"
+)
+
+var (
+	metricsReorgDepth = metrics.NewHistogram("iost_reorg_depth", nil, []float64{1, 2, 3, 4, 6, 8, 12, 16, 24, 32, 48, 64})
+	metricsReorgTx    = metrics.NewCounter("iost_reorg_tx", []string{"type"})
+
+	maxReorgEvents = 100
+)
+
+// ReorgEvent is a switch of the head to another fork.
+type ReorgEvent struct {
+	Time        int64  `json:"time"`
+	OldHead     int64  `json:"oldHead"`
+	OldHeadHash string `json:"oldHeadHash"`
+	NewHead     int64  `json:"newHead"`
+	NewHeadHash string `json:"newHeadHash"`
+	Fork        int64  `json:"fork"`
+	ForkHash    string `json:"forkHash"`
+	// Depth is the number of blocks abandoned from the old chain.
+	Depth int64 `json:"depth"`
+	// Readded is the number of txs of the abandoned blocks put back to the pending txs.
+	Readded int `json:"readded"`
+	// Dropped is the number of txs of the abandoned blocks not put back,
+	// because they are in the new chain too or too old.
+	Dropped int `json:"dropped"`
+}
+
+func newReorgEvent(oldHead, newHead, fork *blockcache.BlockCacheNode) *ReorgEvent {
+	return &ReorgEvent{
+		Time:        time.Now().UnixNano(),
+		OldHead:     oldHead.Head.Number,
+		OldHeadHash: common.Base58Encode(oldHead.HeadHash()),
+		NewHead:     newHead.Head.Number,
+		NewHeadHash: common.Base58Encode(newHead.HeadHash()),
+		Fork:        fork.Head.Number,
+		ForkHash:    common.Base58Encode(fork.HeadHash()),
+		Depth:       oldHead.Head.Number - fork.Head.Number,
+	}
+}
+
+// reorgLog keeps the latest reorg events.
+type reorgLog struct {
+	events []*ReorgEvent
+	mu     sync.RWMutex
+}
+
+func (l *reorgLog) add(e *ReorgEvent) {
+	ilog.Infof("reorg from %v(%v) to %v(%v) at %v, depth=%v, readded=%v, dropped=%v",
+		e.OldHead, e.OldHeadHash, e.NewHead, e.NewHeadHash, e.Fork, e.Depth, e.Readded, e.Dropped)
+	metricsReorgDepth.Observe(float64(e.Depth), nil)
+	metricsReorgTx.Add(float64(e.Readded), map[string]string{"type": "readded"})
+	metricsReorgTx.Add(float64(e.Dropped), map[string]string{"type": "dropped"})
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+	if len(l.events) > maxReorgEvents {
+		l.events = l.events[len(l.events)-maxReorgEvents:]
+	}
+}
+
+// list returns the events, the latest first.
+func (l *reorgLog) list() []*ReorgEvent {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	events := make([]*ReorgEvent, len(l.events))
+	for i, e := range l.events {
+		events[len(l.events)-1-i] = e
+	}
+	return events
+}
@@ -0,0 +1,38 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorgLog(t *testing.T) {
+	origin := maxReorgEvents
+	maxReorgEvents = 2
+	defer func() { maxReorgEvents = origin }()
+
+	node := func(parent *blockcache.BlockCacheNode, number int64) *blockcache.BlockCacheNode {
+		blk := &block.Block{Head: &block.BlockHead{Number: number, Witness: "w"}}
+		assert.Nil(t, blk.CalculateHeadHash())
+		return blockcache.NewBCN(parent, blk)
+	}
+	fork := node(nil, 10)
+	oldHead := node(node(fork, 11), 12)
+	newHead := node(node(node(fork, 11), 12), 13)
+
+	l := new(reorgLog)
+	for i := 0; i < 3; i++ {
+		e := newReorgEvent(oldHead, newHead, fork)
+		e.Readded = i
+		l.add(e)
+	}
+	events := l.list()
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, 2, events[0].Readded)
+	assert.Equal(t, 1, events[1].Readded)
+	assert.Equal(t, int64(2), events[0].Depth)
+	assert.Equal(t, int64(13), events[0].NewHead)
+	assert.Equal(t, int64(10), events[0].Fork)
+}
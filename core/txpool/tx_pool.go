@@ -30,6 +30,7 @@ type TxPImpl struct {
 	deferServer      *DeferServer
 	quitGenerateMode chan struct{}
 	quitCh           chan struct{}
+	reorgs           *reorgLog
 }
 
 // NewTxPoolImpl returns a default TxPImpl instance.
@@ -44,6 +45,7 @@ func NewTxPoolImpl(global global.BaseVariable, blockCache blockcache.BlockCache,
 		chP2PTx:          p2pService.Register("txpool message", p2p.PublishTx),
 		quitGenerateMode: make(chan struct{}),
 		quitCh:           make(chan struct{}),
+		reorgs:           new(reorgLog),
 	}
 	p.forkChain.SetNewHead(blockCache.Head())
 	deferServer, err := NewDeferServer(p)
@@ -161,28 +163,56 @@ func (pool *TxPImpl) verifyWorkers() {
 		select {
 		case <-pool.quitGenerateMode:
 		}
-		var t tx.Tx
-		err := t.Decode(v.Data())
-		if err != nil {
-			ilog.Errorf("decode tx error. err=%v", err)
-			continue
+		msgs := pool.drainP2PTx(v)
+		txs := make([]*tx.Tx, 0, len(msgs))
+		datas := make([][]byte, 0, len(msgs))
+		for _, msg := range msgs {
+			var t tx.Tx
+			err := t.Decode(msg.Data())
+			if err != nil {
+				ilog.Errorf("decode tx error. err=%v", err)
+				continue
+			}
+			txs = append(txs, &t)
+			datas = append(datas, msg.Data())
 		}
-		pool.mu.Lock()
-		ret := pool.verifyDuplicate(&t)
-		if ret != nil {
+		// Verify the signatures out of the lock, verifyTx hits the signature cache then.
+		tx.BatchVerifySigns(txs)
+		for i, t := range txs {
+			pool.mu.Lock()
+			ret := pool.verifyDuplicate(t)
+			if ret != nil {
+				pool.mu.Unlock()
+				continue
+			}
+			ret = pool.verifyTx(t)
+			if ret != nil {
+				pool.mu.Unlock()
+				continue
+			}
+			pool.pendingTx.Add(t)
 			pool.mu.Unlock()
-			continue
+			metricsReceivedTxCount.Add(1, map[string]string{"from": "p2p"})
+			pool.p2pService.Broadcast(datas[i], p2p.PublishTx, p2p.NormalMessage)
 		}
-		ret = pool.verifyTx(&t)
-		if ret != nil {
-			pool.mu.Unlock()
-			continue
+	}
+}
+
+// drainP2PTx returns the message together with the ones already waiting in chP2PTx, at most verifyBatchSize.
+func (pool *TxPImpl) drainP2PTx(first p2p.IncomingMessage) []p2p.IncomingMessage {
+	msgs := []p2p.IncomingMessage{first}
+	for len(msgs) < verifyBatchSize {
+		select {
+		case v, ok := <-pool.chP2PTx:
+			if !ok {
+				return msgs
+			}
+			msgs = append(msgs, v)
+		default:
+			return msgs
 		}
-		pool.pendingTx.Add(&t)
-		pool.mu.Unlock()
-		metricsReceivedTxCount.Add(1, map[string]string{"from": "p2p"})
-		pool.p2pService.Broadcast(v.Data(), p2p.PublishTx, p2p.NormalMessage)
 	}
+	return msgs
 }
 
 func (pool *TxPImpl) processDelaytx(blk *block.Block) {
@@ -451,14 +481,27 @@ func (pool *TxPImpl) doChainChangeByForkBCN() {
 	newHead := pool.forkChain.GetNewHead()
 	oldHead := pool.forkChain.GetOldHead()
 	forkBCN := pool.forkChain.GetForkBCN()
+	var event *ReorgEvent
+	if oldHead != nil && newHead != nil && forkBCN != nil {
+		event = newReorgEvent(oldHead, newHead, forkBCN)
+	}
+	readded := make(map[string]bool)
 	//add txs
 	filterLimit := time.Now().UnixNano() - filterTime
 	for {
-		if oldHead == nil || oldHead == forkBCN || oldHead.Block.Head.Time < filterLimit {
+		if oldHead == nil || oldHead == forkBCN {
 			break
 		}
+		if oldHead.Block.Head.Time < filterLimit {
+			if event != nil {
+				event.Dropped += len(oldHead.Block.Txs)
+			}
+			oldHead = oldHead.GetParent()
+			continue
+		}
 		for _, t := range oldHead.Block.Txs {
 			pool.pendingTx.Add(t)
+			readded[string(t.Hash())] = true
 		}
 		oldHead = oldHead.GetParent()
 	}
@@ -470,9 +513,24 @@ func (pool *TxPImpl) doChainChangeByForkBCN() {
 		}
 		for _, t := range newHead.Block.Txs {
 			pool.DelTx(t.Hash())
+			if readded[string(t.Hash())] {
+				delete(readded, string(t.Hash()))
+				if event != nil {
+					event.Dropped++
+				}
+			}
 		}
 		newHead = newHead.GetParent()
 	}
+	if event != nil {
+		event.Readded = len(readded)
+		pool.reorgs.add(event)
+	}
+}
+
+// ReorgEvents returns the latest reorg events, the latest first.
+func (pool *TxPImpl) ReorgEvents() []*ReorgEvent {
+	return pool.reorgs.list()
 }
 
 func (pool *TxPImpl) doChainChangeByTimeout() {
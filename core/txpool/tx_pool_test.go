@@ -16,6 +16,7 @@ import (
 	core_mock "github.com/iost-official/go-iost/core/mocks"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
 	db_mock "github.com/iost-official/go-iost/db/mocks"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
@@ -92,6 +93,7 @@ func TestNewTxPImpl(t *testing.T) {
 
 		statedb := db_mock.NewMockMVCCDB(ctl)
 		statedb.EXPECT().Flush(Any()).AnyTimes().Return(nil)
+		statedb.EXPECT().Digest().AnyTimes().Return(&db.Digest{}, nil)
 		statedb.EXPECT().Fork().AnyTimes().Return(statedb)
 		statedb.EXPECT().Checkout(Any()).AnyTimes().Return(true)
 		statedb.EXPECT().Close().AnyTimes()
@@ -114,6 +116,7 @@ func TestNewTxPImpl(t *testing.T) {
 		base := core_mock.NewMockChain(ctl)
 		base.EXPECT().Top().AnyTimes().Return(b[0], nil)
 		base.EXPECT().Push(Any()).AnyTimes().Return(nil)
+		base.EXPECT().PutStateDigest(Any(), Any()).AnyTimes().Return(nil)
 		base.EXPECT().Length().AnyTimes().Return(int64(1))
 		base.EXPECT().Close().AnyTimes()
 		base.EXPECT().AllDelaytx().AnyTimes().Return(nil, nil)
@@ -275,6 +278,7 @@ func TestNewTxPImplB(t *testing.T) {
 
 		statedb := db_mock.NewMockMVCCDB(ctl)
 		statedb.EXPECT().Flush(Any()).AnyTimes().Return(nil)
+		statedb.EXPECT().Digest().AnyTimes().Return(&db.Digest{}, nil)
 		statedb.EXPECT().Fork().AnyTimes().Return(statedb)
 		statedb.EXPECT().Checkout(Any()).AnyTimes().Return(true)
 		statedb.EXPECT().Close().AnyTimes()
@@ -297,6 +301,7 @@ func TestNewTxPImplB(t *testing.T) {
 		base := core_mock.NewMockChain(ctl)
 		base.EXPECT().Top().AnyTimes().Return(b[0], nil)
 		base.EXPECT().Push(Any()).AnyTimes().Return(nil)
+		base.EXPECT().PutStateDigest(Any(), Any()).AnyTimes().Return(nil)
 		base.EXPECT().Length().AnyTimes().Return(int64(1))
 		base.EXPECT().Close().AnyTimes()
 		base.EXPECT().AllDelaytx().AnyTimes().Return(nil, nil)
@@ -19,6 +19,8 @@ var (
 	filterTime    = int64(90 * time.Second)
 	maxCacheTxs   = 10000
 
+	verifyBatchSize = 64
+
 	metricsReceivedTxCount = metrics.NewCounter("iost_tx_received_count", []string{"from"})
 	metricsTxPoolSize      = metrics.NewGauge("iost_txpool_size", nil)
 
@@ -8,6 +8,7 @@ import (
 type AlgorithmBackend interface {
 	Sign(message []byte, seckey []byte) []byte
 	Verify(message []byte, pubkey []byte, sig []byte) bool
+	BatchVerify(messages [][]byte, pubkeys [][]byte, sigs [][]byte) []bool
 	GetPubkey(seckey []byte) []byte
 	GenSeckey() []byte
 }
@@ -67,6 +68,11 @@ func (a Algorithm) Verify(message []byte, pubkey []byte, sig []byte) bool {
 	return a.getBackend().Verify(message, pubkey, sig)
 }
 
+// BatchVerify will verify the messages with pubkeys and sigs in batch, it returns the result of each signature
+func (a Algorithm) BatchVerify(messages [][]byte, pubkeys [][]byte, sigs [][]byte) []bool {
+	return a.getBackend().BatchVerify(messages, pubkeys, sigs)
+}
+
 // GetPubkey will get the public key of the secret key
 func (a Algorithm) GetPubkey(seckey []byte) []byte {
 	return a.getBackend().GetPubkey(seckey)
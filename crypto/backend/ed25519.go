@@ -17,6 +17,10 @@ func (b *Ed25519) Sign(message []byte, seckey []byte) []byte {
 
 // Verify will verify the message with pubkey and sig by ed25519
 func (b *Ed25519) Verify(message []byte, pubkey []byte, sig []byte) bool {
+	// ed25519.Verify panics on a public key of bad length.
+	if len(pubkey) != ed25519.PublicKeySize {
+		return false
+	}
 	return ed25519.Verify(pubkey, message, sig)
 }
 
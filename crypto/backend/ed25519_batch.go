@@ -0,0 +1,248 @@
+package backend
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"filippo.io/edwards25519"
+)
+
+// minBatchPerWorker is the least number of signatures of the batch handed to one worker.
+var minBatchPerWorker = 64
+
+// minBisect is the size under which the signatures of a failed batch are verified one by one.
+var minBisect = 4
+
+// torsionChecks is the number of the random subset sums checked for the small order components, the points with
+// any pass all the checks with a probability of at most 2^-torsionChecks.
+const torsionChecks = 64
+
+// inv8 is the inverse of the cofactor 8 modulo the group order.
+var inv8 = func() *edwards25519.Scalar {
+	var b [32]byte
+	b[0] = 8
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b[:])
+	if err != nil {
+		panic(err)
+	}
+	return s.Invert(s)
+}()
+
+// BatchVerify will verify the messages with pubkeys and sigs by ed25519 in batches spread over the CPUs, and
+// returns the result of each signature. A failed batch is split into halves until the invalid signatures are found.
+func (b *Ed25519) BatchVerify(messages [][]byte, pubkeys [][]byte, sigs [][]byte) []bool {
+	ret := make([]bool, len(messages))
+	if len(pubkeys) != len(messages) || len(sigs) != len(messages) {
+		return ret
+	}
+	forChunks(len(messages), minBatchPerWorker, func(start, end int) {
+		bt, err := newEd25519Batch(messages[start:end], pubkeys[start:end], sigs[start:end])
+		if err != nil || !bt.torsionFree() {
+			// Only the forged keys or signatures have the small order components, verify them one by one.
+			for i := start; i < end; i++ {
+				ret[i] = b.Verify(messages[i], pubkeys[i], sigs[i])
+			}
+			return
+		}
+		bt.bisect(b, 0, end-start, false, ret[start:end])
+	})
+	return ret
+}
+
+// ed25519Batch is the decoded signatures checked by the randomized batch equation
+//
+//	[-Σ z_i s_i]B + Σ [z_i]R_i + Σ [z_i k_i]A_i = 0
+//
+// for the random 128-bit z_i, where k_i = H(R_i || A_i || M_i). It takes one multi-scalar multiplication, in which
+// the doublings are shared by all the signatures, instead of a double-scalar multiplication for each one.
+//
+// ed25519.Verify checks R_i = [s_i]B - [k_i]A_i by comparing the encoding of the right side with the one of R_i. So
+// the equation agrees with it only if R_i is encoded canonically, and R_i and A_i have no small order component,
+// which z_i can't tell apart from zero with a probability of 1/2.
+type ed25519Batch struct {
+	messages [][]byte
+	pubkeys  [][]byte
+	sigs     [][]byte
+
+	// valid tells whether the signature passes the checks before the equation, the others are invalid.
+	valid  []bool
+	z      []*edwards25519.Scalar
+	zs     []*edwards25519.Scalar
+	zk     []*edwards25519.Scalar
+	r      []*edwards25519.Point
+	key    []int
+	pubkey []*edwards25519.Point
+}
+
+func newEd25519Batch(messages, pubkeys, sigs [][]byte) (*ed25519Batch, error) {
+	n := len(messages)
+	bt := &ed25519Batch{
+		messages: messages,
+		pubkeys:  pubkeys,
+		sigs:     sigs,
+		valid:    make([]bool, n),
+		z:        make([]*edwards25519.Scalar, n),
+		zs:       make([]*edwards25519.Scalar, n),
+		zk:       make([]*edwards25519.Scalar, n),
+		r:        make([]*edwards25519.Point, n),
+		key:      make([]int, n),
+	}
+	random := make([]byte, 16*n)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	// The pubkey signing several signatures of the batch is decoded and added once.
+	keys := make(map[string]int)
+	var digest [64]byte
+	var zb [32]byte
+	for i := 0; i < n; i++ {
+		pubkey, sig := pubkeys[i], sigs[i]
+		if len(pubkey) != 32 || len(sig) != 64 || !isCanonicalY(sig[:32]) {
+			continue
+		}
+		s, err := edwards25519.NewScalar().SetCanonicalBytes(sig[32:])
+		if err != nil {
+			continue
+		}
+		R, err := new(edwards25519.Point).SetBytes(sig[:32])
+		if err != nil {
+			continue
+		}
+		j, ok := keys[string(pubkey)]
+		if !ok {
+			A, err := new(edwards25519.Point).SetBytes(pubkey)
+			if err != nil {
+				continue
+			}
+			j = len(bt.pubkey)
+			keys[string(pubkey)] = j
+			bt.pubkey = append(bt.pubkey, A)
+		}
+		h := sha512.New()
+		h.Write(sig[:32])
+		h.Write(pubkey)
+		h.Write(messages[i])
+		k, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(digest[:0]))
+		if err != nil {
+			continue
+		}
+		copy(zb[:16], random[16*i:])
+		z, err := edwards25519.NewScalar().SetCanonicalBytes(zb[:])
+		if err != nil {
+			continue
+		}
+
+		bt.valid[i] = true
+		bt.z[i] = z
+		bt.zs[i] = edwards25519.NewScalar().Multiply(z, s)
+		bt.zk[i] = edwards25519.NewScalar().Multiply(z, k)
+		bt.r[i] = R
+		bt.key[i] = j
+	}
+	return bt, nil
+}
+
+// check returns whether the batch equation holds for the valid signatures in [start, end).
+func (bt *ed25519Batch) check(start, end int) bool {
+	scalars := make([]*edwards25519.Scalar, 0, 2*(end-start)+1)
+	points := make([]*edwards25519.Point, 0, 2*(end-start)+1)
+	keys := make(map[int]int)
+	sum := edwards25519.NewScalar()
+	for i := start; i < end; i++ {
+		if !bt.valid[i] {
+			continue
+		}
+		sum.Add(sum, bt.zs[i])
+		scalars = append(scalars, bt.z[i])
+		points = append(points, bt.r[i])
+		if j, ok := keys[bt.key[i]]; ok {
+			scalars[j] = edwards25519.NewScalar().Add(scalars[j], bt.zk[i])
+			continue
+		}
+		keys[bt.key[i]] = len(points)
+		scalars = append(scalars, bt.zk[i])
+		points = append(points, bt.pubkey[bt.key[i]])
+	}
+	scalars = append(scalars, sum.Negate(sum))
+	points = append(points, edwards25519.NewGeneratorPoint())
+	check := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	return check.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// bisect sets the results of the signatures in [start, end), and returns whether all the valid ones pass
+// ed25519.Verify. The range known to have an invalid signature isn't checked as a whole again.
+func (bt *ed25519Batch) bisect(b *Ed25519, start, end int, invalid bool, ret []bool) bool {
+	if end-start <= minBisect {
+		passed := true
+		for i := start; i < end; i++ {
+			if bt.valid[i] {
+				ret[i] = b.Verify(bt.messages[i], bt.pubkeys[i], bt.sigs[i])
+				passed = passed && ret[i]
+			}
+		}
+		return passed
+	}
+	if !invalid && bt.check(start, end) {
+		copy(ret[start:end], bt.valid[start:end])
+		return true
+	}
+	mid := (start + end) / 2
+	left := bt.bisect(b, start, mid, false, ret)
+	// The right half has the invalid signature if the left one has none.
+	right := bt.bisect(b, mid, end, left, ret)
+	return left && right
+}
+
+// torsionFree returns whether all the points of the valid signatures are in the prime order subgroup. Each of the
+// random subset sums of the points has a small order component with a probability of at least 1/2 if any of the
+// points has one.
+func (bt *ed25519Batch) torsionFree() bool {
+	points := make([]*edwards25519.Point, 0, len(bt.r)+len(bt.pubkey))
+	for i, R := range bt.r {
+		if bt.valid[i] {
+			points = append(points, R)
+		}
+	}
+	points = append(points, bt.pubkey...)
+	random := make([]byte, 8*len(points))
+	if _, err := rand.Read(random); err != nil {
+		return false
+	}
+	sums := make([]*edwards25519.Point, torsionChecks)
+	for j := range sums {
+		sums[j] = edwards25519.NewIdentityPoint()
+	}
+	for i, p := range points {
+		w := binary.LittleEndian.Uint64(random[8*i:])
+		for j := range sums {
+			if w>>uint(j)&1 == 1 {
+				sums[j].Add(sums[j], p)
+			}
+		}
+	}
+	zero := edwards25519.NewScalar()
+	q := new(edwards25519.Point)
+	for _, s := range sums {
+		// [8][1/8]P is P without its small order component.
+		q.VarTimeDoubleScalarBaseMult(inv8, s, zero)
+		q.MultByCofactor(q)
+		if q.Equal(s) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isCanonicalY returns whether the y coordinate of the point encoding is below the field prime 2^255 - 19.
+func isCanonicalY(b []byte) bool {
+	if b[31]&0x7f != 0x7f {
+		return true
+	}
+	for i := 30; i > 0; i-- {
+		if b[i] != 0xff {
+			return true
+		}
+	}
+	return b[0] < 0xed
+}
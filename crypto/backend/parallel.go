@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"runtime"
+	"sync"
+)
+
+// minSigsPerWorker is the least number of signatures handed to one worker.
+var minSigsPerWorker = 16
+
+// forChunks splits [0, n) into a chunk per CPU, each of at least min items, and calls fn on the chunks concurrently.
+func forChunks(n, min int, fn func(start, end int)) {
+	workerCnt := runtime.NumCPU()
+	if max := (n + min - 1) / min; workerCnt > max {
+		workerCnt = max
+	}
+	if workerCnt <= 1 {
+		fn(0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	step := (n + workerCnt - 1) / workerCnt
+	for start := 0; start < n; start += step {
+		end := start + step
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// verifyParallel verifies the signatures one by one with verify, spread over the CPUs, and returns the result of
+// each one.
+func verifyParallel(verify func(message, pubkey, sig []byte) bool, messages, pubkeys, sigs [][]byte) []bool {
+	ret := make([]bool, len(messages))
+	if len(pubkeys) != len(messages) || len(sigs) != len(messages) {
+		return ret
+	}
+	forChunks(len(messages), minSigsPerWorker, func(start, end int) {
+		for i := start; i < end; i++ {
+			ret[i] = verify(messages[i], pubkeys[i], sigs[i])
+		}
+	})
+	return ret
+}
@@ -41,3 +41,9 @@ func (b *Secp256k1) GenSeckey() []byte {
 	}
 	return seckey
 }
+
+// BatchVerify will verify the messages with pubkeys and sigs by secp256k1, and returns the result of each
+// signature. secp256k1 has no batch verification, the signatures are verified one by one spread over the CPUs.
+func (b *Secp256k1) BatchVerify(messages [][]byte, pubkeys [][]byte, sigs [][]byte) []bool {
+	return verifyParallel(b.Verify, messages, pubkeys, sigs)
+}
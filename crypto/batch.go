@@ -0,0 +1,42 @@
+package crypto
+
+type batchGroup struct {
+	index    []int
+	messages [][]byte
+	pubkeys  [][]byte
+	sigs     [][]byte
+}
+
+// BatchVerify verifies every signature against the info at the same index and
+// returns the result of each one.
+//
+// Signatures are grouped by algorithm and each group is checked by the backend
+// in batch. The backend finds out the invalid signatures of a failed batch.
+func BatchVerify(sigs []*Signature, infos [][]byte) []bool {
+	ret := make([]bool, len(sigs))
+	if len(infos) != len(sigs) {
+		return ret
+	}
+	groups := make(map[Algorithm]*batchGroup)
+	for i, s := range sigs {
+		if s == nil {
+			continue
+		}
+		g, ok := groups[s.Algorithm]
+		if !ok {
+			g = &batchGroup{}
+			groups[s.Algorithm] = g
+		}
+		g.index = append(g.index, i)
+		g.messages = append(g.messages, infos[i])
+		g.pubkeys = append(g.pubkeys, s.Pubkey)
+		g.sigs = append(g.sigs, s.Sig)
+	}
+	for algo, g := range groups {
+		rets := algo.BatchVerify(g.messages, g.pubkeys, g.sigs)
+		for k, i := range g.index {
+			ret[i] = rets[k]
+		}
+	}
+	return ret
+}
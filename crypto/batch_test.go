@@ -0,0 +1,185 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func genSigs(algo Algorithm, n int) ([]*Signature, [][]byte) {
+	sigs := make([]*Signature, 0, n)
+	msgs := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		msg := make([]byte, 32)
+		rand.Read(msg)
+		msgs = append(msgs, msg)
+		sigs = append(sigs, NewSignature(algo, msg, algo.GenSeckey()))
+	}
+	return sigs, msgs
+}
+
+func TestBatchVerify(t *testing.T) {
+	sigs, msgs := genSigs(Ed25519, 200)
+	secpSigs, secpMsgs := genSigs(Secp256k1, 40)
+	sigs = append(sigs, secpSigs...)
+	msgs = append(msgs, secpMsgs...)
+
+	for i, ok := range BatchVerify(sigs, msgs) {
+		if !ok {
+			t.Fatalf("signature %v should be valid", i)
+		}
+	}
+
+	bad := map[int]bool{3: true, 4: true, 150: true, 220: true}
+	for i := range bad {
+		msgs[i] = []byte("hello world, this is a wrong msg")
+	}
+	sigs[230] = nil
+	bad[230] = true
+	for i, ok := range BatchVerify(sigs, msgs) {
+		if ok == bad[i] {
+			t.Fatalf("signature %v verify result should be %v", i, !bad[i])
+		}
+	}
+
+	if len(BatchVerify(sigs, msgs[1:])) != len(sigs) {
+		t.Fatal("result length should equal to the number of signatures")
+	}
+}
+
+// torsionPoint returns a point of order 8.
+func torsionPoint(t *testing.T) *edwards25519.Point {
+	b, _ := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		t.Fatal("point should be of small order")
+	}
+	return p
+}
+
+// ed25519Scalar returns the secret scalar of the ed25519 seckey.
+func ed25519Scalar(t *testing.T, seckey []byte) *edwards25519.Scalar {
+	h := sha512.Sum512(seckey[:32])
+	a, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// signEd25519 signs msg by the secret scalar a as the pubkey A, with the nonce point r*B + T.
+func signEd25519(t *testing.T, a *edwards25519.Scalar, A []byte, T *edwards25519.Point, msg []byte) ([]byte, *edwards25519.Scalar) {
+	seed := make([]byte, 64)
+	rand.Read(seed)
+	r, _ := edwards25519.NewScalar().SetUniformBytes(seed)
+	R := new(edwards25519.Point).ScalarBaseMult(r)
+	R.Add(R, T)
+	h := sha512.New()
+	h.Write(R.Bytes())
+	h.Write(A)
+	h.Write(msg)
+	k, _ := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	s := edwards25519.NewScalar().MultiplyAdd(k, a, r)
+	return append(R.Bytes(), s.Bytes()...), k
+}
+
+func TestBatchVerifyTorsion(t *testing.T) {
+	T := torsionPoint(t)
+	sigs, msgs := genSigs(Ed25519, 100)
+
+	// R with a small order component passes the cofactored batch equation, but not the single verification.
+	seckey := Ed25519.GenSeckey()
+	pubkey := Ed25519.GetPubkey(seckey)
+	msg := []byte("signed with a small order nonce point")
+	sig, _ := signEd25519(t, ed25519Scalar(t, seckey), pubkey, T, msg)
+	if Ed25519.Verify(msg, pubkey, sig) {
+		t.Fatal("signature should be invalid")
+	}
+	sigs[50] = &Signature{Algorithm: Ed25519, Sig: sig, Pubkey: pubkey}
+	msgs[50] = msg
+	for i, ok := range BatchVerify(sigs, msgs) {
+		if ok != (i != 50) {
+			t.Fatalf("signature %v verify result should be %v", i, i != 50)
+		}
+	}
+
+	// A with a small order component is refused by the batch, the valid signature is found by the single
+	// verification then.
+	a := ed25519Scalar(t, seckey)
+	A := new(edwards25519.Point).ScalarBaseMult(a)
+	A.Add(A, T)
+	for i := 0; ; i++ {
+		msg = []byte{byte(i), byte(i >> 8)}
+		sig, k := signEd25519(t, a, A.Bytes(), edwards25519.NewIdentityPoint(), msg)
+		// k*T is zero only if k is a multiple of 8.
+		if k.Bytes()[0]&7 != 0 {
+			continue
+		}
+		if !Ed25519.Verify(msg, A.Bytes(), sig) {
+			t.Fatal("signature should be valid")
+		}
+		sigs[50] = &Signature{Algorithm: Ed25519, Sig: sig, Pubkey: A.Bytes()}
+		msgs[50] = msg
+		break
+	}
+	for i, ok := range BatchVerify(sigs, msgs) {
+		if !ok {
+			t.Fatalf("signature %v should be valid", i)
+		}
+	}
+}
+
+// benchSigCnt is the number of the signatures verified in each op of the batch benchmarks, the tps is
+// benchSigCnt / (ns/op) * 1e9.
+const benchSigCnt = 256
+
+// benchBatch runs fn on the same benchSigCnt signatures on one CPU, so that ns/op reflects the CPU cost.
+func benchBatch(b *testing.B, algo Algorithm, invalid bool, fn func(sigs []*Signature, msgs [][]byte)) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	sigs, msgs := genSigs(algo, benchSigCnt)
+	if invalid {
+		msgs[benchSigCnt/3] = []byte("hello world, this is a wrong msg")
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn(sigs, msgs)
+	}
+	b.ReportMetric(float64(benchSigCnt)*float64(b.N)/b.Elapsed().Seconds(), "sigs/s")
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	for _, algo := range algos {
+		for _, invalid := range []bool{false, true} {
+			name := reflect.TypeOf(algo.getBackend()).String()
+			if invalid {
+				name += "/invalid"
+			}
+			b.Run(name, func(b *testing.B) {
+				benchBatch(b, algo, invalid, func(sigs []*Signature, msgs [][]byte) {
+					BatchVerify(sigs, msgs)
+				})
+			})
+		}
+	}
+}
+
+// BenchmarkVerifySequential is the baseline of BenchmarkBatchVerify, it verifies the same signatures in a loop.
+func BenchmarkVerifySequential(b *testing.B) {
+	for _, algo := range algos {
+		b.Run(reflect.TypeOf(algo.getBackend()).String(), func(b *testing.B) {
+			benchBatch(b, algo, false, func(sigs []*Signature, msgs [][]byte) {
+				for i, sig := range sigs {
+					sig.Verify(msgs[i])
+				}
+			})
+		})
+	}
+}
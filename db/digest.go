@@ -0,0 +1,86 @@
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const digestSize = 32
+
+var digestKey = []byte(string(SEPARATOR) + "digest")
+
+// Digest is the digest of the state writes flushed to the storage, it's compared between the nodes
+// to find the divergence of the state.
+type Digest struct {
+	// Writes is the digest of the writes of the flushed tag.
+	Writes []byte
+	// State is the digest chained from the writes of all tags flushed, the sha3-256 of the previous State and Writes.
+	// It's comparable only between the nodes flushing from the same state.
+	State []byte
+}
+
+// Encode returns the bytes of the digest.
+func (d *Digest) Encode() []byte {
+	b := make([]byte, 0, 2*digestSize)
+	b = append(b, d.Writes...)
+	return append(b, d.State...)
+}
+
+// DecodeDigest decodes the digest, it returns an empty digest if b is empty.
+func DecodeDigest(b []byte) (*Digest, error) {
+	if len(b) == 0 {
+		return &Digest{}, nil
+	}
+	if len(b) != 2*digestSize {
+		return nil, fmt.Errorf("invalid digest length %v", len(b))
+	}
+	return &Digest{
+		Writes: b[:digestSize],
+		State:  b[digestSize:],
+	}, nil
+}
+
+// next returns the digest chained with the writes keyed by the table and the key.
+func (d *Digest) next(writes map[string]*Item) *Digest {
+	keys := make([]string, 0, len(writes))
+	for k := range writes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha3.New256()
+	var buf [binary.MaxVarintLen64]byte
+	for _, k := range keys {
+		item := writes[k]
+		h.Write(buf[:binary.PutUvarint(buf[:], uint64(len(k)))])
+		h.Write([]byte(k))
+		if item.deleted {
+			h.Write([]byte{1})
+			continue
+		}
+		h.Write([]byte{0})
+		h.Write(buf[:binary.PutUvarint(buf[:], uint64(len(item.value)))])
+		h.Write([]byte(item.value))
+	}
+	w := h.Sum(nil)
+
+	h.Reset()
+	h.Write(d.State)
+	h.Write(w)
+	return &Digest{
+		Writes: w,
+		State:  h.Sum(nil),
+	}
+}
+
+// Digest returns the digest of the state writes flushed to the storage, empty if nothing is flushed.
+func (m *CacheMVCCDB) Digest() (*Digest, error) {
+	b, err := m.storage.Get(digestKey)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeDigest(b)
+}
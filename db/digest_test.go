@@ -0,0 +1,85 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	defer os.RemoveAll(DBPATH)
+	var digests [][]*Digest
+	for _, cacheType := range []mvcc.CacheType{mvcc.TrieCache, mvcc.MapCache, mvcc.BTreeCache} {
+		os.RemoveAll(DBPATH)
+		m, err := NewCacheMVCCDB(DBPATH, cacheType, kv.LevelDBStorage)
+		require.Nil(t, err)
+		d, err := m.Digest()
+		require.Nil(t, err)
+		assert.Empty(t, d.State)
+
+		m.Put("table01", "a", "1")
+		m.Put("table01", "b", "2")
+		m.Commit("tag0")
+		m.Put("table01", "a", "11")
+		m.Del("table01", "b")
+		m.Put("table02", "c", "3")
+		m.Commit("tag1")
+		// A fork of another block isn't flushed.
+		f := m.Fork()
+		f.Put("table01", "x", "0")
+		f.Commit("tag1x")
+
+		require.Nil(t, m.Flush("tag0"))
+		d0, err := m.Digest()
+		require.Nil(t, err)
+		require.Nil(t, m.Flush("tag1"))
+		d1, err := m.Digest()
+		require.Nil(t, err)
+		m.Close()
+
+		// The digest is chained from the flushed one after reopened.
+		m, err = NewCacheMVCCDB(DBPATH, cacheType, kv.LevelDBStorage)
+		require.Nil(t, err)
+		m.Put("table01", "d", "4")
+		m.Commit("tag2")
+		require.Nil(t, m.Flush("tag2"))
+		d2, err := m.Digest()
+		require.Nil(t, err)
+		m.Close()
+
+		assert.NotEqual(t, d0.Writes, d1.Writes)
+		assert.NotEqual(t, d1.State, d2.State)
+		digests = append(digests, []*Digest{d0, d1, d2})
+	}
+	assert.Equal(t, digests[0], digests[1])
+	assert.Equal(t, digests[0], digests[2])
+
+	// The digest of the same writes flushed in one commit.
+	os.RemoveAll(DBPATH)
+	m, err := NewCacheMVCCDB(DBPATH, mvcc.MapCache, kv.LevelDBStorage)
+	require.Nil(t, err)
+	m.Put("table01", "a", "1")
+	m.Put("table01", "b", "2")
+	m.Commit("tag0")
+	require.Nil(t, m.Flush("tag0"))
+	m.Put("table02", "c", "3")
+	m.Del("table01", "b")
+	m.Put("table01", "a", "0")
+	m.Put("table01", "a", "11")
+	m.Commit("tag1")
+	require.Nil(t, m.Flush("tag1"))
+	d, err := m.Digest()
+	require.Nil(t, err)
+	assert.Equal(t, digests[0][1], d)
+	m.Close()
+
+	encoded, err := DecodeDigest(d.Encode())
+	require.Nil(t, err)
+	assert.Equal(t, d, encoded)
+	_, err = DecodeDigest([]byte("digest"))
+	assert.NotNil(t, err)
+}
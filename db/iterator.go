@@ -0,0 +1,248 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+)
+
+// IterOptions is the options of the mvccdb iterator.
+type IterOptions struct {
+	// Start is the first key to iterate, inclusive. Empty means the beginning of the table.
+	Start string
+	// End is the key to stop at, exclusive. Empty means the end of the table.
+	End string
+	// Prefix limits the keys to the ones prefixed with it.
+	Prefix string
+	// Reverse iterates the keys in descending order.
+	Reverse bool
+	// Limit is the max number of keys to iterate, 0 means unlimited.
+	Limit int
+}
+
+// Iterator is the iterator of the keys of a table in mvccdb, it must be released after use.
+type Iterator interface {
+	Next() bool
+	Key() string
+	Value() string
+	Error() error
+	Release()
+}
+
+// rangeCache is the cache iterating its key-value pairs in order, as the btree cache.
+type rangeCache interface {
+	AscendRange(start, end []byte, fn func(key []byte, value interface{}) bool)
+	DescendRange(start, end []byte, fn func(key []byte, value interface{}) bool)
+}
+
+// NewIterator returns the iterator of the keys of the table in the range of opts.
+// The writes not flushed yet are merged with the storage, the ones after the iterator is created aren't seen.
+func (m *CacheMVCCDB) NewIterator(table string, opts *IterOptions) (Iterator, error) {
+	if !m.isValidTable(table) {
+		return nil, ErrTableNotValid
+	}
+	if opts == nil {
+		opts = &IterOptions{}
+	}
+	base := []byte(table + string(SEPARATOR))
+	start := append(append([]byte{}, base...), opts.Start...)
+	if p := append(append([]byte{}, base...), opts.Prefix...); bytes.Compare(p, start) > 0 {
+		start = p
+	}
+	end := prefixEnd(append(append([]byte{}, base...), opts.Prefix...))
+	if opts.End != "" {
+		if e := append(append([]byte{}, base...), opts.End...); bytes.Compare(e, end) < 0 {
+			end = e
+		}
+	}
+
+	// The cache is taken before the storage, the pairs flushed in between are in both of them then.
+	m.rwmu.RLock()
+	items, err := cacheItems(m.stage, start, end, opts.Reverse)
+	m.rwmu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return &mvccIterator{
+		base:    len(base),
+		items:   items,
+		iter:    m.storage.NewIteratorByRange(start, end, opts.Reverse),
+		reverse: opts.Reverse,
+		limit:   opts.Limit,
+	}, nil
+}
+
+// cacheItems returns the items of the cache in [start, end) in the order of the keys.
+func cacheItems(cache mvcc.Cache, start, end []byte, reverse bool) ([]*Item, error) {
+	items := make([]*Item, 0)
+	var err error
+	if rc, ok := cache.(rangeCache); ok {
+		fn := func(key []byte, value interface{}) bool {
+			item, ok := value.(*Item)
+			if !ok {
+				err = fmt.Errorf("can't assert Item type")
+				return false
+			}
+			items = append(items, item)
+			return true
+		}
+		if reverse {
+			rc.DescendRange(start, end, fn)
+		} else {
+			rc.AscendRange(start, end, fn)
+		}
+		return items, err
+	}
+
+	// The values of the parent caches come before the ones overwriting them.
+	latest := make(map[string]*Item)
+	for _, v := range cache.All(commonPrefix(start, end)) {
+		item, ok := v.(*Item)
+		if !ok {
+			return nil, fmt.Errorf("can't assert Item type")
+		}
+		k := item.table + string(SEPARATOR) + item.key
+		if k < string(start) || k >= string(end) {
+			continue
+		}
+		latest[k] = item
+	}
+	keys := make([]string, 0, len(latest))
+	for k := range latest {
+		keys = append(keys, k)
+	}
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+	for _, k := range keys {
+		items = append(items, latest[k])
+	}
+	return items, nil
+}
+
+// mvccIterator merges the ordered items of the cache and the storage iterator, the cache wins on the same key.
+type mvccIterator struct {
+	base    int
+	items   []*Item
+	iter    *kv.Iterator
+	reverse bool
+	limit   int
+
+	storageKey   string
+	storageValue string
+	storageValid bool
+	storageDone  bool
+
+	count int
+	key   string
+	value string
+	err   error
+}
+
+// nextStorage moves the storage iterator forward if its current pair has been taken.
+func (i *mvccIterator) nextStorage() {
+	if i.storageValid || i.storageDone {
+		return
+	}
+	if !i.iter.Next() {
+		i.storageDone = true
+		i.err = i.iter.Error()
+		return
+	}
+	i.storageKey = string(i.iter.Key()[i.base:])
+	i.storageValue = string(i.iter.Value())
+	i.storageValid = true
+}
+
+// before returns whether a comes before b in the order of the iterator.
+func (i *mvccIterator) before(a, b string) bool {
+	if i.reverse {
+		return a > b
+	}
+	return a < b
+}
+
+// Next moves to the next key, it returns false when the range or the limit is reached.
+func (i *mvccIterator) Next() bool {
+	for i.err == nil && (i.limit <= 0 || i.count < i.limit) {
+		i.nextStorage()
+		if i.err != nil {
+			return false
+		}
+		if len(i.items) == 0 {
+			if !i.storageValid {
+				return false
+			}
+			i.key, i.value = i.storageKey, i.storageValue
+			i.storageValid = false
+			i.count++
+			return true
+		}
+		item := i.items[0]
+		if i.storageValid && i.before(i.storageKey, item.key) {
+			i.key, i.value = i.storageKey, i.storageValue
+			i.storageValid = false
+			i.count++
+			return true
+		}
+		i.items = i.items[1:]
+		if i.storageValid && i.storageKey == item.key {
+			i.storageValid = false
+		}
+		if item.deleted {
+			continue
+		}
+		i.key, i.value = item.key, item.value
+		i.count++
+		return true
+	}
+	return false
+}
+
+// Key returns the key of the current pair, without the table.
+func (i *mvccIterator) Key() string {
+	return i.key
+}
+
+// Value returns the value of the current pair.
+func (i *mvccIterator) Value() string {
+	return i.value
+}
+
+// Error returns the error of the iterator.
+func (i *mvccIterator) Error() error {
+	return i.err
+}
+
+// Release will release the iterator
+func (i *mvccIterator) Release() {
+	i.iter.Release()
+	i.items = nil
+}
+
+// prefixEnd returns the least key greater than all keys prefixed with prefix, nil if there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// commonPrefix returns the common prefix of a and b.
+func commonPrefix(a, b []byte) []byte {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func iterate(t *testing.T, m MVCCDB, table string, opts *IterOptions) []string {
+	iter, err := m.NewIterator(table, opts)
+	require.Nil(t, err)
+	defer iter.Release()
+	pairs := []string{}
+	for iter.Next() {
+		pairs = append(pairs, iter.Key()+"="+iter.Value())
+	}
+	require.Nil(t, iter.Error())
+	return pairs
+}
+
+func TestIterator(t *testing.T) {
+	for _, cacheType := range []mvcc.CacheType{mvcc.TrieCache, mvcc.MapCache, mvcc.BTreeCache} {
+		m, err := NewCacheMVCCDB(DBPATH, cacheType, kv.LevelDBStorage)
+		require.Nil(t, err)
+
+		// Flushed to the storage.
+		m.Put("table01", "a1", "1")
+		m.Put("table01", "a2", "2")
+		m.Put("table01", "a4", "4")
+		m.Put("table01", "b1", "5")
+		m.Put("table02", "a1", "x")
+		m.Commit("tag0")
+		require.Nil(t, m.Flush("tag0"))
+
+		// In the commit cache.
+		m.Put("table01", "a2", "22")
+		m.Del("table01", "a4")
+		m.Commit("tag1")
+
+		// In the stage.
+		m.Put("table01", "a3", "3")
+		m.Put("table01", "a5", "5")
+		m.Del("table01", "a1")
+		m.Put("table01", "a1", "11")
+
+		assert.Equal(t, []string{"a1=11", "a2=22", "a3=3", "a5=5", "b1=5"}, iterate(t, m, "table01", nil))
+		assert.Equal(t, []string{"b1=5", "a5=5", "a3=3", "a2=22", "a1=11"}, iterate(t, m, "table01", &IterOptions{Reverse: true}))
+		assert.Equal(t, []string{"a1=11", "a2=22", "a3=3", "a5=5"}, iterate(t, m, "table01", &IterOptions{Prefix: "a"}))
+		assert.Equal(t, []string{"a2=22", "a3=3"}, iterate(t, m, "table01", &IterOptions{Start: "a2", End: "a5"}))
+		assert.Equal(t, []string{"a5=5", "a3=3"}, iterate(t, m, "table01", &IterOptions{Start: "a3", Prefix: "a", Reverse: true}))
+		assert.Equal(t, []string{"a1=11", "a2=22"}, iterate(t, m, "table01", &IterOptions{Limit: 2}))
+		assert.Equal(t, []string{"b1=5", "a5=5"}, iterate(t, m, "table01", &IterOptions{Reverse: true, Limit: 2}))
+		assert.Equal(t, []string{}, iterate(t, m, "table01", &IterOptions{Start: "c"}))
+		assert.Equal(t, []string{"a1=x"}, iterate(t, m, "table02", nil))
+
+		keys, err := m.Keys("table01", "a")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a1", "a2", "a3", "a5"}, keys)
+
+		_, err = m.NewIterator("", nil)
+		assert.Equal(t, ErrTableNotValid, err)
+
+		// A fork doesn't see the writes of the stage.
+		m.Checkout("tag1")
+		assert.Equal(t, []string{"a1=1", "a2=22", "b1=5"}, iterate(t, m.Fork(), "table01", nil))
+
+		m.Close()
+		os.RemoveAll(DBPATH)
+	}
+}
@@ -0,0 +1,376 @@
+package bbolt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// FileName is the name of the database file in the storage directory.
+const FileName = "data.db"
+
+// iterChunk is the number of key-value pairs an iterator reads in one read transaction.
+const iterChunk = 1024
+
+var bucket = []byte("kv")
+
+type op struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// DB is the bbolt database, it keeps the key-value pairs in a single bucket of a B+tree file.
+type DB struct {
+	db    *bolt.DB
+	batch []op
+}
+
+// NewDB returns the bbolt database in the directory path, the directory is created if it doesn't exist.
+func NewDB(path string) (*DB, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	// The writers must remap the file once it outgrows the mmap, and the remapping waits for all
+	// the read transactions, such as the snapshots being exported. A large initial mmap, which
+	// only reserves the address space, keeps the writers from blocking on them.
+	mmapSize := 10 << 30
+	if strconv.IntSize == 32 {
+		mmapSize = 1 << 30
+	}
+	db, err := bolt.Open(filepath.Join(path, FileName), 0644, &bolt.Options{
+		Timeout:         time.Second,
+		InitialMmapSize: mmapSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &DB{
+		db:    db,
+		batch: nil,
+	}, nil
+}
+
+// Get return the value of the specify key
+func (d *DB) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := d.db.View(func(tx *bolt.Tx) error {
+		value = get(tx, key)
+		return nil
+	})
+	return value, err
+}
+
+func get(tx *bolt.Tx, key []byte) []byte {
+	return copyBytes(tx.Bucket(bucket).Get(key))
+}
+
+// Has returns whether the specified key exists
+func (d *DB) Has(key []byte) (bool, error) {
+	var has bool
+	err := d.db.View(func(tx *bolt.Tx) error {
+		// Get can't tell an empty value from a missing key.
+		k, _ := tx.Bucket(bucket).Cursor().Seek(key)
+		has = k != nil && bytes.Equal(k, key)
+		return nil
+	})
+	return has, err
+}
+
+// Put will insert the key-value pair
+func (d *DB) Put(key []byte, value []byte) error {
+	if d.batch == nil {
+		return d.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(bucket).Put(key, value)
+		})
+	}
+	d.batch = append(d.batch, op{key: copyBytes(key), value: copyBytes(value)})
+	return nil
+}
+
+// Delete will remove the specify key
+func (d *DB) Delete(key []byte) error {
+	if d.batch == nil {
+		return d.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(bucket).Delete(key)
+		})
+	}
+	d.batch = append(d.batch, op{key: copyBytes(key), delete: true})
+	return nil
+}
+
+// Keys returns the list of key prefixed with prefix
+func (d *DB) Keys(prefix []byte) ([][]byte, error) {
+	keys := make([][]byte, 0)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, copyBytes(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// BeginBatch will start the batch transaction
+func (d *DB) BeginBatch() error {
+	if d.batch != nil {
+		return fmt.Errorf("not support nested batch write")
+	}
+	d.batch = make([]op, 0)
+	return nil
+}
+
+// CommitBatch will commit the batch transaction, the batch is written in one transaction
+func (d *DB) CommitBatch() error {
+	if d.batch == nil {
+		return fmt.Errorf("no batch write to commit")
+	}
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for _, o := range d.batch {
+			var err error
+			if o.delete {
+				err = b.Delete(o.key)
+			} else {
+				err = b.Put(o.key, o.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.batch = nil
+	return nil
+}
+
+// Size returns the size of the database file
+func (d *DB) Size() (int64, error) {
+	var size int64
+	err := d.db.View(func(tx *bolt.Tx) error {
+		size = tx.Size()
+		return nil
+	})
+	return size, err
+}
+
+// Compact does nothing, bbolt reuses the pages freed by the overwritten and deleted key-value pairs
+// and has no online compaction.
+func (d *DB) Compact(start, limit []byte) error {
+	return nil
+}
+
+// Close will close the database
+func (d *DB) Close() error {
+	d.batch = nil
+	return d.db.Close()
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+// The iterator may see the writes after it's created, use a snapshot for a consistent view.
+func (d *DB) NewIteratorByPrefix(prefix []byte) interface{} {
+	r := util.BytesPrefix(prefix)
+	return &Iter{
+		view:  d.db.View,
+		start: r.Start,
+		limit: r.Limit,
+	}
+}
+
+// NewIteratorByRange returns a new iterator of the key range [start, limit), in descending order if reverse is true
+func (d *DB) NewIteratorByRange(start, limit []byte, reverse bool) interface{} {
+	return &Iter{
+		view:    d.db.View,
+		start:   copyBytes(start),
+		limit:   copyBytes(limit),
+		reverse: reverse,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database, it holds a read transaction
+// until it's released.
+func (d *DB) NewSnapshot() (interface{}, error) {
+	tx, err := d.db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		tx: tx,
+	}, nil
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
+
+// Snapshot is the snapshot of the bbolt database
+type Snapshot struct {
+	tx *bolt.Tx
+}
+
+// Get return the value of the specify key
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	return get(s.tx, key), nil
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+func (s *Snapshot) NewIteratorByPrefix(prefix []byte) interface{} {
+	r := util.BytesPrefix(prefix)
+	return &Iter{
+		view:  s.view,
+		start: r.Start,
+		limit: r.Limit,
+	}
+}
+
+func (s *Snapshot) view(fn func(*bolt.Tx) error) error {
+	return fn(s.tx)
+}
+
+// Release will release the snapshot
+func (s *Snapshot) Release() {
+	s.tx.Rollback()
+}
+
+// Iter is the iterator for the bbolt database.
+// It reads the keys in chunks, each in a short read transaction, so that it doesn't hold one while it's used.
+type Iter struct {
+	view    func(func(*bolt.Tx) error) error
+	start   []byte
+	limit   []byte
+	reverse bool
+
+	keys   [][]byte
+	values [][]byte
+	pos    int
+	last   []byte
+	done   bool
+	err    error
+}
+
+// Next do next item of iterator
+func (i *Iter) Next() bool {
+	if i.pos+1 < len(i.keys) {
+		i.pos++
+		return true
+	}
+	if i.done || i.err != nil {
+		i.keys, i.values = nil, nil
+		return false
+	}
+	i.keys, i.values, i.pos = i.keys[:0], i.values[:0], 0
+	i.err = i.view(i.fill)
+	if i.err != nil || len(i.keys) == 0 {
+		i.done = true
+		return false
+	}
+	i.last = i.keys[len(i.keys)-1]
+	return true
+}
+
+// fill reads the next chunk after the last key read.
+func (i *Iter) fill(tx *bolt.Tx) error {
+	c := tx.Bucket(bucket).Cursor()
+	var k, v []byte
+	if !i.reverse {
+		switch {
+		case i.last != nil:
+			k, v = c.Seek(i.last)
+			if k != nil && bytes.Equal(k, i.last) {
+				k, v = c.Next()
+			}
+		case len(i.start) > 0:
+			k, v = c.Seek(i.start)
+		default:
+			k, v = c.First()
+		}
+		for ; k != nil && (len(i.limit) == 0 || bytes.Compare(k, i.limit) < 0); k, v = c.Next() {
+			if len(i.keys) == iterChunk {
+				return nil
+			}
+			i.keys = append(i.keys, copyBytes(k))
+			i.values = append(i.values, copyBytes(v))
+		}
+		i.done = true
+		return nil
+	}
+
+	// Seek to the first key not below the bound, then step back below it.
+	bound := i.limit
+	if i.last != nil {
+		bound = i.last
+	}
+	if len(bound) == 0 {
+		k, v = c.Last()
+	} else {
+		k, v = c.Seek(bound)
+		if k == nil {
+			k, v = c.Last()
+		}
+		for k != nil && bytes.Compare(k, bound) >= 0 {
+			k, v = c.Prev()
+		}
+	}
+	for ; k != nil && bytes.Compare(k, i.start) >= 0; k, v = c.Prev() {
+		if len(i.keys) == iterChunk {
+			return nil
+		}
+		i.keys = append(i.keys, copyBytes(k))
+		i.values = append(i.values, copyBytes(v))
+	}
+	i.done = true
+	return nil
+}
+
+// Key returns the key of current item
+func (i *Iter) Key() []byte {
+	if i.pos >= len(i.keys) {
+		return nil
+	}
+	return i.keys[i.pos]
+}
+
+// Value returns the value of current item
+func (i *Iter) Value() []byte {
+	if i.pos >= len(i.values) {
+		return nil
+	}
+	return i.values[i.pos]
+}
+
+// Error returns the error of iterator
+func (i *Iter) Error() error {
+	return i.err
+}
+
+// Release will release the iterator
+func (i *Iter) Release() {
+	i.keys, i.values = nil, nil
+	i.done = true
+}
@@ -112,6 +112,11 @@ func (d *DB) Size() (int64, error) {
 	return total, nil
 }
 
+// Compact compacts the underlying storage of the key range [start, limit), nil start or limit means unbounded
+func (d *DB) Compact(start, limit []byte) error {
+	return d.db.CompactRange(util.Range{Start: start, Limit: limit})
+}
+
 // Close will close the database
 func (d *DB) Close() error {
 	return d.db.Close()
@@ -125,14 +130,70 @@ func (d *DB) NewIteratorByPrefix(prefix []byte) interface{} {
 	}
 }
 
+// NewIteratorByRange returns a new iterator of the key range [start, limit), in descending order if reverse is true
+func (d *DB) NewIteratorByRange(start, limit []byte, reverse bool) interface{} {
+	return &Iter{
+		iter:    d.db.NewIterator(&util.Range{Start: start, Limit: limit}, nil),
+		reverse: reverse,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of leveldb
+func (d *DB) NewSnapshot() (interface{}, error) {
+	snap, err := d.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		snap: snap,
+	}, nil
+}
+
+// Snapshot is the snapshot of leveldb
+type Snapshot struct {
+	snap *leveldb.Snapshot
+}
+
+// Get return the value of the specify key
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.snap.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return []byte{}, nil
+	}
+
+	return value, err
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+func (s *Snapshot) NewIteratorByPrefix(prefix []byte) interface{} {
+	iter := s.snap.NewIterator(util.BytesPrefix(prefix), nil)
+	return &Iter{
+		iter: iter,
+	}
+}
+
+// Release will release the snapshot
+func (s *Snapshot) Release() {
+	s.snap.Release()
+}
+
 // Iter is the iterator for leveldb
 type Iter struct {
-	iter iterator.Iterator
+	iter    iterator.Iterator
+	reverse bool
+	started bool
 }
 
 // Next do next item of iterator
 func (i *Iter) Next() bool {
-	return i.iter.Next()
+	if !i.reverse {
+		return i.iter.Next()
+	}
+	if !i.started {
+		i.started = true
+		return i.iter.Last()
+	}
+	return i.iter.Prev()
 }
 
 // Key returns the key of current item
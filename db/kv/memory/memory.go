@@ -0,0 +1,296 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const initCapacity = 4 * 1024 * 1024
+
+type op struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// DB is the in-memory database, it keeps the key-value pairs in a sorted skiplist and loses them once it's closed.
+// It's for the tests and the dev chains.
+type DB struct {
+	db    *memdb.DB
+	batch []op
+	rwmu  sync.RWMutex
+}
+
+// NewDB returns new in-memory database
+func NewDB() (*DB, error) {
+	return &DB{
+		db:    memdb.New(comparer.DefaultComparer, initCapacity),
+		batch: nil,
+	}, nil
+}
+
+// Get return the value of the specify key
+func (d *DB) Get(key []byte) ([]byte, error) {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	return get(d.db, key)
+}
+
+func get(db *memdb.DB, key []byte) ([]byte, error) {
+	value, err := db.Get(key)
+	if err == memdb.ErrNotFound {
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	v := make([]byte, len(value))
+	copy(v, value)
+	return v, nil
+}
+
+// Has returns whether the specified key exists
+func (d *DB) Has(key []byte) (bool, error) {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	return d.db.Contains(key), nil
+}
+
+// Put will insert the key-value pair
+func (d *DB) Put(key []byte, value []byte) error {
+	d.rwmu.Lock()
+	defer d.rwmu.Unlock()
+
+	if d.batch == nil {
+		return d.db.Put(key, value)
+	}
+	d.batch = append(d.batch, op{key: copyBytes(key), value: copyBytes(value)})
+	return nil
+}
+
+// Delete will remove the specify key
+func (d *DB) Delete(key []byte) error {
+	d.rwmu.Lock()
+	defer d.rwmu.Unlock()
+
+	if d.batch == nil {
+		return d.delete(key)
+	}
+	d.batch = append(d.batch, op{key: copyBytes(key), delete: true})
+	return nil
+}
+
+func (d *DB) delete(key []byte) error {
+	err := d.db.Delete(key)
+	if err == memdb.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// Keys returns the list of key prefixed with prefix
+func (d *DB) Keys(prefix []byte) ([][]byte, error) {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	iter := d.db.NewIterator(util.BytesPrefix(prefix))
+	keys := make([][]byte, 0)
+	for iter.Next() {
+		keys = append(keys, copyBytes(iter.Key()))
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// BeginBatch will start the batch transaction
+func (d *DB) BeginBatch() error {
+	d.rwmu.Lock()
+	defer d.rwmu.Unlock()
+
+	if d.batch != nil {
+		return fmt.Errorf("not support nested batch write")
+	}
+	d.batch = make([]op, 0)
+	return nil
+}
+
+// CommitBatch will commit the batch transaction
+func (d *DB) CommitBatch() error {
+	d.rwmu.Lock()
+	defer d.rwmu.Unlock()
+
+	if d.batch == nil {
+		return fmt.Errorf("no batch write to commit")
+	}
+	for _, o := range d.batch {
+		var err error
+		if o.delete {
+			err = d.delete(o.key)
+		} else {
+			err = d.db.Put(o.key, o.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	d.batch = nil
+	return nil
+}
+
+// Size returns the size of the key-value pairs in memory, including the overwritten ones not compacted
+func (d *DB) Size() (int64, error) {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	return int64(d.db.Size()), nil
+}
+
+// Compact frees the memory of the overwritten and deleted key-value pairs.
+// The whole database is rebuilt whatever the range is.
+func (d *DB) Compact(start, limit []byte) error {
+	d.rwmu.Lock()
+	defer d.rwmu.Unlock()
+
+	db, err := clone(d.db)
+	if err != nil {
+		return err
+	}
+	d.db = db
+	return nil
+}
+
+// Close will close the database
+func (d *DB) Close() error {
+	d.rwmu.Lock()
+	defer d.rwmu.Unlock()
+
+	d.db.Reset()
+	d.batch = nil
+	return nil
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+// The iterator may see the writes after it's created, use a snapshot for a consistent view.
+func (d *DB) NewIteratorByPrefix(prefix []byte) interface{} {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	return &Iter{
+		iter: d.db.NewIterator(util.BytesPrefix(prefix)),
+	}
+}
+
+// NewIteratorByRange returns a new iterator of the key range [start, limit), in descending order if reverse is true
+func (d *DB) NewIteratorByRange(start, limit []byte, reverse bool) interface{} {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	return &Iter{
+		iter:    d.db.NewIterator(&util.Range{Start: start, Limit: limit}),
+		reverse: reverse,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database, it's a copy of the database
+func (d *DB) NewSnapshot() (interface{}, error) {
+	d.rwmu.RLock()
+	defer d.rwmu.RUnlock()
+
+	db, err := clone(d.db)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		db: db,
+	}, nil
+}
+
+func clone(src *memdb.DB) (*memdb.DB, error) {
+	dst := memdb.New(comparer.DefaultComparer, src.Size())
+	iter := src.NewIterator(nil)
+	defer iter.Release()
+	for iter.Next() {
+		if err := dst.Put(iter.Key(), iter.Value()); err != nil {
+			return nil, err
+		}
+	}
+	return dst, iter.Error()
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
+
+// Snapshot is the snapshot of the in-memory database
+type Snapshot struct {
+	db *memdb.DB
+}
+
+// Get return the value of the specify key
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	return get(s.db, key)
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+func (s *Snapshot) NewIteratorByPrefix(prefix []byte) interface{} {
+	return &Iter{
+		iter: s.db.NewIterator(util.BytesPrefix(prefix)),
+	}
+}
+
+// Release will release the snapshot
+func (s *Snapshot) Release() {
+	s.db.Reset()
+}
+
+// Iter is the iterator for the in-memory database
+type Iter struct {
+	iter    iterator.Iterator
+	reverse bool
+	started bool
+}
+
+// Next do next item of iterator
+func (i *Iter) Next() bool {
+	if !i.reverse {
+		return i.iter.Next()
+	}
+	if !i.started {
+		i.started = true
+		return i.iter.Last()
+	}
+	return i.iter.Prev()
+}
+
+// Key returns the key of current item
+func (i *Iter) Key() []byte {
+	return i.iter.Key()
+}
+
+// Value returns the value of current item
+func (i *Iter) Value() []byte {
+	return i.iter.Value()
+}
+
+// Error returns the error of iterator
+func (i *Iter) Error() error {
+	return i.iter.Error()
+}
+
+// Release will release the iterator
+func (i *Iter) Release() {
+	i.iter.Release()
+}
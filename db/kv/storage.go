@@ -1,18 +1,50 @@
 package kv
 
 import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/db/kv/bbolt"
 	"github.com/iost-official/go-iost/db/kv/leveldb"
+	"github.com/iost-official/go-iost/db/kv/memory"
 )
 
-// StorageType is the type of storage, include leveldb and rocksdb
+// StorageType is the type of storage, include leveldb, bbolt and memory
 type StorageType uint8
 
 // Storage type constant
 const (
 	_ StorageType = iota
 	LevelDBStorage
+	MemoryStorage
+	BboltStorage
 )
 
+var storageTypeNames = map[StorageType]string{
+	LevelDBStorage: "leveldb",
+	MemoryStorage:  "memory",
+	BboltStorage:   "bbolt",
+}
+
+// ParseStorageType returns the storage type of the name in the db config, the empty name is leveldb.
+func ParseStorageType(name string) (StorageType, error) {
+	if name == "" {
+		return LevelDBStorage, nil
+	}
+	for t, n := range storageTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown storage type %v", name)
+}
+
+func (t StorageType) String() string {
+	if n, ok := storageTypeNames[t]; ok {
+		return n
+	}
+	return fmt.Sprintf("StorageType(%d)", uint8(t))
+}
+
 // StorageBackend is the storage backend interface
 type StorageBackend interface {
 	Get(key []byte) ([]byte, error)
@@ -23,8 +55,11 @@ type StorageBackend interface {
 	BeginBatch() error
 	CommitBatch() error
 	Size() (int64, error)
+	Compact(start, limit []byte) error
 	Close() error
 	NewIteratorByPrefix(prefix []byte) interface{}
+	NewIteratorByRange(start, limit []byte, reverse bool) interface{}
+	NewSnapshot() (interface{}, error)
 }
 
 // Storage is a kv database
@@ -32,7 +67,7 @@ type Storage struct {
 	StorageBackend
 }
 
-// NewStorage return the storage of the specify type
+// NewStorage return the storage of the specify type, the path is ignored by the memory storage
 func NewStorage(path string, t StorageType) (*Storage, error) {
 	switch t {
 	case LevelDBStorage:
@@ -41,6 +76,18 @@ func NewStorage(path string, t StorageType) (*Storage, error) {
 			return nil, err
 		}
 		return &Storage{StorageBackend: sb}, nil
+	case BboltStorage:
+		sb, err := bbolt.NewDB(path)
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{StorageBackend: sb}, nil
+	case MemoryStorage:
+		sb, err := memory.NewDB()
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{StorageBackend: sb}, nil
 	default:
 		sb, err := leveldb.NewDB(path)
 		if err != nil {
@@ -58,6 +105,15 @@ func (s *Storage) NewIteratorByPrefix(prefix []byte) *Iterator {
 	}
 }
 
+// NewIteratorByRange returns a new iterator of the key range [start, limit), nil start or limit means unbounded.
+// The keys are iterated in descending order if reverse is true.
+func (s *Storage) NewIteratorByRange(start, limit []byte, reverse bool) *Iterator {
+	ib := s.StorageBackend.NewIteratorByRange(start, limit, reverse).(IteratorBackend)
+	return &Iterator{
+		IteratorBackend: ib,
+	}
+}
+
 // IteratorBackend is the storage iterator backend
 type IteratorBackend interface {
 	Next() bool
@@ -71,3 +127,34 @@ type IteratorBackend interface {
 type Iterator struct {
 	IteratorBackend
 }
+
+// NewSnapshot returns a read-only view of the current state of the storage
+func (s *Storage) NewSnapshot() (*Snapshot, error) {
+	sb, err := s.StorageBackend.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		SnapshotBackend: sb.(SnapshotBackend),
+	}, nil
+}
+
+// SnapshotBackend is the storage snapshot backend
+type SnapshotBackend interface {
+	Get(key []byte) ([]byte, error)
+	NewIteratorByPrefix(prefix []byte) interface{}
+	Release()
+}
+
+// Snapshot is the storage snapshot, it must be released after use
+type Snapshot struct {
+	SnapshotBackend
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+func (s *Snapshot) NewIteratorByPrefix(prefix []byte) *Iterator {
+	ib := s.SnapshotBackend.NewIteratorByPrefix(prefix).(IteratorBackend)
+	return &Iterator{
+		IteratorBackend: ib,
+	}
+}
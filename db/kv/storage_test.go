@@ -2,6 +2,8 @@ package kv
 
 import (
 	"crypto/rand"
+	"fmt"
+	"os"
 	"os/exec"
 	"reflect"
 	"testing"
@@ -14,12 +16,23 @@ const (
 	DBPATH = "db"
 )
 
+// StorageTestSuite is the conformance suite every storage type must pass.
 type StorageTestSuite struct {
 	suite.Suite
 	storage *Storage
 	t       StorageType
 }
 
+// storageTypes are the storage types run by the suite, and whether they persist the data after closed.
+var storageTypes = []struct {
+	t          StorageType
+	persistent bool
+}{
+	{LevelDBStorage, true},
+	{MemoryStorage, false},
+	{BboltStorage, true},
+}
+
 func (suite *StorageTestSuite) SetupTest() {
 	storage, err := NewStorage(DBPATH, suite.t)
 	suite.Require().Nil(err)
@@ -166,6 +179,12 @@ func (suite *StorageTestSuite) TestRecover() {
 	var value []byte
 	var err error
 
+	for _, st := range storageTypes {
+		if st.t == suite.t && !st.persistent {
+			suite.T().Skipf("%v storage isn't persistent", suite.t)
+		}
+	}
+
 	value, err = suite.storage.Get([]byte("key04"))
 	suite.Nil(err)
 	suite.Equal([]byte("value04"), value)
@@ -204,20 +223,182 @@ func (suite *StorageTestSuite) TestRecover() {
 	suite.Equal([]byte{}, value)
 }
 
+func (suite *StorageTestSuite) TestSnapshot() {
+	snap, err := suite.storage.NewSnapshot()
+	suite.Require().Nil(err)
+	defer snap.Release()
+
+	err = suite.storage.Put([]byte("key01"), []byte("value11"))
+	suite.Nil(err)
+	err = suite.storage.Put([]byte("key06"), []byte("value06"))
+	suite.Nil(err)
+
+	value, err := snap.Get([]byte("key01"))
+	suite.Nil(err)
+	suite.Equal([]byte("value01"), value)
+	value, err = snap.Get([]byte("key06"))
+	suite.Nil(err)
+	suite.Equal([]byte{}, value)
+
+	iter := snap.NewIteratorByPrefix([]byte("key"))
+	keys := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	suite.Nil(iter.Error())
+	iter.Release()
+	suite.Equal([]string{"key01", "key02", "key03", "key04", "key05"}, keys)
+}
+
+func (suite *StorageTestSuite) TestHas() {
+	ok, err := suite.storage.Has([]byte("key01"))
+	suite.Nil(err)
+	suite.True(ok)
+	ok, err = suite.storage.Has([]byte("key06"))
+	suite.Nil(err)
+	suite.False(ok)
+
+	err = suite.storage.Delete([]byte("key01"))
+	suite.Nil(err)
+	ok, err = suite.storage.Has([]byte("key01"))
+	suite.Nil(err)
+	suite.False(ok)
+	err = suite.storage.Delete([]byte("key06"))
+	suite.Nil(err)
+}
+
+func (suite *StorageTestSuite) TestIterator() {
+	err := suite.storage.Put([]byte("key03"), []byte("value13"))
+	suite.Nil(err)
+	err = suite.storage.Delete([]byte("key02"))
+	suite.Nil(err)
+
+	iter := suite.storage.NewIteratorByPrefix([]byte("key"))
+	keys := make([]string, 0)
+	values := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+		values = append(values, string(iter.Value()))
+	}
+	suite.Nil(iter.Error())
+	iter.Release()
+	suite.Equal([]string{"key01", "key03", "key04", "key05"}, keys)
+	suite.Equal([]string{"value01", "value13", "value04", "value05"}, values)
+
+	iter = suite.storage.NewIteratorByPrefix([]byte("none"))
+	suite.False(iter.Next())
+	suite.Nil(iter.Error())
+	iter.Release()
+}
+
+func (suite *StorageTestSuite) TestIteratorByRange() {
+	collect := func(start, limit []byte, reverse bool) []string {
+		iter := suite.storage.NewIteratorByRange(start, limit, reverse)
+		keys := make([]string, 0)
+		for iter.Next() {
+			keys = append(keys, string(iter.Key()))
+		}
+		suite.Nil(iter.Error())
+		iter.Release()
+		return keys
+	}
+	suite.Equal([]string{"key02", "key03", "key04"}, collect([]byte("key02"), []byte("key05"), false))
+	suite.Equal([]string{"key04", "key03", "key02"}, collect([]byte("key02"), []byte("key05"), true))
+	suite.Equal([]string{"iost04", "iost05", "key01"}, collect([]byte("iost04"), []byte("key02"), false))
+	suite.Equal([]string{"key05", "key04"}, collect([]byte("key04"), nil, true))
+	suite.Equal([]string{"iost02", "iost01"}, collect(nil, []byte("iost03"), true))
+	suite.Len(collect(nil, nil, false), 10)
+	suite.Equal([]string{}, collect([]byte("key06"), nil, true))
+}
+
+func (suite *StorageTestSuite) TestIteratorMany() {
+	err := suite.storage.BeginBatch()
+	suite.Nil(err)
+	for i := 0; i < 3000; i++ {
+		err = suite.storage.Put([]byte(fmt.Sprintf("many%05d", i)), []byte(fmt.Sprintf("value%v", i)))
+		suite.Nil(err)
+	}
+	err = suite.storage.CommitBatch()
+	suite.Nil(err)
+
+	iter := suite.storage.NewIteratorByPrefix([]byte("many"))
+	n := 0
+	for iter.Next() {
+		suite.Equal(fmt.Sprintf("many%05d", n), string(iter.Key()))
+		suite.Equal(fmt.Sprintf("value%v", n), string(iter.Value()))
+		n++
+	}
+	suite.Nil(iter.Error())
+	iter.Release()
+	suite.Equal(3000, n)
+
+	iter = suite.storage.NewIteratorByRange([]byte("many00100"), []byte("many02900"), true)
+	n = 2899
+	for iter.Next() {
+		suite.Equal(fmt.Sprintf("many%05d", n), string(iter.Key()))
+		n--
+	}
+	suite.Nil(iter.Error())
+	iter.Release()
+	suite.Equal(99, n)
+}
+
+func (suite *StorageTestSuite) TestCompact() {
+	for i := 0; i < 100; i++ {
+		err := suite.storage.Put([]byte("key01"), []byte(fmt.Sprintf("value%v", i)))
+		suite.Nil(err)
+	}
+	err := suite.storage.Delete([]byte("key02"))
+	suite.Nil(err)
+
+	err = suite.storage.Compact(nil, nil)
+	suite.Nil(err)
+	size, err := suite.storage.Size()
+	suite.Nil(err)
+	suite.True(size >= 0)
+
+	value, err := suite.storage.Get([]byte("key01"))
+	suite.Nil(err)
+	suite.Equal([]byte("value99"), value)
+	value, err = suite.storage.Get([]byte("key02"))
+	suite.Nil(err)
+	suite.Equal([]byte{}, value)
+	keys, err := suite.storage.Keys([]byte("iost"))
+	suite.Nil(err)
+	suite.Len(keys, 5)
+}
+
 func (suite *StorageTestSuite) TearDownTest() {
 	err := suite.storage.Close()
 	suite.Nil(err)
-	cmd := exec.Command("rm", "-r", DBPATH)
-	err = cmd.Run()
+	err = os.RemoveAll(DBPATH)
 	suite.Require().Nil(err)
 }
 
 func TestStorageTestSuite(t *testing.T) {
-	suite.Run(t, &StorageTestSuite{t: LevelDBStorage})
+	for _, st := range storageTypes {
+		t.Run(st.t.String(), func(t *testing.T) {
+			suite.Run(t, &StorageTestSuite{t: st.t})
+		})
+	}
+}
+
+func TestParseStorageType(t *testing.T) {
+	for _, st := range storageTypes {
+		parsed, err := ParseStorageType(st.t.String())
+		assert.Nil(t, err)
+		assert.Equal(t, st.t, parsed)
+	}
+	parsed, err := ParseStorageType("")
+	assert.Nil(t, err)
+	assert.Equal(t, LevelDBStorage, parsed)
+	_, err = ParseStorageType("rocksdb")
+	assert.NotNil(t, err)
 }
 
 func BenchmarkStorage(b *testing.B) {
-	for _, t := range []StorageType{LevelDBStorage} {
+	for _, st := range storageTypes {
+		t := st.t
 		storage, err := NewStorage(DBPATH, t)
 		if err != nil {
 			b.Fatalf("Failed to new storage: %v", err)
@@ -269,7 +450,8 @@ func BenchmarkStorage(b *testing.B) {
 }
 
 func BenchmarkKeys(b *testing.B) {
-	for _, t := range []StorageType{LevelDBStorage} {
+	for _, st := range storageTypes {
+		t := st.t
 		storage, err := NewStorage(DBPATH, t)
 		if err != nil {
 			b.Fatalf("Failed to new storage: %v", err)
@@ -7,6 +7,8 @@ package db_mock
 import (
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/iost-official/go-iost/db"
+	kv "github.com/iost-official/go-iost/db/kv"
+	smt "github.com/iost-official/go-iost/db/smt"
 	reflect "reflect"
 )
 
@@ -91,6 +93,31 @@ func (mr *MockMVCCDBMockRecorder) Del(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockMVCCDB)(nil).Del), arg0, arg1)
 }
 
+// Compact mocks base method
+func (m *MockMVCCDB) Compact() error {
+	ret := m.ctrl.Call(m, "Compact")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compact indicates an expected call of Compact
+func (mr *MockMVCCDBMockRecorder) Compact() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockMVCCDB)(nil).Compact))
+}
+
+// Digest mocks base method
+func (m *MockMVCCDB) Digest() (*db.Digest, error) {
+	ret := m.ctrl.Call(m, "Digest")
+	ret0, _ := ret[0].(*db.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Digest indicates an expected call of Digest
+func (mr *MockMVCCDBMockRecorder) Digest() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Digest", reflect.TypeOf((*MockMVCCDB)(nil).Digest))
+}
+
 // Flush mocks base method
 func (m *MockMVCCDB) Flush(arg0 string) error {
 	ret := m.ctrl.Call(m, "Flush", arg0)
@@ -154,6 +181,31 @@ func (mr *MockMVCCDBMockRecorder) Keys(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockMVCCDB)(nil).Keys), arg0, arg1)
 }
 
+// NewIterator mocks base method
+func (m *MockMVCCDB) NewIterator(arg0 string, arg1 *db.IterOptions) (db.Iterator, error) {
+	ret := m.ctrl.Call(m, "NewIterator", arg0, arg1)
+	ret0, _ := ret[0].(db.Iterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewIterator indicates an expected call of NewIterator
+func (mr *MockMVCCDBMockRecorder) NewIterator(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewIterator", reflect.TypeOf((*MockMVCCDB)(nil).NewIterator), arg0, arg1)
+}
+
+// PrepareStateTree mocks base method
+func (m *MockMVCCDB) PrepareStateTree(arg0 string) error {
+	ret := m.ctrl.Call(m, "PrepareStateTree", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrepareStateTree indicates an expected call of PrepareStateTree
+func (mr *MockMVCCDBMockRecorder) PrepareStateTree(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareStateTree", reflect.TypeOf((*MockMVCCDB)(nil).PrepareStateTree), arg0)
+}
+
 // Put mocks base method
 func (m *MockMVCCDB) Put(arg0, arg1, arg2 string) error {
 	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
@@ -166,6 +218,32 @@ func (mr *MockMVCCDBMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockMVCCDB)(nil).Put), arg0, arg1, arg2)
 }
 
+// Snapshot mocks base method
+func (m *MockMVCCDB) Snapshot() (*kv.Snapshot, error) {
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(*kv.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockMVCCDBMockRecorder) Snapshot() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockMVCCDB)(nil).Snapshot))
+}
+
+// StateProof mocks base method
+func (m *MockMVCCDB) StateProof(arg0, arg1 string) (*smt.Proof, error) {
+	ret := m.ctrl.Call(m, "StateProof", arg0, arg1)
+	ret0, _ := ret[0].(*smt.Proof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProof indicates an expected call of StateProof
+func (mr *MockMVCCDBMockRecorder) StateProof(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockMVCCDB)(nil).StateProof), arg0, arg1)
+}
+
 // Size mocks base method
 func (m *MockMVCCDB) Size() (int64, error) {
 	ret := m.ctrl.Call(m, "Size")
@@ -178,3 +256,16 @@ func (m *MockMVCCDB) Size() (int64, error) {
 func (mr *MockMVCCDBMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockMVCCDB)(nil).Size))
 }
+
+// UpdateStateTree mocks base method
+func (m *MockMVCCDB) UpdateStateTree(arg0 string, arg1 bool) ([]byte, error) {
+	ret := m.ctrl.Call(m, "UpdateStateTree", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStateTree indicates an expected call of UpdateStateTree
+func (mr *MockMVCCDBMockRecorder) UpdateStateTree(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStateTree", reflect.TypeOf((*MockMVCCDB)(nil).UpdateStateTree), arg0, arg1)
+}
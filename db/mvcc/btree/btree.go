@@ -1,9 +1,376 @@
-package mvcc
+package btree
 
-// BTreeNode is the B tree node
-type BTreeNode struct {
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// Constant of btree
+const (
+	DefaultDegree = 32
+)
+
+// cow is the copy-on-write context of a btree, a btree only writes the nodes of its own context.
+// It isn't zero-sized, so that every context has its own address.
+type cow struct {
+	_ byte
+}
+
+type item struct {
+	key    []byte
+	value  interface{}
+	writer *writer
+}
+
+// node is the node of btree, a leaf has no children.
+type node struct {
+	items    []item
+	children []*node
+	cow      *cow
+}
+
+func (n *node) leaf() bool {
+	return len(n.children) == 0
+}
+
+// mutableFor returns the node itself if it's of the context, or a copy of it of the context.
+func (n *node) mutableFor(c *cow) *node {
+	if n.cow == c {
+		return n
+	}
+	out := &node{
+		items: make([]item, len(n.items), cap(n.items)),
+		cow:   c,
+	}
+	copy(out.items, n.items)
+	if len(n.children) > 0 {
+		out.children = make([]*node, len(n.children), cap(n.children))
+		copy(out.children, n.children)
+	}
+	return out
+}
+
+func (n *node) mutableChild(i int) *node {
+	c := n.children[i].mutableFor(n.cow)
+	n.children[i] = c
+	return c
+}
+
+// find returns the index of the first item whose key isn't less than key, and whether it equals.
+func (n *node) find(key []byte) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return bytes.Compare(n.items[i].key, key) >= 0
+	})
+	return i, i < len(n.items) && bytes.Equal(n.items[i].key, key)
+}
+
+// split splits the node at i, and returns the item at i and the new node of the items after i.
+func (n *node) split(i int) (item, *node) {
+	it := n.items[i]
+	next := &node{cow: n.cow}
+	next.items = append(next.items, n.items[i+1:]...)
+	for j := i; j < len(n.items); j++ {
+		n.items[j] = item{}
+	}
+	n.items = n.items[:i]
+	if len(n.children) > 0 {
+		next.children = append(next.children, n.children[i+1:]...)
+		for j := i + 1; j < len(n.children); j++ {
+			n.children[j] = nil
+		}
+		n.children = n.children[:i+1]
+	}
+	return it, next
+}
+
+// maybeSplitChild splits the child at i if it's full, and returns whether it's split.
+func (n *node) maybeSplitChild(i, maxItems int) bool {
+	if len(n.children[i].items) < maxItems {
+		return false
+	}
+	first := n.mutableChild(i)
+	it, second := first.split(maxItems / 2)
+	n.items = append(n.items, item{})
+	copy(n.items[i+1:], n.items[i:])
+	n.items[i] = it
+	n.children = append(n.children, nil)
+	copy(n.children[i+2:], n.children[i+1:])
+	n.children[i+1] = second
+	return true
+}
+
+// insert puts the item in the subtree of the node which isn't full.
+func (n *node) insert(it item, maxItems int) {
+	i, found := n.find(it.key)
+	if found {
+		n.items[i] = it
+		return
+	}
+	if n.leaf() {
+		n.items = append(n.items, item{})
+		copy(n.items[i+1:], n.items[i:])
+		n.items[i] = it
+		return
+	}
+	if n.maybeSplitChild(i, maxItems) {
+		switch c := bytes.Compare(it.key, n.items[i].key); {
+		case c == 0:
+			n.items[i] = it
+			return
+		case c > 0:
+			i++
+		}
+	}
+	n.mutableChild(i).insert(it, maxItems)
+}
+
+func (n *node) get(key []byte) (*item, bool) {
+	i, found := n.find(key)
+	if found {
+		return &n.items[i], true
+	}
+	if n.leaf() {
+		return nil, false
+	}
+	return n.children[i].get(key)
+}
+
+// ascend calls fn on the items in [start, end) in ascending order until it returns false.
+func (n *node) ascend(start, end []byte, fn func(it *item) bool) bool {
+	i := 0
+	if start != nil {
+		i, _ = n.find(start)
+	}
+	for ; i <= len(n.items); i++ {
+		if !n.leaf() && !n.children[i].ascend(start, end, fn) {
+			return false
+		}
+		if i == len(n.items) {
+			break
+		}
+		if end != nil && bytes.Compare(n.items[i].key, end) >= 0 {
+			return false
+		}
+		if !fn(&n.items[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend calls fn on the items in [start, end) in descending order until it returns false.
+func (n *node) descend(start, end []byte, fn func(it *item) bool) bool {
+	i := len(n.items)
+	if end != nil {
+		i, _ = n.find(end)
+	}
+	for ; i >= 0; i-- {
+		if !n.leaf() && !n.children[i].descend(start, end, fn) {
+			return false
+		}
+		if i == 0 {
+			break
+		}
+		if start != nil && bytes.Compare(n.items[i-1].key, start) < 0 {
+			return false
+		}
+		if !fn(&n.items[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// writer is the btree writing the items, its items are dropped by the forks once it's freed.
+type writer struct {
+	freed bool
+}
+
+// shared is the state shared between all forks of a btree.
+type shared struct {
+	rwmu sync.RWMutex
+	// freeGen is increased on every free, a btree prunes the items of the freed writers when it's behind.
+	freeGen uint64
+}
+
+// BTree is the copy-on-write btree. A fork shares the nodes with the btree it forks from,
+// and a node is copied when it's written by a btree not owning it.
+// As the parent of mvcc map, once a btree is freed, its items and the items it inherits are dropped by its forks.
+type BTree struct {
+	degree int
+	root   *node
+	cow    *cow
+	writer *writer
+	pruned uint64
+	shared *shared
+}
+
+// New returns new btree
+func New() *BTree {
+	return NewWithDegree(DefaultDegree)
+}
+
+// NewWithDegree returns new btree whose node has at most 2*degree-1 items.
+func NewWithDegree(degree int) *BTree {
+	if degree < 2 {
+		degree = 2
+	}
+	return &BTree{
+		degree: degree,
+		cow:    &cow{},
+		writer: &writer{},
+		shared: &shared{},
+	}
+}
+
+func (t *BTree) maxItems() int {
+	return t.degree*2 - 1
+}
+
+// Get returns the value of specify key
+func (t *BTree) Get(key []byte) interface{} {
+	t.shared.rwmu.RLock()
+	defer t.shared.rwmu.RUnlock()
+
+	if t.root == nil {
+		return nil
+	}
+	it, ok := t.root.get(key)
+	if !ok || it.writer.freed {
+		return nil
+	}
+	return it.value
+}
+
+// Put will insert the key-value pair
+func (t *BTree) Put(key []byte, value interface{}) {
+	t.shared.rwmu.Lock()
+	defer t.shared.rwmu.Unlock()
+
+	k := make([]byte, len(key))
+	copy(k, key)
+	t.insert(item{key: k, value: value, writer: t.writer})
+}
+
+func (t *BTree) insert(it item) {
+	if t.root == nil {
+		t.root = &node{cow: t.cow}
+		t.root.items = append(t.root.items, it)
+		return
+	}
+	t.root = t.root.mutableFor(t.cow)
+	if len(t.root.items) >= t.maxItems() {
+		first := t.root
+		second, next := first.split(t.maxItems() / 2)
+		t.root = &node{cow: t.cow}
+		t.root.items = append(t.root.items, second)
+		t.root.children = append(t.root.children, first, next)
+	}
+	t.root.insert(it, t.maxItems())
+}
+
+// All returns the list of values prefixed with prefix in the order of keys
+func (t *BTree) All(prefix []byte) []interface{} {
+	values := []interface{}{}
+	t.AscendRange(prefix, prefixEnd(prefix), func(key []byte, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// AscendRange calls fn on the key-value pairs in [start, end) in ascending order until it returns false.
+// A nil start or end means unbounded.
+func (t *BTree) AscendRange(start, end []byte, fn func(key []byte, value interface{}) bool) {
+	t.shared.rwmu.RLock()
+	defer t.shared.rwmu.RUnlock()
+
+	if t.root == nil {
+		return
+	}
+	t.root.ascend(start, end, live(fn))
+}
+
+// DescendRange calls fn on the key-value pairs in [start, end) in descending order until it returns false.
+// A nil start or end means unbounded.
+func (t *BTree) DescendRange(start, end []byte, fn func(key []byte, value interface{}) bool) {
+	t.shared.rwmu.RLock()
+	defer t.shared.rwmu.RUnlock()
+
+	if t.root == nil {
+		return
+	}
+	t.root.descend(start, end, live(fn))
+}
+
+// live skips the items of the freed writers.
+func live(fn func(key []byte, value interface{}) bool) func(it *item) bool {
+	return func(it *item) bool {
+		if it.writer.freed {
+			return true
+		}
+		return fn(it.key, it.value)
+	}
+}
+
+// prune rebuilds the btree without the items of the freed writers.
+func (t *BTree) prune() {
+	t.pruned = t.shared.freeGen
+	if t.root == nil {
+		return
+	}
+	root := t.root
+	t.root = nil
+	t.cow = &cow{}
+	root.ascend(nil, nil, func(it *item) bool {
+		if !it.writer.freed {
+			t.insert(*it)
+		}
+		return true
+	})
+}
+
+// Fork will fork the btree
+// thread safe between all forks of the btree
+func (t *BTree) Fork() interface{} {
+	t.shared.rwmu.Lock()
+	defer t.shared.rwmu.Unlock()
+
+	if t.pruned != t.shared.freeGen {
+		t.prune()
+	}
+	// Both of them copy the shared nodes on writing from now on.
+	t.cow = &cow{}
+	return &BTree{
+		degree: t.degree,
+		root:   t.root,
+		cow:    &cow{},
+		writer: &writer{},
+		pruned: t.pruned,
+		shared: t.shared,
+	}
+}
+
+// Free will free the memory of btree
+func (t *BTree) Free() {
+	t.shared.rwmu.Lock()
+	defer t.shared.rwmu.Unlock()
+
+	t.root = nil
+	t.writer.freed = true
+	t.shared.freeGen++
 }
 
-// BTreeLeaf is the B tree leaf
-type BTreeLeaf struct {
+// prefixEnd returns the least key greater than all keys prefixed with prefix, nil if there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
 }
@@ -0,0 +1,149 @@
+package btree
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func keys(t *BTree, start, end []byte, reverse bool) []string {
+	res := []string{}
+	fn := func(key []byte, value interface{}) bool {
+		res = append(res, string(key))
+		return true
+	}
+	if reverse {
+		t.DescendRange(start, end, fn)
+	} else {
+		t.AscendRange(start, end, fn)
+	}
+	return res
+}
+
+func TestBTree(t *testing.T) {
+	tree := NewWithDegree(2)
+	expected := make(map[string]interface{})
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		k := fmt.Sprintf("key%04d", rnd.Intn(1000))
+		tree.Put([]byte(k), i)
+		expected[k] = i
+	}
+
+	sorted := make([]string, 0, len(expected))
+	for k, v := range expected {
+		assert.Equal(t, v, tree.Get([]byte(k)))
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	assert.Nil(t, tree.Get([]byte("nokey")))
+	assert.Equal(t, sorted, keys(tree, nil, nil, false))
+	assert.Len(t, tree.All([]byte("key")), len(expected))
+
+	reversed := make([]string, len(sorted))
+	for i, k := range sorted {
+		reversed[len(sorted)-1-i] = k
+	}
+	assert.Equal(t, reversed, keys(tree, nil, nil, true))
+
+	var inRange []string
+	for _, k := range sorted {
+		if k >= "key0100" && k < "key0200" {
+			inRange = append(inRange, k)
+		}
+	}
+	assert.Equal(t, inRange, keys(tree, []byte("key0100"), []byte("key0200"), false))
+
+	var first []string
+	tree.DescendRange(nil, []byte("key0500"), func(key []byte, value interface{}) bool {
+		first = append(first, string(key))
+		return len(first) < 3
+	})
+	assert.Len(t, first, 3)
+	assert.True(t, first[0] < "key0500" && first[1] < first[0] && first[2] < first[1])
+}
+
+func TestAll(t *testing.T) {
+	tree := New()
+	tree.Put([]byte("a/1"), "a1")
+	tree.Put([]byte("ab/1"), "ab1")
+	tree.Put([]byte("a/0"), "a0")
+	tree.Put([]byte{'b', 0xff}, "b")
+	tree.Put([]byte{'b', 0xff, 0xff}, "bb")
+	tree.Put([]byte{'c'}, "c")
+
+	assert.Equal(t, []interface{}{"a0", "a1"}, tree.All([]byte("a/")))
+	assert.Equal(t, []interface{}{"b", "bb"}, tree.All([]byte{'b', 0xff}))
+	assert.Equal(t, []interface{}{"a0", "a1", "ab1", "b", "bb", "c"}, tree.All(nil))
+	assert.Equal(t, []interface{}{}, tree.All([]byte("d")))
+}
+
+func TestFork(t *testing.T) {
+	tree := NewWithDegree(2)
+	for i := 0; i < 100; i++ {
+		tree.Put([]byte(fmt.Sprintf("key%03d", i)), i)
+	}
+	fork := tree.Fork().(*BTree)
+	for i := 0; i < 100; i += 2 {
+		fork.Put([]byte(fmt.Sprintf("key%03d", i)), -i)
+	}
+	fork.Put([]byte("new"), "fork")
+	tree.Put([]byte("key001"), "tree")
+
+	for i := 0; i < 100; i++ {
+		k := []byte(fmt.Sprintf("key%03d", i))
+		switch {
+		case i == 1:
+			assert.Equal(t, "tree", tree.Get(k))
+			assert.Equal(t, 1, fork.Get(k))
+		case i%2 == 0:
+			assert.Equal(t, i, tree.Get(k))
+			assert.Equal(t, -i, fork.Get(k))
+		default:
+			assert.Equal(t, i, tree.Get(k))
+			assert.Equal(t, i, fork.Get(k))
+		}
+	}
+	assert.Nil(t, tree.Get([]byte("new")))
+	assert.Equal(t, "fork", fork.Get([]byte("new")))
+	assert.Len(t, tree.All(nil), 100)
+	assert.Len(t, fork.All(nil), 101)
+}
+
+func TestFree(t *testing.T) {
+	commit0 := New()
+	commit0.Put([]byte("a"), 0)
+	commit0.Put([]byte("b"), 0)
+	commit1 := commit0.Fork().(*BTree)
+	commit1.Put([]byte("b"), 1)
+	commit1.Put([]byte("c"), 1)
+	stage := commit1.Fork().(*BTree)
+	stage.Put([]byte("d"), 2)
+
+	// As the commits of mvccdb, the commits before the flushed one are freed.
+	commit0.Free()
+	assert.Nil(t, commit0.Get([]byte("a")))
+	assert.Nil(t, stage.Get([]byte("a")))
+	assert.Equal(t, 1, stage.Get([]byte("b")))
+	assert.Equal(t, []interface{}{1, 1, 2}, stage.All(nil))
+	assert.Equal(t, []interface{}{1, 1}, commit1.All(nil))
+
+	fork := commit1.Fork().(*BTree)
+	assert.Equal(t, []interface{}{1, 1}, fork.All(nil))
+	fork.Put([]byte("a"), 3)
+	assert.Equal(t, 3, fork.Get([]byte("a")))
+	assert.Nil(t, commit1.Get([]byte("a")))
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, []byte("b"), prefixEnd([]byte("a")))
+	assert.Equal(t, []byte{'a', 1}, prefixEnd([]byte{'a', 0}))
+	assert.Equal(t, []byte{'b'}, prefixEnd([]byte{'a', 0xff}))
+	assert.Nil(t, prefixEnd([]byte{0xff, 0xff}))
+	assert.Nil(t, prefixEnd(nil))
+	assert.True(t, bytes.Compare([]byte("a/"), prefixEnd([]byte("a"))) < 0)
+}
@@ -1,6 +1,9 @@
 package mvcc
 
 import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/db/mvcc/btree"
 	"github.com/iost-official/go-iost/db/mvcc/map"
 	"github.com/iost-official/go-iost/db/mvcc/trie"
 )
@@ -13,8 +16,35 @@ const (
 	_ CacheType = iota
 	TrieCache
 	MapCache
+	BTreeCache
 )
 
+var cacheTypeNames = map[CacheType]string{
+	TrieCache:  "trie",
+	MapCache:   "map",
+	BTreeCache: "btree",
+}
+
+// ParseCacheType returns the cache type of the name in the db config, the empty name is map.
+func ParseCacheType(name string) (CacheType, error) {
+	if name == "" {
+		return MapCache, nil
+	}
+	for t, n := range cacheTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown cache type %v", name)
+}
+
+func (t CacheType) String() string {
+	if n, ok := cacheTypeNames[t]; ok {
+		return n
+	}
+	return fmt.Sprintf("CacheType(%d)", int(t))
+}
+
 // Cache is the cache interface
 type Cache interface {
 	Get(key []byte) interface{}
@@ -31,6 +61,8 @@ func NewCache(t CacheType) Cache {
 		return trie.New()
 	case MapCache:
 		return mvccmap.New()
+	case BTreeCache:
+		return btree.New()
 	default:
 		return trie.New()
 	}
@@ -0,0 +1,119 @@
+package mvcc
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var cacheTypes = []struct {
+	name string
+	t    CacheType
+}{
+	{"Trie", TrieCache},
+	{"Map", MapCache},
+	{"BTree", BTreeCache},
+}
+
+func TestParseCacheType(t *testing.T) {
+	for _, c := range cacheTypes {
+		parsed, err := ParseCacheType(c.t.String())
+		if err != nil || parsed != c.t {
+			t.Fatalf("parse %v: %v, %v", c.t, parsed, err)
+		}
+	}
+	if parsed, err := ParseCacheType(""); err != nil || parsed != MapCache {
+		t.Fatalf("parse the empty name: %v, %v", parsed, err)
+	}
+	if _, err := ParseCacheType("skiplist"); err == nil {
+		t.Fatal("expect an error for the unknown cache type")
+	}
+}
+
+// stateKeys returns the keys like the ones written by the contracts, "state/" + contract + "-" + field + "-" + account.
+func stateKeys(n int) [][]byte {
+	fields := []string{"m-token.iost-TB", "m-gas.iost-pg", "m-ram.iost-us", "m-vote_producer.iost-v_1"}
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("state/%v-user%08d", fields[i%len(fields)], i/len(fields)))
+	}
+	return keys
+}
+
+func BenchmarkPut(b *testing.B) {
+	keys := stateKeys(100000)
+	for _, c := range cacheTypes {
+		b.Run(c.name, func(b *testing.B) {
+			cache := NewCache(c.t)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cache.Put(keys[i%len(keys)], i)
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := stateKeys(100000)
+	for _, c := range cacheTypes {
+		b.Run(c.name, func(b *testing.B) {
+			cache := NewCache(c.t)
+			for i, k := range keys {
+				cache.Put(k, i)
+			}
+			rnd := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cache.Get(keys[rnd.Intn(len(keys))])
+			}
+		})
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	keys := stateKeys(10000)
+	for _, c := range cacheTypes {
+		b.Run(c.name, func(b *testing.B) {
+			cache := NewCache(c.t)
+			for i, k := range keys {
+				cache.Put(k, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cache.All([]byte("state/m-gas.iost-pg"))
+			}
+		})
+	}
+}
+
+// BenchmarkBlock forks a stage from the head for each block, writes and reads it, and commits it as the new head,
+// as the mvccdb does when the blocks are verified. The commits before the last 10 ones are freed as they are flushed.
+func BenchmarkBlock(b *testing.B) {
+	keys := stateKeys(100000)
+	for _, c := range cacheTypes {
+		b.Run(c.name, func(b *testing.B) {
+			head := NewCache(c.t)
+			for i, k := range keys {
+				head.Put(k, i)
+			}
+			commits := []Cache{head}
+			rnd := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				stage := head.Fork().(Cache)
+				for j := 0; j < 200; j++ {
+					stage.Get(keys[rnd.Intn(len(keys))])
+				}
+				for j := 0; j < 50; j++ {
+					stage.Put(keys[rnd.Intn(len(keys))], i)
+				}
+				head = stage
+				commits = append(commits, head)
+				if len(commits) > 10 {
+					commits[0].Free()
+					commits = commits[1:]
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/iost-official/go-iost/db/kv"
 	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/db/schema"
+	"github.com/iost-official/go-iost/db/smt"
 )
 
 //go:generate mockgen -destination mocks/mock_mvccdb.go -package db_mock github.com/iost-official/go-iost/db MVCCDB
@@ -18,8 +20,17 @@ const (
 // error of mvccdb
 var (
 	ErrTableNotValid = fmt.Errorf("table name is not valid")
+
+	compactChunkKeys = 100000
 )
 
+var tagKey = []byte(string(SEPARATOR) + "tag")
+
+// Schema is the schema of the storage of mvccdb, the db created before has the tag.
+var Schema = schema.New("StateDB", []byte(string(SEPARATOR)+"schema"), schema.BaseVersion, func(s *kv.Storage) (bool, error) {
+	return s.Has(tagKey)
+})
+
 // MVCCDB is the interface of mvccdb
 type MVCCDB interface {
 	Get(table string, key string) (string, error)
@@ -27,18 +38,31 @@ type MVCCDB interface {
 	Del(table string, key string) error
 	Has(table string, key string) (bool, error)
 	Keys(table string, prefix string) ([]string, error)
+	NewIterator(table string, opts *IterOptions) (Iterator, error)
 	Checkout(t string) bool
 	Commit(t string)
 	CurrentTag() string
 	Fork() MVCCDB
 	Flush(t string) error
+	Digest() (*Digest, error)
+	UpdateStateTree(table string, rebuild bool) ([]byte, error)
+	PrepareStateTree(table string) error
+	StateProof(table string, key string) (*smt.Proof, error)
+	Snapshot() (*kv.Snapshot, error)
+	Compact() error
 	Size() (int64, error)
 	Close() error
 }
 
 // NewMVCCDB return new mvccdb
 func NewMVCCDB(path string) (MVCCDB, error) {
-	return NewCacheMVCCDB(path, mvcc.MapCache)
+	return NewMVCCDBWithStorage(path, kv.LevelDBStorage, mvcc.MapCache)
+}
+
+// NewMVCCDBWithStorage return new mvccdb on the storage of the specify type, keeping the uncommitted writes in the
+// cache of the specify type
+func NewMVCCDBWithStorage(path string, storageType kv.StorageType, cacheType mvcc.CacheType) (MVCCDB, error) {
+	return NewCacheMVCCDB(path, cacheType, storageType)
 }
 
 // Item is the value of cache
@@ -47,12 +71,20 @@ type Item struct {
 	key     string
 	value   string
 	deleted bool
+	// stage is the stage the item is written in, it tells the writes of a commit from the ones of its parents.
+	stage *stageID
+}
+
+// stageID identifies the stage of mvccdb until it's committed.
+type stageID struct {
+	_ byte
 }
 
 // Commit is the cache of specify tag
 type Commit struct {
 	mvcc.Cache
-	Tag string
+	Tag   string
+	stage *stageID
 }
 
 // NewCommit returns new commit
@@ -141,28 +173,43 @@ func (m *CommitManager) FreeBefore(c *Commit) {
 type CacheMVCCDB struct {
 	head    *Commit
 	stage   mvcc.Cache
+	stageID *stageID
 	storage *kv.Storage
 	cm      *CommitManager
 	rwmu    sync.RWMutex
+	flushmu *sync.Mutex
+	// prepared is guarded by flushmu.
+	prepared *treePreparer
 }
 
 // NewCacheMVCCDB returns new CacheMVCCDB
-func NewCacheMVCCDB(path string, cacheType mvcc.CacheType) (*CacheMVCCDB, error) {
-	storage, err := kv.NewStorage(path, kv.LevelDBStorage)
+func NewCacheMVCCDB(path string, cacheType mvcc.CacheType, storageType kv.StorageType) (*CacheMVCCDB, error) {
+	storage, err := kv.NewStorage(path, storageType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to new storage: %v", err)
 	}
+	if err := Schema.Open(storage); err != nil {
+		storage.Close()
+		return nil, err
+	}
 	stage := mvcc.NewCache(cacheType)
 	cm := NewCommitManager()
 
 	mvccdb := &CacheMVCCDB{
-		head:    nil,
-		stage:   stage,
-		storage: storage,
-		cm:      cm,
+		head:     nil,
+		stage:    stage,
+		stageID:  &stageID{},
+		storage:  storage,
+		cm:       cm,
+		flushmu:  new(sync.Mutex),
+		prepared: &treePreparer{},
+	}
+	if err := mvccdb.loadPreparedTree(); err != nil {
+		storage.Close()
+		return nil, fmt.Errorf("failed to load prepared state tree: %v", err)
 	}
 
-	tag, err := storage.Get([]byte(string(SEPARATOR) + "tag"))
+	tag, err := storage.Get(tagKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get init tag from storage: %v", err)
 	}
@@ -220,6 +267,7 @@ func (m *CacheMVCCDB) Put(table string, key string, value string) error {
 		key:     key,
 		value:   value,
 		deleted: false,
+		stage:   m.stageID,
 	}
 	m.stage.Put(k, v)
 	return nil
@@ -236,6 +284,7 @@ func (m *CacheMVCCDB) Del(table string, key string) error {
 		key:     key,
 		value:   "",
 		deleted: true,
+		stage:   m.stageID,
 	}
 	m.stage.Put(k, v)
 	return nil
@@ -261,27 +310,21 @@ func (m *CacheMVCCDB) Has(table string, key string) (bool, error) {
 	return true, nil
 }
 
-// Keys returns the list of key prefixed with prefix in the table
+// Keys returns the list of key prefixed with prefix in the table in ascending order
 func (m *CacheMVCCDB) Keys(table string, prefix string) ([]string, error) {
-	//if !m.isValidTable(table) {
-	//	return nil, ErrTableNotValid
-	//}
-	//p := []byte(table + string(SEPARATOR) + prefix)
-	//m.stagerw.RLock()
-	//vlist := m.stage.Keys(p)
-	//m.stagerw.RUnlock()
-	//keys, err := m.storage.Keys(p)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//// TODO use iterator instead of keys
-	//for key := range keys {
-	//
-	//}
-	//	if !ok {
-	//		return nil, error.New("can't assert Item type")
-	//	}
-	return nil, nil
+	iter, err := m.NewIterator(table, &IterOptions{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	keys := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return keys, nil
 }
 
 // Checkout will checkout the specify tag of mvccdb
@@ -295,6 +338,7 @@ func (m *CacheMVCCDB) Checkout(t string) bool {
 	}
 	m.head = head
 	m.stage = m.head.ForkCache()
+	m.stageID = &stageID{}
 	return true
 }
 
@@ -304,7 +348,9 @@ func (m *CacheMVCCDB) Commit(t string) {
 	defer m.rwmu.Unlock()
 
 	m.head = NewCommit(m.stage, t)
+	m.head.stage = m.stageID
 	m.stage = m.head.ForkCache()
+	m.stageID = &stageID{}
 	m.cm.Add(m.head)
 }
 
@@ -323,10 +369,13 @@ func (m *CacheMVCCDB) Fork() MVCCDB {
 	defer m.rwmu.RUnlock()
 
 	mvccdb := &CacheMVCCDB{
-		head:    m.head,
-		stage:   m.head.ForkCache(),
-		storage: m.storage,
-		cm:      m.cm,
+		head:     m.head,
+		stage:    m.head.ForkCache(),
+		stageID:  &stageID{},
+		storage:  m.storage,
+		cm:       m.cm,
+		flushmu:  m.flushmu,
+		prepared: m.prepared,
 	}
 	return mvccdb
 }
@@ -337,18 +386,36 @@ func (m *CacheMVCCDB) Flush(t string) error {
 	if commit == nil {
 		return fmt.Errorf("not found tag: %v", t)
 	}
+	m.flushmu.Lock()
+	defer m.flushmu.Unlock()
 	if err := m.storage.BeginBatch(); err != nil {
 		return err
 	}
-	err := m.storage.Put([]byte(string(SEPARATOR)+"tag"), []byte(t))
+	err := m.storage.Put(tagKey, []byte(t))
 	if err != nil {
 		return err
 	}
+	writes := make(map[string]*Item)
+	var treeWrites map[string]*Item
+	treeCommitted := false
+	if p := m.prepared.tree; p != nil {
+		treeWrites = make(map[string]*Item)
+	}
 	for _, v := range commit.All([]byte("")) {
 		item, ok := v.(*Item)
 		if !ok {
 			return fmt.Errorf("can't assert Item type")
 		}
+		if item.stage == commit.stage {
+			writes[item.table+string(SEPARATOR)+item.key] = item
+		}
+		if p := m.prepared.tree; p != nil {
+			if item.table == p.table {
+				treeWrites[item.key] = item
+			} else if item.table == treeTable(p.table) {
+				treeCommitted = true
+			}
+		}
 		if item.deleted {
 			err := m.storage.Delete([]byte(item.table + string(SEPARATOR) + item.key))
 			if err != nil {
@@ -361,6 +428,17 @@ func (m *CacheMVCCDB) Flush(t string) error {
 			}
 		}
 	}
+	if err := m.flushPreparedTree(treeWrites, treeCommitted); err != nil {
+		return err
+	}
+	digest, err := m.Digest()
+	if err != nil {
+		return err
+	}
+	err = m.storage.Put(digestKey, digest.next(writes).Encode())
+	if err != nil {
+		return err
+	}
 	if err := m.storage.CommitBatch(); err != nil {
 		return err
 	}
@@ -368,6 +446,48 @@ func (m *CacheMVCCDB) Flush(t string) error {
 	return nil
 }
 
+// Snapshot returns a read-only view of the flushed state of mvccdb
+func (m *CacheMVCCDB) Snapshot() (*kv.Snapshot, error) {
+	return m.storage.NewSnapshot()
+}
+
+// Compact compacts the storage of mvccdb range by range. It doesn't hold back the flushes, the storage
+// compacts the ranges while they are written.
+func (m *CacheMVCCDB) Compact() error {
+	bounds, err := m.compactBounds()
+	if err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(bounds); i++ {
+		err := m.storage.Compact(bounds[i], bounds[i+1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactBounds splits the keys of the storage to ranges of compactChunkKeys keys.
+func (m *CacheMVCCDB) compactBounds() ([][]byte, error) {
+	snap, err := m.storage.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+	bounds := [][]byte{nil}
+	iter := snap.NewIteratorByPrefix([]byte{})
+	defer iter.Release()
+	for n := 1; iter.Next(); n++ {
+		if n%compactChunkKeys == 0 {
+			bounds = append(bounds, append([]byte{}, iter.Key()...))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return append(bounds, nil), nil
+}
+
 // Size returns the size of mvccdb
 func (m *CacheMVCCDB) Size() (int64, error) {
 	return m.storage.Size()
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+)
+
+const pruneBatchKeys = 10000
+
+// PruneResult is the result of pruning a mvccdb.
+type PruneResult struct {
+	Keys      int64
+	Before    int64
+	After     int64
+	Reclaimed int64
+	Tag       string
+}
+
+// DiskUsage returns the total size of the files in the directory.
+func DiskUsage(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.Mode().IsRegular() {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// Prune copies the keys of the mvccdb at path to a new storage of the type, and replaces the old one with it.
+// The space the old storage keeps for the overwritten and deleted keys, such as the stale versions and the
+// tombstones of leveldb or the free pages of bbolt, isn't copied. It must not be called when the mvccdb is
+// opened, and the old storage is kept if the current tag of the new one differs.
+func Prune(path string, storageType kv.StorageType) (*PruneResult, error) {
+	if storageType == kv.MemoryStorage {
+		return nil, fmt.Errorf("the %v storage isn't kept on disk", storageType)
+	}
+	path = filepath.Clean(path)
+	before, err := DiskUsage(path)
+	if err != nil {
+		return nil, err
+	}
+	tmp := path + ".prune"
+	err = os.RemoveAll(tmp)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	keys, err := copyStorage(path, tmp, storageType)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := currentTag(path, storageType)
+	if err != nil {
+		return nil, err
+	}
+	newTag, err := currentTag(tmp, storageType)
+	if err != nil {
+		return nil, err
+	}
+	if tag != newTag {
+		return nil, fmt.Errorf("current tag changed by pruning: %x -> %x", tag, newTag)
+	}
+
+	old := path + ".old"
+	err = os.Rename(path, old)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Rename(tmp, path)
+	if err != nil {
+		os.Rename(old, path)
+		return nil, err
+	}
+	err = os.RemoveAll(old)
+	if err != nil {
+		return nil, err
+	}
+	after, err := DiskUsage(path)
+	if err != nil {
+		return nil, err
+	}
+	return &PruneResult{
+		Keys:      keys,
+		Before:    before,
+		After:     after,
+		Reclaimed: before - after,
+		Tag:       tag,
+	}, nil
+}
+
+// copyStorage copies all keys of the storage at src to a new storage at dst, and returns the number of keys.
+func copyStorage(src, dst string, storageType kv.StorageType) (int64, error) {
+	if _, err := os.Stat(src); err != nil {
+		return 0, err
+	}
+	from, err := kv.NewStorage(src, storageType)
+	if err != nil {
+		return 0, err
+	}
+	defer from.Close()
+	to, err := kv.NewStorage(dst, storageType)
+	if err != nil {
+		return 0, err
+	}
+	defer to.Close()
+
+	var keys int64
+	iter := from.NewIteratorByPrefix([]byte{})
+	defer iter.Release()
+	if err := to.BeginBatch(); err != nil {
+		return 0, err
+	}
+	for iter.Next() {
+		if err := to.Put(iter.Key(), iter.Value()); err != nil {
+			return 0, err
+		}
+		keys++
+		if keys%pruneBatchKeys == 0 {
+			if err := to.CommitBatch(); err != nil {
+				return 0, err
+			}
+			if err := to.BeginBatch(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	if err := to.CommitBatch(); err != nil {
+		return 0, err
+	}
+	return keys, to.Compact(nil, nil)
+}
+
+func currentTag(path string, storageType kv.StorageType) (string, error) {
+	m, err := NewCacheMVCCDB(path, mvcc.MapCache, storageType)
+	if err != nil {
+		return "", err
+	}
+	defer m.Close()
+	return m.CurrentTag(), nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/stretchr/testify/assert"
+)
+
+func fillMVCCDB(t *testing.T, m MVCCDB) {
+	value := string(make([]byte, 256))
+	for i := 0; i < 2000; i++ {
+		assert.Nil(t, m.Put("state", fmt.Sprintf("key%04d", i), value))
+	}
+	m.Commit("tag1")
+	assert.Nil(t, m.Flush("tag1"))
+	for i := 0; i < 2000; i += 2 {
+		assert.Nil(t, m.Del("state", fmt.Sprintf("key%04d", i)))
+	}
+	m.Commit("tag2")
+	assert.Nil(t, m.Flush("tag2"))
+}
+
+func TestPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prune")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "StateDB")
+
+	m, err := NewMVCCDB(path)
+	assert.Nil(t, err)
+	fillMVCCDB(t, m)
+	assert.Nil(t, m.Close())
+
+	result, err := Prune(path, kv.LevelDBStorage)
+	assert.Nil(t, err)
+	// The live keys, the tag, the digest and the schema version.
+	assert.Equal(t, int64(1003), result.Keys)
+	assert.Equal(t, "tag2", result.Tag)
+	assert.Equal(t, result.Before-result.After, result.Reclaimed)
+	assert.True(t, result.Reclaimed > 0)
+	_, err = os.Stat(path + ".prune")
+	assert.True(t, os.IsNotExist(err))
+
+	m, err = NewMVCCDB(path)
+	assert.Nil(t, err)
+	defer m.Close()
+	assert.Equal(t, "tag2", m.CurrentTag())
+	v, err := m.Get("state", "key0001")
+	assert.Nil(t, err)
+	assert.Equal(t, 256, len(v))
+	has, err := m.Has("state", "key0002")
+	assert.Nil(t, err)
+	assert.False(t, has)
+
+	_, err = Prune(filepath.Join(dir, "NotExist"), kv.LevelDBStorage)
+	assert.NotNil(t, err)
+	_, err = Prune(path, kv.MemoryStorage)
+	assert.NotNil(t, err)
+}
+
+func TestCompact(t *testing.T) {
+	origin := compactChunkKeys
+	compactChunkKeys = 100
+	defer func() { compactChunkKeys = origin }()
+
+	dir, err := ioutil.TempDir("", "compact")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	m, err := NewMVCCDB(filepath.Join(dir, "StateDB"))
+	assert.Nil(t, err)
+	defer m.Close()
+	fillMVCCDB(t, m)
+
+	bounds, err := m.(*CacheMVCCDB).compactBounds()
+	assert.Nil(t, err)
+	assert.Equal(t, 12, len(bounds))
+
+	// The blocks are flushed while the state db is compacted.
+	done := make(chan error)
+	go func() {
+		done <- m.Compact()
+	}()
+	for i := 0; i < 2000; i += 4 {
+		assert.Nil(t, m.Put("state", fmt.Sprintf("key%04d", i), "new"))
+	}
+	m.Commit("tag3")
+	assert.Nil(t, m.Flush("tag3"))
+	assert.Nil(t, <-done)
+
+	assert.Equal(t, "tag3", m.CurrentTag())
+	v, err := m.Get("state", "key1999")
+	assert.Nil(t, err)
+	assert.Equal(t, 256, len(v))
+	v, err = m.Get("state", "key1996")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", v)
+	has, err := m.Has("state", "key1998")
+	assert.Nil(t, err)
+	assert.False(t, has)
+}
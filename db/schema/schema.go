@@ -0,0 +1,153 @@
+// Package schema records the schema versions of the dbs, and migrates the dbs of the older versions
+// step by step to the versions of the program.
+package schema
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/db/kv"
+)
+
+// BaseVersion is the version of the dbs created before the versions are recorded.
+const BaseVersion int64 = 1
+
+// errors of the schema
+var (
+	ErrOutdated = errors.New("db schema is outdated")
+	ErrTooNew   = errors.New("db schema is newer than supported")
+)
+
+// Migration is a step migrating the db from the version before to Version. It may be interrupted and run again,
+// the version is recorded only after it returns.
+type Migration struct {
+	Version     int64
+	Description string
+	Migrate     func(s *kv.Storage) error
+}
+
+// Schema is the schema of a kind of db.
+type Schema struct {
+	// Name is the name of the db in the messages.
+	Name string
+	// Version is the version of the db written by the program.
+	Version int64
+
+	key        []byte
+	created    func(s *kv.Storage) (bool, error)
+	migrations map[int64]*Migration
+}
+
+// New returns the schema of the version, whose version is kept under the key in the db. created reports
+// whether the db is created before, the empty db is given the version of the schema.
+func New(name string, key []byte, version int64, created func(s *kv.Storage) (bool, error)) *Schema {
+	return &Schema{
+		Name:       name,
+		Version:    version,
+		key:        key,
+		created:    created,
+		migrations: make(map[int64]*Migration),
+	}
+}
+
+// Register registers the migration step to its version. It panics if the version is registered or out of the schema.
+func (s *Schema) Register(m *Migration) {
+	if m.Version <= BaseVersion || m.Version > s.Version {
+		panic(fmt.Sprintf("migration of %v to version %v out of range (%v, %v]", s.Name, m.Version, BaseVersion, s.Version))
+	}
+	if _, ok := s.migrations[m.Version]; ok {
+		panic(fmt.Sprintf("migration of %v to version %v registered twice", s.Name, m.Version))
+	}
+	s.migrations[m.Version] = m
+}
+
+// Get returns the version of the db, and whether it's recorded in the db.
+func (s *Schema) Get(db *kv.Storage) (int64, bool, error) {
+	b, err := db.Get(s.key)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get the schema version of %v: %v", s.Name, err)
+	}
+	if len(b) != 0 {
+		return common.BytesToInt64(b), true, nil
+	}
+	created, err := s.created(db)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to check %v: %v", s.Name, err)
+	}
+	if created {
+		return BaseVersion, false, nil
+	}
+	return s.Version, false, nil
+}
+
+func (s *Schema) put(db *kv.Storage, version int64) error {
+	if err := db.Put(s.key, common.Int64ToBytes(version)); err != nil {
+		return fmt.Errorf("failed to put the schema version of %v: %v", s.Name, err)
+	}
+	return nil
+}
+
+// Open checks the version of the db opened by the program, and records it if it isn't recorded.
+func (s *Schema) Open(db *kv.Storage) error {
+	version, recorded, err := s.Get(db)
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		if err := s.put(db, version); err != nil {
+			return err
+		}
+	}
+	if version < s.Version {
+		return fmt.Errorf("%v: %v is of version %v, expected %v, run `iserver migrate` to migrate it", ErrOutdated, s.Name, version, s.Version)
+	}
+	if version > s.Version {
+		return fmt.Errorf("%v: %v is of version %v, expected %v, upgrade iserver to open it", ErrTooNew, s.Name, version, s.Version)
+	}
+	return nil
+}
+
+// Plan returns the migration steps from the version to the version of the schema.
+func (s *Schema) Plan(version int64) ([]*Migration, error) {
+	if version > s.Version {
+		return nil, fmt.Errorf("%v: %v is of version %v, expected %v", ErrTooNew, s.Name, version, s.Version)
+	}
+	steps := make([]*Migration, 0, s.Version-version)
+	for v := version + 1; v <= s.Version; v++ {
+		m, ok := s.migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("no migration of %v to version %v", s.Name, v)
+		}
+		steps = append(steps, m)
+	}
+	return steps, nil
+}
+
+// Migrate migrates the db to the version of the schema step by step, and records the version after each step.
+// before is called before running each step. It returns the version before the migration.
+func (s *Schema) Migrate(db *kv.Storage, before func(m *Migration)) (int64, error) {
+	version, _, err := s.Get(db)
+	if err != nil {
+		return 0, err
+	}
+	steps, err := s.Plan(version)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range steps {
+		if before != nil {
+			before(m)
+		}
+		if err := m.Migrate(db); err != nil {
+			return version, fmt.Errorf("failed to migrate %v to version %v: %v", s.Name, m.Version, err)
+		}
+		if err := s.put(db, m.Version); err != nil {
+			return version, err
+		}
+	}
+	if err := s.put(db, s.Version); err != nil {
+		return version, err
+	}
+	return version, nil
+}
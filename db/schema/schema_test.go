@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchema(version int64) *Schema {
+	return New("TestDB", []byte("version"), version, func(s *kv.Storage) (bool, error) {
+		return s.Has([]byte("data"))
+	})
+}
+
+func TestOpen(t *testing.T) {
+	db, err := kv.NewStorage("", kv.MemoryStorage)
+	require.Nil(t, err)
+	defer db.Close()
+
+	// The empty db is given the version of the schema.
+	s := testSchema(3)
+	assert.Nil(t, s.Open(db))
+	version, recorded, err := s.Get(db)
+	require.Nil(t, err)
+	assert.True(t, recorded)
+	assert.Equal(t, int64(3), version)
+
+	assert.True(t, strings.HasPrefix(testSchema(2).Open(db).Error(), ErrTooNew.Error()))
+	assert.True(t, strings.HasPrefix(testSchema(4).Open(db).Error(), ErrOutdated.Error()))
+
+	// The db created before the versions are recorded is of the base version.
+	old, err := kv.NewStorage("", kv.MemoryStorage)
+	require.Nil(t, err)
+	defer old.Close()
+	require.Nil(t, old.Put([]byte("data"), []byte("0")))
+	assert.NotNil(t, s.Open(old))
+	version, recorded, err = s.Get(old)
+	require.Nil(t, err)
+	assert.True(t, recorded)
+	assert.Equal(t, BaseVersion, version)
+}
+
+func TestMigrate(t *testing.T) {
+	db, err := kv.NewStorage("", kv.MemoryStorage)
+	require.Nil(t, err)
+	defer db.Close()
+	require.Nil(t, db.Put([]byte("data"), []byte("0")))
+
+	s := testSchema(3)
+	step := func(value string) func(s *kv.Storage) error {
+		return func(s *kv.Storage) error {
+			v, err := s.Get([]byte("data"))
+			if err != nil {
+				return err
+			}
+			return s.Put([]byte("data"), append(v, value...))
+		}
+	}
+	s.Register(&Migration{Version: 3, Description: "append 3", Migrate: step("3")})
+	_, err = s.Plan(BaseVersion)
+	assert.NotNil(t, err)
+	s.Register(&Migration{Version: 2, Description: "append 2", Migrate: step("2")})
+	assert.Panics(t, func() { s.Register(&Migration{Version: 2}) })
+	assert.Panics(t, func() { s.Register(&Migration{Version: 4}) })
+	assert.Panics(t, func() { s.Register(&Migration{Version: BaseVersion}) })
+
+	steps, err := s.Plan(BaseVersion)
+	require.Nil(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, int64(2), steps[0].Version)
+
+	ran := make([]int64, 0)
+	from, err := s.Migrate(db, func(m *Migration) {
+		ran = append(ran, m.Version)
+	})
+	require.Nil(t, err)
+	assert.Equal(t, BaseVersion, from)
+	assert.Equal(t, []int64{2, 3}, ran)
+	v, err := db.Get([]byte("data"))
+	require.Nil(t, err)
+	assert.Equal(t, "023", string(v))
+	assert.Nil(t, s.Open(db))
+
+	// Nothing to migrate at the version of the schema.
+	from, err = s.Migrate(db, func(m *Migration) {
+		t.Errorf("unexpected migration to %v", m.Version)
+	})
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), from)
+}
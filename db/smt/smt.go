@@ -0,0 +1,446 @@
+// Package smt implements the sparse merkle tree committing the key-value pairs of a table.
+//
+// A key is placed on the path of its sha3-256 hash. The tree is compact: a subtree holding a single
+// leaf is the leaf itself, placed at the least depth where it's alone, so a key is proved by the hashes
+// of the siblings on the path from the root to its leaf. The nodes are kept in a Store by their positions.
+package smt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// HashSize is the size of the hashes of the tree.
+const HashSize = 32
+
+const (
+	leafPrefix     byte = 0
+	internalPrefix byte = 1
+)
+
+var emptyHash = make([]byte, HashSize)
+
+// errors of the tree
+var (
+	ErrInvalidNode   = errors.New("invalid node of the state tree")
+	ErrRootMismatch  = errors.New("root of the proof mismatch")
+	ErrInvalidProof  = errors.New("invalid proof")
+	ErrKeyInProof    = errors.New("the key is in the tree")
+	ErrKeyNotInProof = errors.New("the key isn't in the tree")
+)
+
+// Store is the storage of the nodes, Get returns an empty string if the key isn't found.
+type Store interface {
+	Get(key string) (string, error)
+	Put(key string, value string) error
+	Del(key string) error
+}
+
+// Tree is the sparse merkle tree on a Store.
+type Tree struct {
+	store Store
+}
+
+// New returns the tree on the store.
+func New(store Store) *Tree {
+	return &Tree{store: store}
+}
+
+// Proof proves whether a key is in the tree.
+type Proof struct {
+	// Siblings are the hashes of the siblings on the path from the root to the end of the proof.
+	Siblings [][]byte
+	// LeafPath and LeafValue are the path and the value hash of the leaf at the end of the proof
+	// if it's of another key, which proves the key isn't in the tree. They are nil otherwise.
+	LeafPath  []byte
+	LeafValue []byte
+}
+
+func hash(data ...[]byte) []byte {
+	h := sha3.New256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// Path returns the path of the key in the tree.
+func Path(key []byte) []byte {
+	return hash(key)
+}
+
+// ValueHash returns the hash of the value committed in the leaf.
+func ValueHash(value []byte) []byte {
+	return hash(value)
+}
+
+func leafHash(path, valueHash []byte) []byte {
+	return hash([]byte{leafPrefix}, path, valueHash)
+}
+
+func internalHash(left, right []byte) []byte {
+	return hash([]byte{internalPrefix}, left, right)
+}
+
+// bit returns the bit of the path at the depth, which tells the child to go down.
+func bit(path []byte, depth int) int {
+	return int(path[depth/8]>>(7-uint(depth%8))) & 1
+}
+
+// node is a leaf if path isn't nil, or an internal node with the hashes of its children.
+type node struct {
+	path  []byte
+	value []byte
+	left  []byte
+	right []byte
+}
+
+func (n *node) isLeaf() bool {
+	return n.path != nil
+}
+
+func (n *node) hash() []byte {
+	if n.isLeaf() {
+		return leafHash(n.path, n.value)
+	}
+	return internalHash(n.left, n.right)
+}
+
+func (n *node) child(b int) []byte {
+	if b == 0 {
+		return n.left
+	}
+	return n.right
+}
+
+func (n *node) setChild(b int, h []byte) {
+	if b == 0 {
+		n.left = h
+	} else {
+		n.right = h
+	}
+}
+
+func (n *node) encode() string {
+	if n.isLeaf() {
+		return string(leafPrefix) + string(n.path) + string(n.value)
+	}
+	return string(internalPrefix) + string(n.left) + string(n.right)
+}
+
+func decodeNode(s string) (*node, error) {
+	if len(s) != 1+2*HashSize {
+		return nil, ErrInvalidNode
+	}
+	a, b := []byte(s[1:1+HashSize]), []byte(s[1+HashSize:])
+	switch s[0] {
+	case leafPrefix:
+		return &node{path: a, value: b}, nil
+	case internalPrefix:
+		return &node{left: a, right: b}, nil
+	default:
+		return nil, ErrInvalidNode
+	}
+}
+
+func hashOf(n *node) []byte {
+	if n == nil {
+		return emptyHash
+	}
+	return n.hash()
+}
+
+// nodeKey returns the key of the position at the depth on the path, the depth and the bits of the path before it.
+func nodeKey(depth int, path []byte) string {
+	prefix := make([]byte, 2+(depth+7)/8)
+	binary.BigEndian.PutUint16(prefix, uint16(depth))
+	copy(prefix[2:], path)
+	if depth%8 != 0 {
+		prefix[len(prefix)-1] &= 0xff << (8 - uint(depth%8))
+	}
+	return hex.EncodeToString(prefix)
+}
+
+func (t *Tree) get(depth int, path []byte) (*node, error) {
+	s, err := t.store.Get(nodeKey(depth, path))
+	if err != nil || s == "" {
+		return nil, err
+	}
+	return decodeNode(s)
+}
+
+func (t *Tree) put(depth int, path []byte, n *node) error {
+	return t.store.Put(nodeKey(depth, path), n.encode())
+}
+
+func (t *Tree) del(depth int, path []byte) error {
+	return t.store.Del(nodeKey(depth, path))
+}
+
+// Root returns the root hash of the tree, all zeros if it's empty.
+func (t *Tree) Root() ([]byte, error) {
+	n, err := t.get(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, hashOf(n)...), nil
+}
+
+// Put puts the key-value pair in the tree.
+func (t *Tree) Put(key, value []byte) error {
+	_, err := t.update(0, Path(key), ValueHash(value))
+	return err
+}
+
+// Delete deletes the key from the tree.
+func (t *Tree) Delete(key []byte) error {
+	_, err := t.update(0, Path(key), nil)
+	return err
+}
+
+// update puts the leaf of the path into the subtree at the depth, or deletes it if valueHash is nil.
+// It returns the new root of the subtree, nil if it's empty.
+func (t *Tree) update(depth int, path, valueHash []byte) (*node, error) {
+	n, err := t.get(depth, path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case n == nil:
+		if valueHash == nil {
+			return nil, nil
+		}
+		leaf := &node{path: path, value: valueHash}
+		return leaf, t.put(depth, path, leaf)
+	case n.isLeaf() && bytes.Equal(n.path, path):
+		if valueHash == nil {
+			return nil, t.del(depth, path)
+		}
+		leaf := &node{path: path, value: valueHash}
+		return leaf, t.put(depth, path, leaf)
+	case n.isLeaf():
+		if valueHash == nil {
+			return n, nil
+		}
+		return t.split(depth, n, &node{path: path, value: valueHash})
+	}
+
+	b := bit(path, depth)
+	child, err := t.update(depth+1, path, valueHash)
+	if err != nil {
+		return nil, err
+	}
+	sibling := n.child(1 - b)
+	// The subtree of a single leaf becomes the leaf.
+	var leaf *node
+	if child == nil && !bytes.Equal(sibling, emptyHash) {
+		siblingPath := append([]byte{}, path...)
+		siblingPath[depth/8] ^= 1 << (7 - uint(depth%8))
+		s, err := t.get(depth+1, siblingPath)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			return nil, ErrInvalidNode
+		}
+		if s.isLeaf() {
+			leaf = s
+		}
+	} else if child != nil && child.isLeaf() && bytes.Equal(sibling, emptyHash) {
+		leaf = child
+	} else if child == nil {
+		return nil, t.del(depth, path)
+	}
+	if leaf != nil {
+		if err := t.del(depth+1, leaf.path); err != nil {
+			return nil, err
+		}
+		return leaf, t.put(depth, leaf.path, leaf)
+	}
+	n.setChild(b, hashOf(child))
+	return n, t.put(depth, path, n)
+}
+
+// split replaces the leaf a at the depth with the internal nodes holding a and b down to where their paths diverge.
+func (t *Tree) split(depth int, a, b *node) (*node, error) {
+	n := &node{left: emptyHash, right: emptyHash}
+	ba, bb := bit(a.path, depth), bit(b.path, depth)
+	if ba != bb {
+		for _, leaf := range []*node{a, b} {
+			if err := t.put(depth+1, leaf.path, leaf); err != nil {
+				return nil, err
+			}
+		}
+		n.setChild(ba, a.hash())
+		n.setChild(bb, b.hash())
+	} else {
+		c, err := t.split(depth+1, a, b)
+		if err != nil {
+			return nil, err
+		}
+		n.setChild(ba, c.hash())
+	}
+	return n, t.put(depth, a.path, n)
+}
+
+// Build builds the tree of the key-value pairs returned by next until it returns false. The tree must be empty.
+func (t *Tree) Build(next func() (key, value []byte, ok bool)) error {
+	leaves := make([]*node, 0)
+	for {
+		key, value, ok := next()
+		if !ok {
+			break
+		}
+		leaves = append(leaves, &node{path: Path(key), value: ValueHash(value)})
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].path, leaves[j].path) < 0
+	})
+	_, err := t.build(0, leaves)
+	return err
+}
+
+// Builder computes the root of the tree of the key-value pairs added, without storing the nodes of the tree.
+// Only the hashes of the values are kept.
+type Builder struct {
+	leaves []*node
+}
+
+// Add adds the key-value pair, the keys must be unique.
+func (b *Builder) Add(key, value []byte) {
+	b.leaves = append(b.leaves, &node{path: Path(key), value: ValueHash(value)})
+}
+
+// Root returns the root hash of the tree of the pairs added, all zeros if it's empty.
+func (b *Builder) Root() ([]byte, error) {
+	sort.Slice(b.leaves, func(i, j int) bool {
+		return bytes.Compare(b.leaves[i].path, b.leaves[j].path) < 0
+	})
+	n, err := New(nopStore{}).build(0, b.leaves)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, hashOf(n)...), nil
+}
+
+// nopStore drops the nodes of the tree.
+type nopStore struct{}
+
+func (nopStore) Get(key string) (string, error)     { return "", nil }
+func (nopStore) Put(key string, value string) error { return nil }
+func (nopStore) Del(key string) error               { return nil }
+
+// build builds the subtree at the depth of the leaves sorted by their paths, and returns its root.
+func (t *Tree) build(depth int, leaves []*node) (*node, error) {
+	switch len(leaves) {
+	case 0:
+		return nil, nil
+	case 1:
+		return leaves[0], t.put(depth, leaves[0].path, leaves[0])
+	}
+	i := sort.Search(len(leaves), func(i int) bool {
+		return bit(leaves[i].path, depth) == 1
+	})
+	left, err := t.build(depth+1, leaves[:i])
+	if err != nil {
+		return nil, err
+	}
+	right, err := t.build(depth+1, leaves[i:])
+	if err != nil {
+		return nil, err
+	}
+	n := &node{left: hashOf(left), right: hashOf(right)}
+	return n, t.put(depth, leaves[0].path, n)
+}
+
+// Prove returns the proof of whether the key is in the tree.
+func (t *Tree) Prove(key []byte) (*Proof, error) {
+	path := Path(key)
+	proof := &Proof{Siblings: make([][]byte, 0)}
+	for depth := 0; ; depth++ {
+		n, err := t.get(depth, path)
+		if err != nil {
+			return nil, err
+		}
+		if n == nil {
+			return proof, nil
+		}
+		if n.isLeaf() {
+			if !bytes.Equal(n.path, path) {
+				proof.LeafPath, proof.LeafValue = n.path, n.value
+			}
+			return proof, nil
+		}
+		proof.Siblings = append(proof.Siblings, n.child(1-bit(path, depth)))
+	}
+}
+
+// rootOf returns the root computed from the hash at the end of the path of the proof.
+func (p *Proof) rootOf(path, h []byte) ([]byte, error) {
+	if len(p.Siblings) > 8*HashSize {
+		return nil, ErrInvalidProof
+	}
+	for depth := len(p.Siblings) - 1; depth >= 0; depth-- {
+		sibling := p.Siblings[depth]
+		if len(sibling) != HashSize {
+			return nil, ErrInvalidProof
+		}
+		if bit(path, depth) == 0 {
+			h = internalHash(h, sibling)
+		} else {
+			h = internalHash(sibling, h)
+		}
+	}
+	return h, nil
+}
+
+func checkRoot(root, computed []byte) error {
+	if !bytes.Equal(root, computed) {
+		return fmt.Errorf("%v: %x, expected %x", ErrRootMismatch, computed, root)
+	}
+	return nil
+}
+
+// VerifyMembership checks that the key-value pair is in the tree of the root by the proof.
+func VerifyMembership(root, key, value []byte, proof *Proof) error {
+	if proof.LeafPath != nil {
+		return ErrKeyNotInProof
+	}
+	path := Path(key)
+	computed, err := proof.rootOf(path, leafHash(path, ValueHash(value)))
+	if err != nil {
+		return err
+	}
+	return checkRoot(root, computed)
+}
+
+// VerifyNonMembership checks that the key isn't in the tree of the root by the proof.
+func VerifyNonMembership(root, key []byte, proof *Proof) error {
+	path := Path(key)
+	h := emptyHash
+	if proof.LeafPath != nil {
+		if len(proof.LeafPath) != HashSize || len(proof.LeafValue) != HashSize {
+			return ErrInvalidProof
+		}
+		if bytes.Equal(proof.LeafPath, path) {
+			return ErrKeyInProof
+		}
+		for depth := 0; depth < len(proof.Siblings) && depth < 8*HashSize; depth++ {
+			if bit(proof.LeafPath, depth) != bit(path, depth) {
+				return ErrInvalidProof
+			}
+		}
+		h = leafHash(proof.LeafPath, proof.LeafValue)
+	}
+	computed, err := proof.rootOf(path, h)
+	if err != nil {
+		return err
+	}
+	return checkRoot(root, computed)
+}
@@ -0,0 +1,121 @@
+package smt
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapStore map[string]string
+
+func (s mapStore) Get(key string) (string, error) {
+	return s[key], nil
+}
+
+func (s mapStore) Put(key string, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s mapStore) Del(key string) error {
+	delete(s, key)
+	return nil
+}
+
+func build(t *testing.T, pairs map[string]string) (*Tree, mapStore) {
+	store := mapStore{}
+	tree := New(store)
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	i := 0
+	require.Nil(t, tree.Build(func() ([]byte, []byte, bool) {
+		if i == len(keys) {
+			return nil, nil, false
+		}
+		i++
+		return []byte(keys[i-1]), []byte(pairs[keys[i-1]]), true
+	}))
+	return tree, store
+}
+
+func TestTree(t *testing.T) {
+	store := mapStore{}
+	tree := New(store)
+	root, err := tree.Root()
+	require.Nil(t, err)
+	assert.Equal(t, make([]byte, HashSize), root)
+
+	r := rand.New(rand.NewSource(1))
+	pairs := make(map[string]string)
+	for i := 0; i < 2000; i++ {
+		k := fmt.Sprintf("key%d", r.Intn(500))
+		if r.Intn(4) == 0 {
+			require.Nil(t, tree.Delete([]byte(k)))
+			delete(pairs, k)
+		} else {
+			v := fmt.Sprintf("value%d", i)
+			require.Nil(t, tree.Put([]byte(k), []byte(v)))
+			pairs[k] = v
+		}
+	}
+	root, err = tree.Root()
+	require.Nil(t, err)
+
+	// The tree is the same however it's built.
+	built, builtStore := build(t, pairs)
+	builtRoot, err := built.Root()
+	require.Nil(t, err)
+	assert.Equal(t, root, builtRoot)
+	assert.Equal(t, builtStore, store)
+	var b Builder
+	for k, v := range pairs {
+		b.Add([]byte(k), []byte(v))
+	}
+	builderRoot, err := b.Root()
+	require.Nil(t, err)
+	assert.Equal(t, root, builderRoot)
+
+	for i := 0; i < 500; i++ {
+		k := fmt.Sprintf("key%d", i)
+		proof, err := tree.Prove([]byte(k))
+		require.Nil(t, err)
+		if v, ok := pairs[k]; ok {
+			assert.Nil(t, VerifyMembership(root, []byte(k), []byte(v), proof))
+			assert.NotNil(t, VerifyMembership(root, []byte(k), []byte(v+"x"), proof))
+			assert.NotNil(t, VerifyNonMembership(root, []byte(k), proof))
+		} else {
+			assert.Nil(t, VerifyNonMembership(root, []byte(k), proof))
+			assert.NotNil(t, VerifyMembership(root, []byte(k), []byte("x"), proof))
+		}
+	}
+
+	// Deleting all keys empties the tree.
+	for k := range pairs {
+		require.Nil(t, tree.Delete([]byte(k)))
+	}
+	root, err = tree.Root()
+	require.Nil(t, err)
+	assert.Equal(t, make([]byte, HashSize), root)
+	assert.Empty(t, store)
+}
+
+func TestProofOfSingleKey(t *testing.T) {
+	tree, _ := build(t, map[string]string{"a": "1"})
+	root, err := tree.Root()
+	require.Nil(t, err)
+	proof, err := tree.Prove([]byte("a"))
+	require.Nil(t, err)
+	assert.Empty(t, proof.Siblings)
+	assert.Nil(t, VerifyMembership(root, []byte("a"), []byte("1"), proof))
+
+	proof, err = tree.Prove([]byte("b"))
+	require.Nil(t, err)
+	assert.Nil(t, VerifyNonMembership(root, []byte("b"), proof))
+	proof.LeafValue = ValueHash([]byte("2"))
+	assert.NotNil(t, VerifyNonMembership(root, []byte("b"), proof))
+}
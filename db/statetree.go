@@ -0,0 +1,445 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/smt"
+)
+
+// treeKey is the storage key of the state of the tree prepared in the storage, "state:table".
+var treeKey = []byte(string(SEPARATOR) + "tree")
+
+// The states of the prepared tree in the storage. The nodes are being written while it's installing, and they
+// stay at the state of a flushed tag while it's frozen. The nodes of both are dropped when mvccdb is opened.
+const (
+	treeInstalling = "installing"
+	treePrepared   = "prepared"
+	treeFrozen     = "frozen"
+)
+
+const treeBatchKeys = 10000
+
+// treeStore is the store of the nodes of the state tree in a table of mvccdb.
+type treeStore struct {
+	m     *CacheMVCCDB
+	table string
+}
+
+func (s *treeStore) Get(key string) (string, error) {
+	return s.m.Get(s.table, key)
+}
+
+func (s *treeStore) Put(key string, value string) error {
+	return s.m.Put(s.table, key, value)
+}
+
+func (s *treeStore) Del(key string) error {
+	return s.m.Del(s.table, key)
+}
+
+// stateTree returns the state tree of the table, whose nodes are in the table with the suffix "_tree".
+func (m *CacheMVCCDB) stateTree(table string) *smt.Tree {
+	return smt.New(&treeStore{m: m, table: treeTable(table)})
+}
+
+func treeTable(table string) string {
+	return table + "_tree"
+}
+
+// overlayStore is the tree store keeping the writes in memory over the nodes in the storage, the writes are
+// put in the storage at once after the tree is updated.
+type overlayStore struct {
+	storage *kv.Storage
+	prefix  string
+	writes  map[string]*string
+}
+
+func newOverlayStore(storage *kv.Storage, table string) *overlayStore {
+	return &overlayStore{
+		storage: storage,
+		prefix:  treeTable(table) + string(SEPARATOR),
+		writes:  make(map[string]*string),
+	}
+}
+
+func (s *overlayStore) Get(key string) (string, error) {
+	if v, ok := s.writes[key]; ok {
+		if v == nil {
+			return "", nil
+		}
+		return *v, nil
+	}
+	v, err := s.storage.Get([]byte(s.prefix + key))
+	return string(v), err
+}
+
+func (s *overlayStore) Put(key string, value string) error {
+	s.writes[key] = &value
+	return nil
+}
+
+func (s *overlayStore) Del(key string) error {
+	s.writes[key] = nil
+	return nil
+}
+
+// write puts the writes in the storage.
+func (s *overlayStore) write() error {
+	for k, v := range s.writes {
+		var err error
+		if v == nil {
+			err = s.storage.Delete([]byte(s.prefix + k))
+		} else {
+			err = s.storage.Put([]byte(s.prefix+k), []byte(*v))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapStore is the tree store in memory.
+type mapStore map[string]string
+
+func (s mapStore) Get(key string) (string, error) {
+	return s[key], nil
+}
+
+func (s mapStore) Put(key string, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s mapStore) Del(key string) error {
+	delete(s, key)
+	return nil
+}
+
+// applyWrites updates the tree with the writes keyed by the keys of the table, in the order of the keys.
+func applyWrites(tree *smt.Tree, writes map[string]*Item) error {
+	keys := make([]string, 0, len(writes))
+	for k := range writes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var err error
+		if item := writes[k]; item.deleted {
+			err = tree.Delete([]byte(k))
+		} else {
+			err = tree.Put([]byte(k), []byte(item.value))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preparedTree is the state tree of a table built from the flushed state before the first block committing
+// the state root, so that the block doesn't build it from the whole state.
+//
+// It's built in memory from a snapshot while the flushes keep their writes to the table in the backlog, then
+// the backlog is applied and the nodes are installed in the storage. From then on the flushes update the nodes
+// with their writes until the first block committing the root is executed. The tree is frozen then: the block
+// writes the difference from the frozen nodes in its stage, the same as the other blocks of the height on the
+// forks, and the flushes keep their writes in the backlog again until the block is flushed.
+type preparedTree struct {
+	table    string
+	building bool
+	frozen   bool
+	backlog  []map[string]*Item
+}
+
+// treePreparer holds the prepared tree for all forks of mvccdb, it's guarded by the flush mutex.
+type treePreparer struct {
+	tree *preparedTree
+}
+
+// loadPreparedTree loads the prepared tree in the storage, the nodes which aren't at the flushed state are dropped.
+func (m *CacheMVCCDB) loadPreparedTree() error {
+	v, err := m.storage.Get(treeKey)
+	if err != nil || len(v) == 0 {
+		return err
+	}
+	state := strings.SplitN(string(v), ":", 2)
+	if len(state) != 2 {
+		return fmt.Errorf("invalid prepared tree state %q", v)
+	}
+	if state[0] == treePrepared {
+		m.prepared.tree = &preparedTree{table: state[1]}
+		return nil
+	}
+	return m.dropTree(state[1])
+}
+
+// dropTree deletes the nodes of the tree of the table in the storage and its state.
+func (m *CacheMVCCDB) dropTree(table string) error {
+	for {
+		keys, err := m.storage.Keys([]byte(treeTable(table) + string(SEPARATOR)))
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return m.storage.Delete(treeKey)
+		}
+		if len(keys) > treeBatchKeys {
+			keys = keys[:treeBatchKeys]
+		}
+		if err := m.storage.BeginBatch(); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := m.storage.Delete(k); err != nil {
+				return err
+			}
+		}
+		if err := m.storage.CommitBatch(); err != nil {
+			return err
+		}
+	}
+}
+
+// PrepareStateTree builds the state tree of the table from the flushed state for the first block committing
+// the state root, see preparedTree. The flushes aren't held back while it's built. It does nothing if the tree
+// is prepared or committed already.
+func (m *CacheMVCCDB) PrepareStateTree(table string) error {
+	if !m.isValidTable(table) {
+		return ErrTableNotValid
+	}
+	p, snap, err := m.startPreparing(table)
+	if p == nil || err != nil {
+		return err
+	}
+	return m.buildTree(p, snap)
+}
+
+// buildTree builds the prepared tree from the snapshot of the storage and installs it, the snapshot is released.
+func (m *CacheMVCCDB) buildTree(p *preparedTree, snap *kv.Snapshot) error {
+	nodes := make(mapStore)
+	tree := smt.New(nodes)
+	prefix := []byte(p.table + string(SEPARATOR))
+	iter := snap.NewIteratorByPrefix(prefix)
+	err := tree.Build(func() ([]byte, []byte, bool) {
+		if !iter.Next() {
+			return nil, nil, false
+		}
+		return iter.Key()[len(prefix):], iter.Value(), true
+	})
+	if err == nil {
+		err = iter.Error()
+	}
+	iter.Release()
+	snap.Release()
+	if err != nil {
+		m.flushmu.Lock()
+		if m.prepared.tree == p {
+			m.prepared.tree = nil
+		}
+		m.flushmu.Unlock()
+		return err
+	}
+	return m.installTree(p, tree, nodes)
+}
+
+func (m *CacheMVCCDB) startPreparing(table string) (*preparedTree, *kv.Snapshot, error) {
+	m.flushmu.Lock()
+	defer m.flushmu.Unlock()
+	if m.prepared.tree != nil {
+		return nil, nil, nil
+	}
+	iter := m.storage.NewIteratorByPrefix([]byte(treeTable(table) + string(SEPARATOR)))
+	committed := iter.Next()
+	iter.Release()
+	if committed {
+		return nil, nil, nil
+	}
+	snap, err := m.storage.NewSnapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &preparedTree{table: table, building: true}
+	m.prepared.tree = p
+	return p, snap, nil
+}
+
+// installTree applies the backlog to the tree built and writes its nodes in the storage.
+func (m *CacheMVCCDB) installTree(p *preparedTree, tree *smt.Tree, nodes mapStore) error {
+	m.flushmu.Lock()
+	defer m.flushmu.Unlock()
+	if m.prepared.tree != p {
+		return fmt.Errorf("the state tree of %v is built too late", p.table)
+	}
+	for _, writes := range p.backlog {
+		if err := applyWrites(tree, writes); err != nil {
+			m.prepared.tree = nil
+			return err
+		}
+	}
+	err := m.writeTree(p.table, nodes)
+	if err != nil {
+		m.prepared.tree = nil
+		return err
+	}
+	p.building = false
+	p.backlog = nil
+	return nil
+}
+
+func (m *CacheMVCCDB) writeTree(table string, nodes mapStore) error {
+	err := m.storage.Put(treeKey, []byte(treeInstalling+":"+table))
+	if err != nil {
+		return err
+	}
+	prefix := treeTable(table) + string(SEPARATOR)
+	if err := m.storage.BeginBatch(); err != nil {
+		return err
+	}
+	n := 0
+	for k, v := range nodes {
+		if err := m.storage.Put([]byte(prefix+k), []byte(v)); err != nil {
+			return err
+		}
+		n++
+		if n%treeBatchKeys == 0 {
+			if err := m.storage.CommitBatch(); err != nil {
+				return err
+			}
+			if err := m.storage.BeginBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := m.storage.Put(treeKey, []byte(treePrepared+":"+table)); err != nil {
+		return err
+	}
+	return m.storage.CommitBatch()
+}
+
+// flushPreparedTree updates the prepared tree with the writes of the commit in the batch of the flush, it's
+// called with the flush mutex held. The tree is dropped once the commit writes the tree in the storage.
+func (m *CacheMVCCDB) flushPreparedTree(writes map[string]*Item, committed bool) error {
+	p := m.prepared.tree
+	if p == nil {
+		return nil
+	}
+	if committed {
+		m.prepared.tree = nil
+		if p.building {
+			return nil
+		}
+		return m.storage.Delete(treeKey)
+	}
+	if p.building || p.frozen {
+		p.backlog = append(p.backlog, writes)
+		return nil
+	}
+	store := newOverlayStore(m.storage, p.table)
+	if err := applyWrites(smt.New(store), writes); err != nil {
+		return err
+	}
+	return store.write()
+}
+
+// usePreparedTree returns the root of the prepared tree of the table updated with the writes of the stage,
+// and whether the tree is prepared. The tree is frozen by the first call.
+func (m *CacheMVCCDB) usePreparedTree(table string) ([]byte, bool, error) {
+	m.flushmu.Lock()
+	defer m.flushmu.Unlock()
+	p := m.prepared.tree
+	if p == nil || p.table != table {
+		return nil, false, nil
+	}
+	if p.building {
+		// It isn't built in time, the tree is built from the whole state by the block.
+		m.prepared.tree = nil
+		return nil, false, nil
+	}
+	if !p.frozen {
+		if err := m.storage.Put(treeKey, []byte(treeFrozen+":"+table)); err != nil {
+			return nil, false, err
+		}
+		p.frozen = true
+	}
+	writes := make(map[string]*Item)
+	for _, w := range p.backlog {
+		for k, item := range w {
+			writes[k] = item
+		}
+	}
+	for _, v := range m.stage.All([]byte(table + string(SEPARATOR))) {
+		item, ok := v.(*Item)
+		if !ok {
+			return nil, false, fmt.Errorf("can't assert Item type")
+		}
+		if item.table == table {
+			writes[item.key] = item
+		}
+	}
+	tree := m.stateTree(table)
+	if err := applyWrites(tree, writes); err != nil {
+		return nil, false, err
+	}
+	root, err := tree.Root()
+	return root, true, err
+}
+
+// UpdateStateTree updates the state tree of the table with the writes of the stage, and returns its root.
+// If rebuild is true, the tree is the one of the whole table instead, it's the prepared tree updated with the
+// writes since it's flushed if it's prepared, or it's built from all keys of the table, the tree must be empty then.
+func (m *CacheMVCCDB) UpdateStateTree(table string, rebuild bool) ([]byte, error) {
+	if !m.isValidTable(table) {
+		return nil, ErrTableNotValid
+	}
+	tree := m.stateTree(table)
+	if rebuild {
+		if root, ok, err := m.usePreparedTree(table); ok || err != nil {
+			return root, err
+		}
+		iter, err := m.NewIterator(table, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer iter.Release()
+		err = tree.Build(func() ([]byte, []byte, bool) {
+			if !iter.Next() {
+				return nil, nil, false
+			}
+			return []byte(iter.Key()), []byte(iter.Value()), true
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+		return tree.Root()
+	}
+
+	writes := make(map[string]*Item)
+	for _, v := range m.stage.All([]byte(table + string(SEPARATOR))) {
+		item, ok := v.(*Item)
+		if !ok {
+			return nil, fmt.Errorf("can't assert Item type")
+		}
+		if item.stage == m.stageID && item.table == table {
+			writes[item.key] = item
+		}
+	}
+	if err := applyWrites(tree, writes); err != nil {
+		return nil, err
+	}
+	return tree.Root()
+}
+
+// StateProof returns the proof of whether the key is in the state tree of the table.
+func (m *CacheMVCCDB) StateProof(table string, key string) (*smt.Proof, error) {
+	if !m.isValidTable(table) {
+		return nil, ErrTableNotValid
+	}
+	return m.stateTree(table).Prove([]byte(key))
+}
@@ -0,0 +1,237 @@
+package db
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/db/smt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateTree(t *testing.T) {
+	for _, cacheType := range []mvcc.CacheType{mvcc.TrieCache, mvcc.MapCache, mvcc.BTreeCache} {
+		m, err := NewCacheMVCCDB(DBPATH, cacheType, kv.LevelDBStorage)
+		require.Nil(t, err)
+
+		m.Put("state", "a", "1")
+		m.Put("state", "b", "2")
+		m.Put("other", "x", "0")
+		root0, err := m.UpdateStateTree("state", true)
+		require.Nil(t, err)
+		m.Commit("tag0")
+		require.Nil(t, m.Flush("tag0"))
+
+		m.Put("state", "a", "11")
+		m.Del("state", "b")
+		m.Put("state", "c", "3")
+		root1, err := m.UpdateStateTree("state", false)
+		require.Nil(t, err)
+		m.Commit("tag1")
+		assert.NotEqual(t, root0, root1)
+
+		// The tree updated with the writes of the commits is the one built from the whole table.
+		f := m.Fork()
+		f.Checkout("tag1")
+		f.Put("state", "d", "4")
+		root2, err := f.UpdateStateTree("state", false)
+		require.Nil(t, err)
+
+		other, err := NewCacheMVCCDB(DBPATH+"other", cacheType, kv.LevelDBStorage)
+		require.Nil(t, err)
+		other.Put("state", "a", "11")
+		other.Put("state", "c", "3")
+		other.Put("state", "d", "4")
+		built, err := other.UpdateStateTree("state", true)
+		require.Nil(t, err)
+		assert.Equal(t, built, root2)
+		other.Close()
+		os.RemoveAll(DBPATH + "other")
+
+		// The proofs are of the state of the checked out tag.
+		m.Checkout("tag1")
+		proof, err := m.StateProof("state", "a")
+		require.Nil(t, err)
+		assert.Nil(t, smt.VerifyMembership(root1, []byte("a"), []byte("11"), proof))
+		proof, err = m.StateProof("state", "b")
+		require.Nil(t, err)
+		assert.Nil(t, smt.VerifyNonMembership(root1, []byte("b"), proof))
+		proof, err = m.StateProof("state", "d")
+		require.Nil(t, err)
+		assert.Nil(t, smt.VerifyNonMembership(root1, []byte("d"), proof))
+
+		m.Close()
+		os.RemoveAll(DBPATH)
+	}
+}
+
+// builtRoot returns the root of the tree built from the key-value pairs.
+func builtRoot(t *testing.T, kvs map[string]string) []byte {
+	root, err := smt.New(builtNodes(t, kvs)).Root()
+	require.Nil(t, err)
+	return root
+}
+
+// builtNodes returns the nodes of the tree built from the key-value pairs.
+func builtNodes(t *testing.T, kvs map[string]string) mapStore {
+	keys := make([]string, 0, len(kvs))
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	nodes := make(mapStore)
+	i := 0
+	require.Nil(t, smt.New(nodes).Build(func() ([]byte, []byte, bool) {
+		if i == len(keys) {
+			return nil, nil, false
+		}
+		i++
+		return []byte(keys[i-1]), []byte(kvs[keys[i-1]]), true
+	}))
+	return nodes
+}
+
+// storedNodes returns the nodes of the tree of the table in the storage.
+func storedNodes(t *testing.T, m *CacheMVCCDB, table string) mapStore {
+	nodes := make(mapStore)
+	prefix := []byte(treeTable(table) + string(SEPARATOR))
+	iter := m.storage.NewIteratorByPrefix(prefix)
+	for iter.Next() {
+		nodes[string(iter.Key()[len(prefix):])] = string(iter.Value())
+	}
+	require.Nil(t, iter.Error())
+	iter.Release()
+	return nodes
+}
+
+func TestPrepareStateTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statetree")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "StateDB")
+	m, err := NewCacheMVCCDB(path, mvcc.MapCache, kv.LevelDBStorage)
+	require.Nil(t, err)
+
+	state := make(map[string]string)
+	put := func(db MVCCDB, kvs map[string]string, key, value string) {
+		require.Nil(t, db.Put("state", key, value))
+		kvs[key] = value
+	}
+	del := func(db MVCCDB, kvs map[string]string, key string) {
+		require.Nil(t, db.Del("state", key))
+		delete(kvs, key)
+	}
+	for i := 0; i < 100; i++ {
+		put(m, state, fmt.Sprintf("key%03d", i), fmt.Sprint(i))
+	}
+	m.Commit("tag0")
+	require.Nil(t, m.Flush("tag0"))
+
+	// The block flushed while the tree is built is in the backlog.
+	p, snap, err := m.startPreparing("state")
+	require.Nil(t, err)
+	require.NotNil(t, p)
+	put(m, state, "key000", "changed")
+	del(m, state, "key001")
+	m.Commit("tag1")
+	require.Nil(t, m.Flush("tag1"))
+	require.Nil(t, m.buildTree(p, snap))
+	assert.Equal(t, builtNodes(t, state), storedNodes(t, m, "state"))
+	require.Nil(t, m.PrepareStateTree("state"))
+
+	// The flushes update the tree installed, it's kept after reopening.
+	put(m, state, "key002", "changed")
+	del(m, state, "key003")
+	m.Commit("tag2")
+	require.Nil(t, m.Flush("tag2"))
+	assert.Equal(t, builtNodes(t, state), storedNodes(t, m, "state"))
+	require.Nil(t, m.Close())
+	m, err = NewCacheMVCCDB(path, mvcc.MapCache, kv.LevelDBStorage)
+	require.Nil(t, err)
+	defer m.Close()
+	require.NotNil(t, m.prepared.tree)
+
+	// The block before the first one committing the root isn't flushed when it's executed.
+	put(m, state, "key004", "changed")
+	put(m, state, "key100", "new")
+	m.Commit("tag3")
+	first := make(map[string]string)
+	for k, v := range state {
+		first[k] = v
+	}
+	put(m, first, "key005", "first")
+	del(m, first, "key006")
+	del(m, first, "key100")
+	root, err := m.UpdateStateTree("state", true)
+	require.Nil(t, err)
+	assert.Equal(t, builtRoot(t, first), root)
+	m.Commit("tag4")
+
+	// The block of the same height on the fork is executed after the block before it is flushed.
+	require.Nil(t, m.Flush("tag3"))
+	f := m.Fork()
+	require.True(t, f.Checkout("tag3"))
+	fork := make(map[string]string)
+	for k, v := range state {
+		fork[k] = v
+	}
+	put(f, fork, "key007", "fork")
+	root, err = f.UpdateStateTree("state", true)
+	require.Nil(t, err)
+	assert.Equal(t, builtRoot(t, fork), root)
+
+	// The tree is in the commits once the block is flushed, the next blocks update it with their writes.
+	require.Nil(t, m.Flush("tag4"))
+	assert.Nil(t, m.prepared.tree)
+	assert.Equal(t, builtNodes(t, first), storedNodes(t, m, "state"))
+	has, err := m.storage.Has(treeKey)
+	require.Nil(t, err)
+	assert.False(t, has)
+	put(m, first, "key008", "next")
+	root, err = m.UpdateStateTree("state", false)
+	require.Nil(t, err)
+	assert.Equal(t, builtRoot(t, first), root)
+	proof, err := m.StateProof("state", "key005")
+	require.Nil(t, err)
+	assert.Nil(t, smt.VerifyMembership(root, []byte("key005"), []byte("first"), proof))
+}
+
+func TestPrepareStateTreeFrozen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statetree")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "StateDB")
+	m, err := NewCacheMVCCDB(path, mvcc.MapCache, kv.LevelDBStorage)
+	require.Nil(t, err)
+
+	state := map[string]string{"a": "1", "b": "2"}
+	for k, v := range state {
+		require.Nil(t, m.Put("state", k, v))
+	}
+	m.Commit("tag0")
+	require.Nil(t, m.Flush("tag0"))
+	require.Nil(t, m.PrepareStateTree("state"))
+	_, err = m.UpdateStateTree("state", true)
+	require.Nil(t, err)
+
+	// The frozen tree isn't at the flushed state after restarting, it's dropped and built by the block.
+	require.Nil(t, m.Close())
+	m, err = NewCacheMVCCDB(path, mvcc.MapCache, kv.LevelDBStorage)
+	require.Nil(t, err)
+	defer m.Close()
+	assert.Nil(t, m.prepared.tree)
+	keys, err := m.storage.Keys([]byte("state_tree/"))
+	require.Nil(t, err)
+	assert.Empty(t, keys)
+	require.Nil(t, m.Put("state", "c", "3"))
+	state["c"] = "3"
+	root, err := m.UpdateStateTree("state", true)
+	require.Nil(t, err)
+	assert.Equal(t, builtRoot(t, state), root)
+}
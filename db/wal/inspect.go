@@ -0,0 +1,242 @@
+package wal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// CorruptedSuffix is appended to the names of the files moved away by Truncate, the wal ignores them.
+const CorruptedSuffix = ".corrupted"
+
+// Segment is the result of checking a segment file of the wal.
+type Segment struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	// Tail is whether it's the segment being written, the one preallocated with the .wal.tmp suffix.
+	Tail    bool `json:"tail,omitempty"`
+	Records int  `json:"records"`
+	Entries int  `json:"entries"`
+	// FirstIndex and LastIndex are the indexes of the entries in the segment.
+	FirstIndex uint64 `json:"firstIndex"`
+	LastIndex  uint64 `json:"lastIndex"`
+	// ValidBytes is the length of the intact records from the beginning of the segment.
+	ValidBytes int64 `json:"validBytes"`
+	// Err is the error of the first corrupted record, the records after it aren't checked.
+	Err string `json:"error,omitempty"`
+	// Torn is whether the corrupted record is partially written, as the last one written before a crash.
+	Torn bool `json:"torn,omitempty"`
+	// Unchecked is whether the segment follows a corrupted one, so it isn't read by the wal.
+	Unchecked bool `json:"unchecked,omitempty"`
+}
+
+// Corrupted returns whether the segment has a corrupted record.
+func (s *Segment) Corrupted() bool {
+	return s.Err != ""
+}
+
+// segmentNames returns the segments of the wal in the order of writing, the .wal ones and then the tail.
+// The other .wal.tmp files are the preallocated ones never written.
+func segmentNames(dir string) ([]string, error) {
+	names, err := filterDirWithExt(dir, "")
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]string, 0)
+	tail := ""
+	for _, name := range names {
+		if _, _, err := parseWALName(name); err == nil {
+			segments = append(segments, name)
+		} else if strings.HasSuffix(name, ".wal.tmp") && tail == "" {
+			tail = name
+		}
+	}
+	if tail != "" {
+		segments = append(segments, tail)
+	}
+	if len(segments) == 0 {
+		return nil, ErrFileNotFound
+	}
+	return segments, nil
+}
+
+// Inspect reads the segments of the wal in dir without changing them. It decodes the records, verifies
+// the crc chain across the segments, and calls fn on each entry if fn isn't nil. It stops checking at
+// the first corrupted record, which is where the wal stops recovering.
+func Inspect(dir string, fn func(segment string, e *Entry) error) ([]*Segment, error) {
+	names, err := segmentNames(dir)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]*Segment, 0, len(names))
+	prevCrc := uint64(0)
+	corrupted := false
+	for _, name := range names {
+		s := &Segment{
+			Name:      name,
+			Tail:      strings.HasSuffix(name, ".wal.tmp"),
+			Unchecked: corrupted,
+		}
+		segments = append(segments, s)
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		s.Size = info.Size()
+		if corrupted {
+			continue
+		}
+		prevCrc, err = inspectSegment(filepath.Join(dir, name), s, prevCrc, fn)
+		if err != nil {
+			return nil, err
+		}
+		corrupted = s.Corrupted()
+	}
+	return segments, nil
+}
+
+// inspectSegment checks the segment chained to the crc of the previous segment, and returns its last crc.
+// The error is returned only if the file can't be read or fn fails, the corruption is recorded in s.
+func inspectSegment(path string, s *Segment, prevCrc uint64, fn func(segment string, e *Entry) error) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	// The crc is chained across the segments, as a single decoder of all segments does.
+	d := newDecoder(f)
+	d.updateCRC(prevCrc)
+	log := &Log{}
+	for {
+		err := d.decode(log)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.Err = err.Error()
+			s.Torn = err == io.ErrUnexpectedEOF
+			break
+		}
+		switch log.Type {
+		case LogType_entryType:
+			var e Entry
+			if err := proto.Unmarshal(log.Data, &e); err != nil {
+				s.Err = fmt.Sprintf("invalid entry: %v", err)
+			} else {
+				if s.Entries == 0 {
+					s.FirstIndex = e.Index
+				}
+				s.LastIndex = e.Index
+				s.Entries++
+				if fn != nil {
+					if err := fn(s.Name, &e); err != nil {
+						return 0, err
+					}
+				}
+			}
+		case LogType_metaDataType:
+		case LogType_crcType:
+			if crc := d.lastCRC(); crc != 0 && log.Check(crc) != nil {
+				s.Err = ErrCRCMismatch.Error()
+			} else {
+				d.updateCRC(log.Checksum)
+			}
+		default:
+			s.Err = fmt.Sprintf("unexpected record type %d", log.Type)
+		}
+		if s.Corrupted() {
+			break
+		}
+		s.Records++
+		s.ValidBytes = d.getLastOffset()
+	}
+	return d.lastCRC(), nil
+}
+
+// Truncate cuts the wal in dir at its first corrupted record, so that the wal recovers the entries before it.
+// The corrupted segment is kept with CorruptedSuffix and replaced by its intact records, and the segments
+// after it are renamed with CorruptedSuffix. It returns the corrupted segment, nil if there is none.
+// The wal must not be opened when it's truncated.
+func Truncate(dir string) (*Segment, error) {
+	segments, err := Inspect(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	var corrupted *Segment
+	for _, s := range segments {
+		if s.Corrupted() {
+			corrupted = s
+			break
+		}
+	}
+	if corrupted == nil {
+		return nil, nil
+	}
+
+	for _, s := range segments {
+		if !s.Unchecked {
+			continue
+		}
+		path := filepath.Join(dir, s.Name)
+		if err := os.Rename(path, path+CorruptedSuffix); err != nil {
+			return nil, err
+		}
+	}
+	path := filepath.Join(dir, corrupted.Name)
+	if corrupted.ValidBytes == 0 {
+		return corrupted, os.Rename(path, path+CorruptedSuffix)
+	}
+	if err := copyFile(path, path+CorruptedSuffix, -1); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if corrupted.Tail {
+		// The tail keeps its preallocated size, the wal appends after the zeros are found.
+		if _, err := f.Seek(corrupted.ValidBytes, io.SeekStart); err != nil {
+			return nil, err
+		}
+		err = ZeroToEnd(f)
+	} else {
+		err = f.Truncate(corrupted.ValidBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return corrupted, f.Sync()
+}
+
+// copyFile copies the first n bytes of src to dst, the whole file if n is negative.
+func copyFile(src, dst string, n int64) error {
+	if _, err := os.Stat(dst); err == nil {
+		return errors.New("backup " + dst + " already exists")
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		_, err = io.Copy(out, in)
+	} else {
+		_, err = io.CopyN(out, in, n)
+	}
+	if err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
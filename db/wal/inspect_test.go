@@ -0,0 +1,176 @@
+package wal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInspectAndTruncate(t *testing.T) {
+	p, err := ioutil.TempDir(os.TempDir(), "waltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(p)
+
+	w, err := Create(p, []byte("somedata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Save([]Entry{{Data: []byte("Entry1")}, {Data: []byte("Entry2")}})
+	w.cut()
+	w.Save([]Entry{{Data: []byte("Entry3")}})
+	w.Close()
+
+	var data []string
+	segments, err := Inspect(p, func(segment string, e *Entry) error {
+		data = append(data, string(e.Data))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 2 || !segments[1].Tail {
+		t.Fatalf("segments = %+v, want a segment and the tail", segments)
+	}
+	if s := segments[0]; s.Corrupted() || s.Entries != 2 || s.FirstIndex != 0 || s.LastIndex != 1 {
+		t.Fatalf("segment = %+v", s)
+	}
+	if s := segments[1]; s.Corrupted() || s.Entries != 1 || s.FirstIndex != 2 {
+		t.Fatalf("tail = %+v", s)
+	}
+	if len(data) != 3 || data[2] != "Entry3" {
+		t.Fatalf("entries = %v", data)
+	}
+	if s, err := Truncate(p); s != nil || err != nil {
+		t.Fatalf("truncate an intact wal = %v, %v", s, err)
+	}
+
+	// Corrupt the second entry of the first segment.
+	path := filepath.Join(p, segments[0].Name)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := bytes.Index(b, []byte("Entry2"))
+	b[i] = 'e'
+	if err := ioutil.WriteFile(path, b, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	segments, err = Inspect(p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := segments[0]; !s.Corrupted() || s.Torn || s.Entries != 1 || s.ValidBytes == 0 {
+		t.Fatalf("corrupted segment = %+v", s)
+	}
+	if !segments[1].Unchecked {
+		t.Fatal("the tail after the corrupted segment should be unchecked")
+	}
+
+	s, err := Truncate(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || s.Name != segments[0].Name {
+		t.Fatalf("truncated = %+v", s)
+	}
+	for _, name := range []string{segments[0].Name, segments[1].Name} {
+		if _, err := os.Stat(filepath.Join(p, name+CorruptedSuffix)); err != nil {
+			t.Fatalf("backup of %v not found: %v", name, err)
+		}
+	}
+	segments, err = Inspect(p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 || segments[0].Corrupted() || segments[0].Entries != 1 {
+		t.Fatalf("segments after truncated = %+v", segments)
+	}
+
+	// The wal recovers the entries before the corruption, and appends after them.
+	w, err = Create(p, []byte("somedata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ents, err := w.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ents) != 1 || string(ents[0].Data) != "Entry1" {
+		t.Fatalf("recovered entries = %v", ents)
+	}
+	if _, err := w.SaveSingle(Entry{Data: []byte("Entry4")}); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	data = nil
+	if _, err := Inspect(p, func(segment string, e *Entry) error {
+		data = append(data, string(e.Data))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 || data[1] != "Entry4" {
+		t.Fatalf("entries after recovered = %v", data)
+	}
+}
+
+func TestPin(t *testing.T) {
+	p, err := ioutil.TempDir(os.TempDir(), "waltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(p)
+
+	w, err := Create(filepath.Join(p, "wal"), []byte("somedata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Save([]Entry{{Data: []byte("Entry1")}})
+	w.cut()
+	w.Save([]Entry{{Data: []byte("Entry2")}})
+	pin, err := w.Pin(filepath.Join(p, "pin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pin.Release()
+
+	// The entries saved and the segments removed after the pin don't change it.
+	w.Save([]Entry{{Data: []byte("Entry3")}})
+	w.cut()
+	if err := w.RemoveFiles(1); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := segmentNames(filepath.Join(p, "wal")); len(names) != 2 {
+		t.Fatalf("segments of the wal = %v, want the first one removed", names)
+	}
+	dir := filepath.Join(p, "copy")
+	if err := pin.CopyTo(dir); err != nil {
+		t.Fatal(err)
+	}
+	var data []string
+	segments, err := Inspect(dir, func(segment string, e *Entry) error {
+		data = append(data, string(e.Data))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 2 || !segments[1].Tail || segments[1].Corrupted() {
+		t.Fatalf("segments = %+v, want a segment and the tail", segments)
+	}
+	if len(data) != 2 || data[1] != "Entry2" {
+		t.Fatalf("entries = %v", data)
+	}
+	if err := pin.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(p, "pin")); !os.IsNotExist(err) {
+		t.Fatalf("pin isn't released: %v", err)
+	}
+}
@@ -95,7 +95,9 @@ func checkWalNames(dirpath string, names []string) []string {
 		}
 		wnames = append(wnames, name)
 	}
-	wnames = append(wnames, tmpWal)
+	if tmpWal != "" {
+		wnames = append(wnames, tmpWal)
+	}
 	return wnames
 }
 
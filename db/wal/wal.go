@@ -622,6 +622,83 @@ func (w *WAL) CleanDir() error {
 	return nil
 }
 
+// Pin is the segments of a WAL at the moment it's pinned. The segments are hard linked, so they're kept
+// after the WAL removes them, and the entries saved after the pin aren't in it.
+type Pin struct {
+	dir      string
+	names    []string
+	tail     string
+	tailSize int64
+}
+
+// Pin links the segments of the WAL into dir and records the size of the tail. It doesn't copy the
+// segments unless dir is on another file system, so it's cheap enough to call while the WAL is in use.
+func (w *WAL) Pin(dir string) (*Pin, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	p := &Pin{dir: dir}
+	if w.encoder != nil {
+		if err := w.encoder.flush(); err != nil {
+			return nil, err
+		}
+		off, err := w.tail().Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		p.tail = filepath.Base(w.tail().Name())
+		p.tailSize = off
+	}
+	names, err := segmentNames(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		src, dst := filepath.Join(w.dir, name), filepath.Join(dir, name)
+		if err := os.Link(src, dst); err != nil {
+			if err := copyFile(src, dst, -1); err != nil {
+				os.RemoveAll(dir)
+				return nil, err
+			}
+		}
+	}
+	p.names = names
+	return p, nil
+}
+
+// CopyTo copies the pinned segments to dir, the tail only up to its size at the pin.
+func (p *Pin) CopyTo(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range p.names {
+		dst := filepath.Join(dir, name)
+		if name != p.tail {
+			if err := copyFile(filepath.Join(p.dir, name), dst, -1); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyFile(filepath.Join(p.dir, name), dst, p.tailSize); err != nil {
+			return err
+		}
+		// The copied tail is preallocated again with zeros like the one of the WAL.
+		if p.tailSize < SegmentSizeBytes {
+			if err := os.Truncate(dst, SegmentSizeBytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Release removes the links of the pin.
+func (p *Pin) Release() error {
+	return os.RemoveAll(p.dir)
+}
+
 // Size return WAL used data size include current tmp file.
 func (w *WAL) Size() uint64 {
 	size := uint64(len(w.files)) * uint64(SegmentSizeBytes)
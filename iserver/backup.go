@@ -0,0 +1,54 @@
+package iserver
+
+import (
+	"os"
+	"sync"
+
+	"github.com/iost-official/go-iost/core/backup"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/ilog"
+)
+
+// backuper takes the hot backups of the node, only one backup runs at a time.
+type backuper struct {
+	bv       global.BaseVariable
+	blkCache blockcache.BlockCache
+	mu       sync.Mutex
+}
+
+func newBackuper(bv global.BaseVariable, blkCache blockcache.BlockCache) *backuper {
+	return &backuper{
+		bv:       bv,
+		blkCache: blkCache,
+	}
+}
+
+// backup creates the backup next to the dbs, calls fn with its directory, and removes it.
+func (b *backuper) backup(fn func(dir string, m *backup.Manifest) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	conf := b.bv.Config()
+	dir := conf.DB.LdbPath + "Backup"
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	var chainID uint32
+	if conf.P2P != nil {
+		chainID = conf.P2P.ChainID
+	}
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	m, err := backup.Create(dir, chainID, storageType, b.blkCache, b.bv.BlockChain(), b.bv.StateDB())
+	if err != nil {
+		return err
+	}
+	ilog.Infof("created backup at block %v, %v files", m.Number, len(m.Files))
+	return fn(dir, m)
+}
@@ -0,0 +1,94 @@
+package iserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/ilog"
+)
+
+// CompactResult is the result of compacting the state db online.
+type CompactResult struct {
+	Before    int64
+	After     int64
+	Reclaimed int64
+	Duration  string
+}
+
+// compactor compacts the state db online, periodically if the interval is set.
+type compactor struct {
+	stateDB  db.MVCCDB
+	path     string
+	interval time.Duration
+	mu       sync.Mutex
+	exit     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newCompactor(stateDB db.MVCCDB, path string, interval time.Duration) *compactor {
+	return &compactor{
+		stateDB:  stateDB,
+		path:     path,
+		interval: interval,
+		exit:     make(chan struct{}),
+	}
+}
+
+func (c *compactor) Start() error {
+	if c.interval <= 0 {
+		return nil
+	}
+	c.wg.Add(1)
+	go c.loop()
+	return nil
+}
+
+func (c *compactor) Stop() {
+	close(c.exit)
+	c.wg.Wait()
+}
+
+func (c *compactor) loop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			result, err := c.compact()
+			if err != nil {
+				ilog.Errorf("compact state db failed. err=%v", err)
+				continue
+			}
+			ilog.Infof("compacted state db in %v, reclaimed %v bytes", result.Duration, result.Reclaimed)
+		case <-c.exit:
+			return
+		}
+	}
+}
+
+// compact compacts the state db, only one compaction runs at a time.
+func (c *compactor) compact() (*CompactResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start := time.Now()
+	before, err := db.DiskUsage(c.path)
+	if err != nil {
+		return nil, err
+	}
+	err = c.stateDB.Compact()
+	if err != nil {
+		return nil, err
+	}
+	after, err := db.DiskUsage(c.path)
+	if err != nil {
+		return nil, err
+	}
+	return &CompactResult{
+		Before:    before,
+		After:     after,
+		Reclaimed: before - after,
+		Duration:  time.Since(start).String(),
+	}, nil
+}
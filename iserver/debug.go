@@ -3,14 +3,18 @@ package iserver
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/core/backup"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/txpool"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
 )
@@ -22,16 +26,24 @@ type DebugServer struct {
 	p2p      *p2p.NetService
 	blkCache blockcache.BlockCache
 	blkChain block.Chain
+	snaps    *snapshot.Auto
+	compact  func() (*CompactResult, error)
+	backup   func(fn func(dir string, m *backup.Manifest) error) error
+	txp      *txpool.TxPImpl
 }
 
 // NewDebugServer returns new debug server
-func NewDebugServer(conf *common.DebugConfig, p2p *p2p.NetService, blkCache blockcache.BlockCache, blkChain block.Chain) *DebugServer {
+func NewDebugServer(conf *common.DebugConfig, p2p *p2p.NetService, blkCache blockcache.BlockCache, blkChain block.Chain, snaps *snapshot.Auto, compact func() (*CompactResult, error), backup func(fn func(dir string, m *backup.Manifest) error) error, txp *txpool.TxPImpl) *DebugServer {
 	return &DebugServer{
 		srv:      &http.Server{Addr: conf.ListenAddr},
 		conf:     conf,
 		p2p:      p2p,
 		blkCache: blkCache,
 		blkChain: blkChain,
+		snaps:    snaps,
+		compact:  compact,
+		backup:   backup,
+		txp:      txp,
 	}
 }
 
@@ -40,9 +52,21 @@ func (d *DebugServer) Start() error {
 	http.HandleFunc(
 		"/debug/blockcache/",
 		func(rw http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("format") == "json" {
+				bytes, _ := json.MarshalIndent(d.blkCache.Tree(), "", "    ")
+				rw.Write(bytes)
+				return
+			}
 			rw.Write([]byte(d.blkCache.Draw()))
 		})
 
+	http.HandleFunc(
+		"/debug/reorgs/",
+		func(rw http.ResponseWriter, r *http.Request) {
+			bytes, _ := json.MarshalIndent(d.txp.ReorgEvents(), "", "    ")
+			rw.Write(bytes)
+		})
+
 	http.HandleFunc(
 		"/debug/blockchain/",
 		func(rw http.ResponseWriter, r *http.Request) {
@@ -62,11 +86,74 @@ func (d *DebugServer) Start() error {
 	http.HandleFunc(
 		"/debug/p2p/neighbors/",
 		func(rw http.ResponseWriter, r *http.Request) {
+			if d.p2p == nil {
+				rw.Write([]byte("p2p is disabled"))
+				return
+			}
 			neighbors := d.p2p.NeighborStat()
 			bytes, _ := json.MarshalIndent(neighbors, "", "    ")
 			rw.Write(bytes)
 		})
 
+	http.HandleFunc(
+		"/debug/snapshots/",
+		func(rw http.ResponseWriter, r *http.Request) {
+			if d.snaps == nil {
+				rw.Write([]byte("auto snapshot is disabled"))
+				return
+			}
+			if name := strings.TrimPrefix(r.URL.Path, "/debug/snapshots/"); name != "" {
+				path, ok := d.snaps.Path(name)
+				if !ok {
+					http.NotFound(rw, r)
+					return
+				}
+				http.ServeFile(rw, r, path)
+				return
+			}
+			bytes, _ := json.MarshalIndent(d.snaps.List(), "", "    ")
+			rw.Write(bytes)
+		})
+
+	http.HandleFunc(
+		"/debug/statedb/compact/",
+		func(rw http.ResponseWriter, r *http.Request) {
+			if !requirePost(rw, r) {
+				return
+			}
+			result, err := d.compact()
+			if err != nil {
+				rw.WriteHeader(http.StatusInternalServerError)
+				rw.Write([]byte(err.Error()))
+				return
+			}
+			bytes, _ := json.MarshalIndent(result, "", "    ")
+			rw.Write(bytes)
+		})
+
+	http.HandleFunc(
+		"/debug/backup/",
+		func(rw http.ResponseWriter, r *http.Request) {
+			if !requirePost(rw, r) {
+				return
+			}
+			streaming := false
+			err := d.backup(func(dir string, m *backup.Manifest) error {
+				streaming = true
+				rw.Header().Set("Content-Type", "application/gzip")
+				rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=backup-%v.tar.gz", m.Number))
+				return backup.Archive(rw, dir)
+			})
+			if err == nil {
+				return
+			}
+			ilog.Errorf("backup failed. err=%v", err)
+			if !streaming {
+				rw.WriteHeader(http.StatusInternalServerError)
+				rw.Write([]byte(err.Error()))
+			}
+		})
+
 	http.HandleFunc(
 		"/debug/setloglevel/",
 		func(rw http.ResponseWriter, r *http.Request) {
@@ -89,6 +176,17 @@ func (d *DebugServer) Start() error {
 	return nil
 }
 
+// requirePost replies 405 to the requests which aren't POST, it guards the handlers doing heavy work on the node
+// against the crawlers and the prefetches of the browsers.
+func requirePost(rw http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodPost {
+		return true
+	}
+	rw.Header().Set("Allow", http.MethodPost)
+	http.Error(rw, "method not allowed, use POST", http.StatusMethodNotAllowed)
+	return false
+}
+
 // Stop stops debug server
 func (d *DebugServer) Stop() {
 	ilog.Infof("Stopping debug server...")
@@ -0,0 +1,74 @@
+package iserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+)
+
+// DevAccountID is the funded account of the developer chain, it shares the key of the producer.
+const DevAccountID = "admin"
+
+// EnableDev makes conf run a single-node developer chain, whose data is kept in a temporary directory.
+func EnableDev(conf *common.Config, interval time.Duration) error {
+	if conf.ACC == nil {
+		return fmt.Errorf("acc config is required by the developer chain")
+	}
+	if conf.ACC.ID == DevAccountID {
+		return fmt.Errorf("acc id should not be %v in the developer chain", DevAccountID)
+	}
+	dir, err := ioutil.TempDir("", "iost-dev")
+	if err != nil {
+		return err
+	}
+	conf.DB = &common.DBConfig{LdbPath: dir + string(os.PathSeparator)}
+	conf.Snapshot = &common.SnapshotConfig{Enable: false}
+	conf.Dev = &common.DevConfig{
+		Enable:        true,
+		BlockInterval: interval,
+	}
+	return nil
+}
+
+func devGenesisConfig(conf *common.Config) (*common.GenesisConfig, error) {
+	acc, err := account.NewKeyPair(common.Base58Decode(conf.ACC.SecKey), crypto.NewAlgorithm(conf.ACC.Algorithm))
+	if err != nil {
+		return nil, err
+	}
+	pubkey := acc.ReadablePubkey()
+	return &common.GenesisConfig{
+		CreateGenesis:    true,
+		InitialTimestamp: time.Now().UTC().Format(time.RFC3339),
+		TokenInfo: &common.TokenInfo{
+			FoundationAccount: "foundation",
+			IOSTTotalSupply:   90000000000,
+			IOSTDecimal:       8,
+		},
+		WitnessInfo: []*common.Witness{{
+			ID:             conf.ACC.ID,
+			Owner:          pubkey,
+			Active:         pubkey,
+			SignatureBlock: pubkey,
+			Balance:        0,
+		}},
+		ContractPath: filepath.Join(conf.Genesis, "contract"),
+		AdminInfo: &common.Witness{
+			ID:      DevAccountID,
+			Owner:   pubkey,
+			Active:  pubkey,
+			Balance: 21000000000,
+		},
+		FoundationInfo: &common.Witness{
+			ID:      "foundation",
+			Owner:   pubkey,
+			Active:  pubkey,
+			Balance: 0,
+		},
+	}, nil
+}
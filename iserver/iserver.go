@@ -1,15 +1,23 @@
 package iserver
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/consensus"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/consensus/statesync"
 	"github.com/iost-official/go-iost/consensus/synchronizer"
+	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
 	"github.com/iost-official/go-iost/core/global"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/core/txpool"
 	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
 	"github.com/iost-official/go-iost/rpc"
@@ -23,19 +31,73 @@ type Service interface {
 
 // IServer is application for IOST.
 type IServer struct {
-	bv        global.BaseVariable
-	p2p       *p2p.NetService
-	sync      *synchronizer.SyncImpl
-	txp       *txpool.TxPImpl
-	rpcServer *rpc.Server
-	consensus consensus.Consensus
-	debug     *DebugServer
+	bv         global.BaseVariable
+	p2p        p2p.Service
+	p2pStarted bool
+	stateSync  *statesync.Server
+	snapshots  *snapshot.Auto
+	compactor  *compactor
+	sync       *synchronizer.SyncImpl
+	txp        *txpool.TxPImpl
+	rpcServer  *rpc.Server
+	consensus  consensus.Consensus
+	debug      *DebugServer
+}
+
+// checkStorage refuses the memory storage outside the dev chains, and over a block cache wal which
+// can't be replayed on the empty dbs.
+func checkStorage(conf *common.Config) error {
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	if storageType != kv.MemoryStorage {
+		return nil
+	}
+	if !conf.IsDev() {
+		return fmt.Errorf("the memory storage loses the chain once iserver stops, it's only for the dev chains")
+	}
+	if _, err := os.Stat(blockcache.WALDir(conf)); err == nil {
+		return fmt.Errorf("the memory storage can't replay the block cache wal %v, remove it first", blockcache.WALDir(conf))
+	}
+	return nil
 }
 
 // New returns a iserver application
 func New(conf *common.Config) *IServer {
 	tx.ChainID = conf.P2P.ChainID
+	if conf.Upgrade != nil {
+		block.StateRootNumber = conf.Upgrade.StateRootNumber
+	}
 
+	var err error
+	var netService *p2p.NetService
+	var p2pService p2p.Service
+	if conf.IsDev() {
+		p2pService = p2p.NewNopService()
+	} else {
+		netService, err = p2p.NewNetService(conf.P2P)
+		if err != nil {
+			ilog.Fatalf("network initialization failed, stop the program! err:%v", err)
+		}
+		p2pService = netService
+	}
+
+	// The state must be downloaded before the databases are opened, so the network starts first.
+	p2pStarted := false
+	if needStateSync(conf) {
+		if err := p2pService.Start(); err != nil {
+			ilog.Fatalf("start network failed, stop the program! err:%v", err)
+		}
+		p2pStarted = true
+		if err := stateSync(conf, p2pService); err != nil {
+			ilog.Fatalf("State sync failed: %v", err)
+		}
+	}
+
+	if err := checkStorage(conf); err != nil {
+		ilog.Fatalf("Check storage failed: %v", err)
+	}
 	bv, err := global.New(conf)
 	if err != nil {
 		ilog.Fatalf("create global failed. err=%v", err)
@@ -47,11 +109,6 @@ func New(conf *common.Config) *IServer {
 		ilog.Fatalf("Recover DB failed: %v", err)
 	}
 
-	p2pService, err := p2p.NewNetService(conf.P2P)
-	if err != nil {
-		ilog.Fatalf("network initialization failed, stop the program! err:%v", err)
-	}
-
 	accSecKey := conf.ACC.SecKey
 	acc, err := account.NewKeyPair(common.Base58Decode(accSecKey), crypto.NewAlgorithm(conf.ACC.Algorithm))
 	if err != nil {
@@ -63,6 +120,10 @@ func New(conf *common.Config) *IServer {
 		ilog.Fatalf("blockcache initialization failed, stop the program! err:%v", err)
 	}
 
+	if block.StateRootNumber > 0 && blkCache.LinkedRoot().Head.Number < block.StateRootNumber {
+		go prepareStateTree(bv.StateDB())
+	}
+
 	txp, err := txpool.NewTxPoolImpl(bv, blkCache, p2pService)
 	if err != nil {
 		ilog.Fatalf("txpool initialization failed, stop the program! err:%v", err)
@@ -70,37 +131,83 @@ func New(conf *common.Config) *IServer {
 
 	consensus := consensus.New(consensus.Pob, acc, bv, blkCache, txp, p2pService)
 
-	rpcServer := rpc.New(txp, blkCache, bv, p2pService)
+	rpcServer := rpc.New(txp, blkCache, bv, p2pService, consensus)
+
+	var sync *synchronizer.SyncImpl
+	var stateSyncServer *statesync.Server
+	if conf.IsDev() {
+		// There is nothing to sync from, start producing blocks at once.
+		bv.SetMode(global.ModeNormal)
+		ilog.Infof("Dev chain data path: %v", conf.DB.LdbPath)
+		ilog.Infof("Dev account: %v, its seckey is acc.seckey of the config", DevAccountID)
+	} else {
+		sync, err = synchronizer.NewSynchronizer(bv, blkCache, p2pService)
+		if err != nil {
+			ilog.Fatalf("synchronizer initialization failed, stop the program! err:%v", err)
+		}
+	}
 
-	sync, err := synchronizer.NewSynchronizer(bv, blkCache, p2pService)
-	if err != nil {
-		ilog.Fatalf("synchronizer initialization failed, stop the program! err:%v", err)
+	snapshots := snapshot.NewAuto(conf, bv.StateDB(), func() int64 {
+		return blkCache.LinkedRoot().Head.Number
+	})
+	if !conf.IsDev() {
+		storageType, err := kv.ParseStorageType(conf.DB.Storage)
+		if err != nil {
+			ilog.Fatalf("parse storage type failed, stop the program! err:%v", err)
+		}
+		cacheType, err := mvcc.ParseCacheType(conf.DB.Cache)
+		if err != nil {
+			ilog.Fatalf("parse cache type failed, stop the program! err:%v", err)
+		}
+		stateSyncServer = statesync.NewServer(func() []string {
+			return snapshotFiles(conf, snapshots)
+		}, storageType, cacheType, p2pService)
 	}
 
-	debug := NewDebugServer(conf.Debug, p2pService, blkCache, bv.BlockChain())
+	compactor := newCompactor(bv.StateDB(), conf.DB.LdbPath+"StateDB", conf.DB.CompactInterval)
+
+	backuper := newBackuper(bv, blkCache)
+
+	debug := NewDebugServer(conf.Debug, netService, blkCache, bv.BlockChain(), snapshots, compactor.compact, backuper.backup, txp)
 
 	return &IServer{
-		bv:        bv,
-		p2p:       p2pService,
-		sync:      sync,
-		txp:       txp,
-		rpcServer: rpcServer,
-		consensus: consensus,
-		debug:     debug,
+		bv:         bv,
+		p2p:        p2pService,
+		p2pStarted: p2pStarted,
+		stateSync:  stateSyncServer,
+		snapshots:  snapshots,
+		compactor:  compactor,
+		sync:       sync,
+		txp:        txp,
+		rpcServer:  rpcServer,
+		consensus:  consensus,
+		debug:      debug,
 	}
 }
 
+// services returns the resident services in the order of starting.
+func (s *IServer) services() []Service {
+	services := []Service{s.p2p}
+	if s.stateSync != nil {
+		services = append(services, s.stateSync)
+	}
+	if s.sync != nil {
+		services = append(services, s.sync)
+	}
+	services = append(services, s.txp, s.consensus, s.rpcServer)
+	if s.snapshots != nil {
+		services = append(services, s.snapshots)
+	}
+	return append(services, s.compactor)
+}
+
 // Start starts iserver application.
 func (s *IServer) Start() error {
-	Services := []Service{
-		s.p2p,
-		s.sync,
-		s.txp,
-		s.consensus,
-		s.rpcServer,
-	}
-	for _, s := range Services {
-		if err := s.Start(); err != nil {
+	for _, svc := range s.services() {
+		if svc == s.p2p && s.p2pStarted {
+			continue
+		}
+		if err := svc.Start(); err != nil {
 			return err
 		}
 	}
@@ -119,16 +226,16 @@ func (s *IServer) Stop() {
 	if conf.Debug != nil {
 		s.debug.Stop()
 	}
-	Services := []Service{
-		s.rpcServer,
-		s.consensus,
-		s.txp,
-		s.sync,
-		s.p2p,
-	}
-	for _, s := range Services {
-		s.Stop()
+	services := s.services()
+	for i := len(services) - 1; i >= 0; i-- {
+		services[i].Stop()
 	}
 	s.bv.BlockChain().Close()
 	s.bv.StateDB().Close()
+	s.bv.EvidenceDB().Close()
+	if conf.IsDev() {
+		if err := os.RemoveAll(conf.DB.LdbPath); err != nil {
+			ilog.Errorf("remove dev chain data failed. err=%v", err)
+		}
+	}
 }
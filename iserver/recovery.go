@@ -4,16 +4,23 @@ import (
 	"fmt"
 
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/checkpoint"
 	"github.com/iost-official/go-iost/consensus/genesis"
 	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/global"
 	"github.com/iost-official/go-iost/ilog"
 )
 
+// checkGenesis creates the genesis block if the chain is empty, and checks the chain against the checkpoints.
 func checkGenesis(bv global.BaseVariable) error {
 	blockChain := bv.BlockChain()
 	stateDB := bv.StateDB()
 	conf := bv.Config()
+	checkpoints, err := checkpoint.New(conf.Checkpoint)
+	if err != nil {
+		return err
+	}
 	if !conf.Snapshot.Enable && blockChain.Length() == int64(0) { //blockchaindb is empty
 		// TODO: remove the module of starting iserver from yaml.
 
@@ -23,10 +30,25 @@ func checkGenesis(bv global.BaseVariable) error {
 			return fmt.Errorf("blockchaindb is empty, but statedb is not")
 		}
 
-		blk, err := genesis.GenGenesisByFile(stateDB, conf.Genesis)
+		var blk *block.Block
+		if conf.IsDev() {
+			var gConf *common.GenesisConfig
+			gConf, err = devGenesisConfig(conf)
+			if err != nil {
+				return fmt.Errorf("new dev genesis config failed, stop the program. err: %v", err)
+			}
+			blk, err = genesis.GenGenesis(stateDB, gConf)
+		} else {
+			blk, err = genesis.GenGenesisByFile(stateDB, conf.Genesis)
+		}
 		if err != nil {
 			return fmt.Errorf("new GenGenesis failed, stop the program. err: %v", err)
 		}
+		// Check the genesis before it's saved, so a wrong genesis config can be fixed and retried.
+		err = checkpoints.Check(0, blk.HeadHash())
+		if err != nil {
+			return fmt.Errorf("genesis config doesn't match the genesis hash of the checkpoints. err: %v", err)
+		}
 		err = blockChain.Push(blk)
 		if err != nil {
 			return fmt.Errorf("push block in blockChain failed, stop the program. err: %v", err)
@@ -36,11 +58,39 @@ func checkGenesis(bv global.BaseVariable) error {
 		if err != nil {
 			return fmt.Errorf("flush block into stateDB failed, stop the program. err: %v", err)
 		}
+		digest, err := stateDB.Digest()
+		if err != nil {
+			return fmt.Errorf("get state digest failed, stop the program. err: %v", err)
+		}
+		err = blockChain.PutStateDigest(blk.HeadHash(), digest.Encode())
+		if err != nil {
+			return fmt.Errorf("save state digest failed, stop the program. err: %v", err)
+		}
 		ilog.Infof("Created Genesis.")
-
-		// TODO check genesis hash between config and db
 		ilog.Infof("GenesisHash: %v", common.Base58Encode(blk.HeadHash()))
 	}
+
+	for _, number := range checkpoints.Numbers() {
+		if number >= blockChain.Length() {
+			break
+		}
+		hash, err := blockChain.GetHashByNumber(number)
+		if err != nil {
+			// The blocks before the snapshot are not in the chain.
+			continue
+		}
+		if err := checkpoints.Check(number, hash); err != nil {
+			return err
+		}
+	}
+	if conf.Snapshot.Enable {
+		blk, err := snapshot.Load(stateDB)
+		if err == nil {
+			if err := checkpoints.Check(blk.Head.Number, blk.HeadHash()); err != nil {
+				return fmt.Errorf("snapshot: %v", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,86 @@
+package iserver
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/checkpoint"
+	"github.com/iost-official/go-iost/consensus/snapshot"
+	"github.com/iost-official/go-iost/consensus/statesync"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/db/mvcc"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/p2p"
+)
+
+// needStateSync returns whether the node should download the state from peers,
+// which is only done when state sync is enabled and the node has no data yet.
+func needStateSync(conf *common.Config) bool {
+	if conf.IsDev() || conf.StateSync == nil || !conf.StateSync.Enable {
+		return false
+	}
+	if conf.Snapshot != nil && conf.Snapshot.Enable {
+		return false
+	}
+	for _, name := range []string{"BlockChainDB", "StateDB"} {
+		if _, err := os.Stat(conf.DB.LdbPath + name); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// stateSync downloads a snapshot from peers and makes the node start from it.
+func stateSync(conf *common.Config, p2pService p2p.Service) error {
+	err := os.MkdirAll(conf.DB.LdbPath, 0755)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(conf.DB.LdbPath, snapshot.FileName)
+	tmp := path + ".download"
+	defer os.Remove(tmp)
+
+	checkpoints, err := checkpoint.New(conf.Checkpoint)
+	if err != nil {
+		return err
+	}
+	storageType, err := kv.ParseStorageType(conf.DB.Storage)
+	if err != nil {
+		return err
+	}
+	cacheType, err := mvcc.ParseCacheType(conf.DB.Cache)
+	if err != nil {
+		return err
+	}
+	info, err := statesync.NewClient(conf.StateSync.MinPeers, checkpoints, storageType, cacheType, p2pService).Fetch(tmp)
+	if err != nil {
+		return err
+	}
+	// Keep the snapshot, so the node serves it to other peers too.
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return err
+	}
+	ilog.Infof("State sync finished, start from block %v", info.Number)
+	conf.Snapshot = &common.SnapshotConfig{
+		Enable:   true,
+		FilePath: path,
+	}
+	return nil
+}
+
+// snapshotFiles returns the snapshot files served to the peers doing state sync: the snapshot in the db path, and
+// the automatic snapshots kept.
+func snapshotFiles(conf *common.Config, snapshots *snapshot.Auto) []string {
+	files := []string{filepath.Join(conf.DB.LdbPath, snapshot.FileName)}
+	if snapshots == nil {
+		return files
+	}
+	for _, m := range snapshots.List() {
+		if path, ok := snapshots.Path(m.File); ok {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,20 @@
+package iserver
+
+import (
+	"time"
+
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+// prepareStateTree builds the state tree before the first block committing the state root, so that the block
+// doesn't build it from the whole state while it's produced or verified.
+func prepareStateTree(stateDB db.MVCCDB) {
+	start := time.Now()
+	if err := stateDB.PrepareStateTree(database.StateTable); err != nil {
+		ilog.Warnf("Prepare state tree failed, the first block committing the state root builds it. err=%v", err)
+		return
+	}
+	ilog.Infof("Prepared state tree in %v", time.Since(start))
+}
@@ -0,0 +1,198 @@
+package iwallet
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/light"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/db/smt"
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var trustedHash string
+var trustedWitnesses []string
+var stateKeys []string
+
+// lightCmd verifies the block headers like a light client.
+var lightCmd = &cobra.Command{
+	Use:   "light trustedNumber [toNumber]",
+	Short: "Verify block headers as a light client",
+	Long: `Verify the witness signatures of the block headers following a trusted block, without trusting the node.
+The trusted block is checked against --hash, and its witness list is given by --witnesses or proved against its
+state root. A new witness list is taken only if it's proved against the state root of a verified block, which needs
+the node to keep the state of the block. So the trusted block and the headers must commit the state root, the V0
+blocks before the state root upgrade of the chain are refused.
+The headers are verified up to toNumber, or the head block of the node.
+The values of the state keys given by --keys are verified at the last block against its state root.`,
+	Example: `  iwallet light 1000 2000 --hash 5Y1r...`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("please enter the trusted block number")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid block number %v", args[0])
+		}
+		to := int64(-1)
+		if len(args) > 1 {
+			to, err = strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid block number %v", args[1])
+			}
+		} else {
+			info, err := sdk.getChainInfo()
+			if err != nil {
+				return err
+			}
+			to = info.HeadBlock
+		}
+
+		if trustedHash == "" {
+			return fmt.Errorf("please set --hash of the trusted block %v", from)
+		}
+		trusted, err := fetchHeader(from)
+		if err != nil {
+			return err
+		}
+		hash := common.Base58Encode(trusted.HeadHash())
+		if hash != trustedHash {
+			return fmt.Errorf("block %v is %v, not the trusted %v", from, hash, trustedHash)
+		}
+		witnesses := trustedWitnesses
+		if len(witnesses) == 0 {
+			value, proof, err := fetchStateProof(trusted, light.PendingListKey)
+			if err == nil {
+				err = light.VerifyState(trusted, light.PendingListKey, value, true, proof)
+			}
+			if err == nil {
+				witnesses, err = light.ParseWitnessList(value)
+			}
+			if err != nil {
+				return fmt.Errorf("no witness list of block %v, set --witnesses: %v", from, err)
+			}
+		}
+		client, err := light.NewClient(trusted, witnesses)
+		if err != nil {
+			return err
+		}
+
+		for num := from + 1; num <= to; num++ {
+			header, err := fetchHeader(num)
+			if err != nil {
+				return err
+			}
+			err = client.Verify(header)
+			if err == light.ErrNotScheduled {
+				err = provePending(client)
+				if err != nil {
+					return fmt.Errorf("witness list changed at block %v, and it can't be proved: %v", num, err)
+				}
+				err = client.Verify(header)
+			}
+			if err != nil {
+				return fmt.Errorf("verify block %v failed: %v", num, err)
+			}
+			if sdk.verbose {
+				fmt.Printf("block %v %v by %v verified\n", num, common.Base58Encode(header.HeadHash()), header.Head.Witness)
+			}
+		}
+		fmt.Printf("Verified headers %v to %v, head %v\n", from+1, to, common.Base58Encode(client.Head().HeadHash()))
+		for _, key := range stateKeys {
+			err = verifyState(client.Head(), key)
+			if err != nil {
+				return fmt.Errorf("verify state key %v failed: %v", key, err)
+			}
+		}
+		return nil
+	},
+}
+
+func verifyState(head *block.Block, key string) error {
+	resp, err := sdk.getStateProof(head.Head.Number, key)
+	if err != nil {
+		return err
+	}
+	proof, err := checkStateProof(head, resp)
+	if err != nil {
+		return err
+	}
+	err = light.VerifyState(head, key, resp.Value, resp.Exists, proof)
+	if err != nil {
+		return err
+	}
+	if resp.Exists {
+		fmt.Printf("state %v = %v verified\n", key, resp.Value)
+	} else {
+		fmt.Printf("state %v not exists verified\n", key)
+	}
+	return nil
+}
+
+// provePending sets the pending witness list of the client proved at its last verified header.
+func provePending(client *light.Client) error {
+	value, proof, err := fetchStateProof(client.Head(), light.PendingListKey)
+	if err != nil {
+		return err
+	}
+	return client.SetPending(value, proof)
+}
+
+// fetchStateProof returns the value of the existing key at the header, with the proof to verify.
+func fetchStateProof(head *block.Block, key string) (string, *smt.Proof, error) {
+	resp, err := sdk.getStateProof(head.Head.Number, key)
+	if err != nil {
+		return "", nil, err
+	}
+	proof, err := checkStateProof(head, resp)
+	if err != nil {
+		return "", nil, err
+	}
+	if !resp.Exists {
+		return "", nil, fmt.Errorf("state %v not exists", key)
+	}
+	return resp.Value, proof, nil
+}
+
+// checkStateProof checks that the proof is of the header, and returns it.
+func checkStateProof(head *block.Block, resp *rpcpb.StateProofResponse) (*smt.Proof, error) {
+	header := &block.Block{}
+	err := header.Decode(resp.Header)
+	if err != nil {
+		return nil, fmt.Errorf("decode header of block %v failed: %v", head.Head.Number, err)
+	}
+	if !bytes.Equal(header.HeadHash(), head.HeadHash()) {
+		return nil, fmt.Errorf("the proof is of block %v, not the verified %v", common.Base58Encode(header.HeadHash()), common.Base58Encode(head.HeadHash()))
+	}
+	return &smt.Proof{
+		Siblings:  resp.Siblings,
+		LeafPath:  resp.LeafPath,
+		LeafValue: resp.LeafValue,
+	}, nil
+}
+
+func fetchHeader(num int64) (*block.Block, error) {
+	resp, err := sdk.getBlockHeader(num)
+	if err != nil {
+		return nil, err
+	}
+	header := &block.Block{}
+	err = header.Decode(resp.Header)
+	if err != nil {
+		return nil, fmt.Errorf("decode header of block %v failed: %v", num, err)
+	}
+	return header, nil
+}
+
+func init() {
+	rootCmd.AddCommand(lightCmd)
+	lightCmd.Flags().StringVarP(&trustedHash, "hash", "", "", "hash of the trusted block (required)")
+	lightCmd.Flags().StringSliceVarP(&trustedWitnesses, "witnesses", "", []string{}, "witness list scheduling the children of the trusted block, split by comma")
+	lightCmd.Flags().StringSliceVarP(&stateKeys, "keys", "", []string{}, "raw state keys to verify at the last block, split by comma")
+}
@@ -339,6 +339,26 @@ func (s *SDK) getGetBlockByHash(hash string, complete bool) (*rpcpb.BlockRespons
 	return client.GetBlockByHash(context.Background(), &rpcpb.GetBlockByHashRequest{Hash: hash, Complete: complete})
 }
 
+func (s *SDK) getBlockHeader(num int64) (*rpcpb.BlockHeaderResponse, error) {
+	conn, err := grpc.Dial(s.server, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := rpcpb.NewApiServiceClient(conn)
+	return client.GetBlockHeader(context.Background(), &rpcpb.GetBlockHeaderRequest{Number: num})
+}
+
+func (s *SDK) getStateProof(num int64, key string) (*rpcpb.StateProofResponse, error) {
+	conn, err := grpc.Dial(s.server, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := rpcpb.NewApiServiceClient(conn)
+	return client.GetStateProof(context.Background(), &rpcpb.GetStateProofRequest{Number: num, Key: key})
+}
+
 func (s *SDK) getTxByHash(hash string) (*rpcpb.TransactionResponse, error) {
 	conn, err := grpc.Dial(s.server, grpc.WithInsecure())
 	if err != nil {
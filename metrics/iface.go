@@ -14,3 +14,8 @@ type Gauge interface {
 type Summary interface {
 	Observe(float64, map[string]string) error
 }
+
+// Histogram defines the API of histogram-type metrics.
+type Histogram interface {
+	Observe(float64, map[string]string) error
+}
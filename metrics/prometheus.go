@@ -67,3 +67,25 @@ func (p *PromSummary) Observe(value float64, tagkv map[string]string) error {
 	summary.Observe(value)
 	return nil
 }
+
+// PromHistogram is the implementation of Histogram with prometheus's HistogramVec.
+type PromHistogram struct {
+	histogramVec *prometheus.HistogramVec
+}
+
+// NewPromHistogram returns a instance of PromHistogram.
+func NewPromHistogram(h *prometheus.HistogramVec) *PromHistogram {
+	return &PromHistogram{
+		histogramVec: h,
+	}
+}
+
+// Observe adds the observations to the prometheus Histogram.
+func (p *PromHistogram) Observe(value float64, tagkv map[string]string) error {
+	histogram, err := p.histogramVec.GetMetricWith(prometheus.Labels(tagkv))
+	if err != nil {
+		return err
+	}
+	histogram.Observe(value)
+	return nil
+}
@@ -52,6 +52,13 @@ const (
 	SyncBlockResponse
 	SyncHeight
 	PublishTx
+	Evidence
+	SnapshotInfoRequest
+	SnapshotInfoResponse
+	SnapshotChunkRequest
+	SnapshotChunkResponse
+	SyncHeaderRequest
+	SyncHeaderResponse
 
 	UrgentMessage = 1
 	NormalMessage = 2
@@ -81,6 +88,20 @@ func (m MessageType) String() string {
 		return "PublishTx"
 	case NewBlockHash:
 		return "NewBlockHash"
+	case Evidence:
+		return "Evidence"
+	case SnapshotInfoRequest:
+		return "SnapshotInfoRequest"
+	case SnapshotInfoResponse:
+		return "SnapshotInfoResponse"
+	case SnapshotChunkRequest:
+		return "SnapshotChunkRequest"
+	case SnapshotChunkResponse:
+		return "SnapshotChunkResponse"
+	case SyncHeaderRequest:
+		return "SyncHeaderRequest"
+	case SyncHeaderResponse:
+		return "SyncHeaderResponse"
 	default:
 		return "unknown_type:" + strconv.Itoa(int(m))
 	}
@@ -0,0 +1,54 @@
+package p2p
+
+// NopService is a Service without any network, used when the node runs alone.
+//
+// Registered channels never receive messages, and messages sent to it are dropped.
+type NopService struct{}
+
+var _ Service = &NopService{}
+
+// NewNopService returns a NopService instance.
+func NewNopService() *NopService {
+	return &NopService{}
+}
+
+// Start starts the jobs.
+func (ns *NopService) Start() error {
+	return nil
+}
+
+// Stop stops all the jobs.
+func (ns *NopService) Stop() {}
+
+// ID returns an empty ID.
+func (ns *NopService) ID() string {
+	return ""
+}
+
+// ConnectBPs does nothing.
+func (ns *NopService) ConnectBPs([]string) {}
+
+// PutPeerToBlack does nothing.
+func (ns *NopService) PutPeerToBlack(string) {}
+
+// Broadcast drops the message.
+func (ns *NopService) Broadcast([]byte, MessageType, MessagePriority) {}
+
+// SendToPeer drops the message.
+func (ns *NopService) SendToPeer(PeerID, []byte, MessageType, MessagePriority) {}
+
+// Register returns a channel that never receives messages.
+func (ns *NopService) Register(id string, mTyps ...MessageType) chan IncomingMessage {
+	if len(mTyps) == 0 {
+		return nil
+	}
+	return make(chan IncomingMessage)
+}
+
+// Deregister does nothing.
+func (ns *NopService) Deregister(string, ...MessageType) {}
+
+// GetAllNeighbors returns an empty list.
+func (ns *NopService) GetAllNeighbors() []*Peer {
+	return []*Peer{}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/iost-official/go-iost/vm"
 
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus"
 	"github.com/iost-official/go-iost/consensus/cverifier"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
@@ -19,6 +20,7 @@ import (
 	"github.com/iost-official/go-iost/core/global"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
 	rpcpb "github.com/iost-official/go-iost/rpc/pb"
@@ -36,18 +38,20 @@ type APIService struct {
 	txpool     txpool.TxPool
 	blockchain block.Chain
 	bv         global.BaseVariable
+	consensus  consensus.Consensus
 
 	quitCh chan struct{}
 }
 
 // NewAPIService returns a new APIService instance.
-func NewAPIService(tp txpool.TxPool, bcache blockcache.BlockCache, bv global.BaseVariable, p2pService p2p.Service, quitCh chan struct{}) *APIService {
+func NewAPIService(tp txpool.TxPool, bcache blockcache.BlockCache, bv global.BaseVariable, p2pService p2p.Service, cons consensus.Consensus, quitCh chan struct{}) *APIService {
 	return &APIService{
 		p2pService: p2pService,
 		txpool:     tp,
 		blockchain: bv.BlockChain(),
 		bc:         bcache,
 		bv:         bv,
+		consensus:  cons,
 		quitCh:     quitCh,
 	}
 }
@@ -122,6 +126,9 @@ func (as *APIService) GetTxByHash(ctx context.Context, req *rpcpb.TxHashRequest)
 		err       error
 	)
 	t, err = as.blockchain.GetTx(txHashBytes)
+	if err == block.ErrPruned {
+		return nil, fmt.Errorf("tx %v is pruned, use GetTxReceiptByTxHash for the receipt", req.GetHash())
+	}
 	if err != nil {
 		status = rpcpb.TransactionResponse_PACKED
 		t, txReceipt, err = as.txpool.GetFromChain(txHashBytes)
@@ -165,7 +172,12 @@ func (as *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByH
 	)
 	status := rpcpb.BlockResponse_IRREVERSIBLE
 	blk, err = as.blockchain.GetBlockByHash(hashBytes)
-	if err != nil {
+	if err == block.ErrPruned {
+		blk, err = as.getPrunedBlock(hashBytes, req.GetComplete())
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
 		status = rpcpb.BlockResponse_PENDING
 		blk, err = as.bc.GetBlockByHash(hashBytes)
 		if err != nil {
@@ -187,7 +199,16 @@ func (as *APIService) GetBlockByNumber(ctx context.Context, req *rpcpb.GetBlockB
 	)
 	status := rpcpb.BlockResponse_IRREVERSIBLE
 	blk, err = as.blockchain.GetBlockByNumber(number)
-	if err != nil {
+	if err == block.ErrPruned {
+		var hash []byte
+		hash, err = as.blockchain.GetHashByNumber(number)
+		if err == nil {
+			blk, err = as.getPrunedBlock(hash, req.GetComplete())
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
 		status = rpcpb.BlockResponse_PENDING
 		blk, err = as.bc.GetBlockByNumber(number)
 		if err != nil {
@@ -200,6 +221,14 @@ func (as *APIService) GetBlockByNumber(ctx context.Context, req *rpcpb.GetBlockB
 	}, nil
 }
 
+// getPrunedBlock returns the block whose txs are pruned with the receipts, it fails if the txs are requested.
+func (as *APIService) getPrunedBlock(hash []byte, complete bool) (*block.Block, error) {
+	if complete {
+		return nil, fmt.Errorf("txs of block %v are pruned, request it without complete", common.Base58Encode(hash))
+	}
+	return as.blockchain.GetBlockWithReceiptsByHash(hash)
+}
+
 // GetAccount returns account information corresponding to the given account name.
 func (as *APIService) GetAccount(ctx context.Context, req *rpcpb.GetAccountRequest) (*rpcpb.Account, error) {
 	dbVisitor, err := as.getStateDBVisitor(req.ByLongestChain)
@@ -517,6 +546,139 @@ func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiServic
 		}
 	}
 }
+
+// GetEvidence returns the equivocation evidences of the witness, or of all witnesses if it's empty.
+func (as *APIService) GetEvidence(ctx context.Context, req *rpcpb.GetEvidenceRequest) (*rpcpb.GetEvidenceResponse, error) {
+	evidences, err := as.bv.EvidenceDB().List(req.GetWitness())
+	if err != nil {
+		return nil, err
+	}
+	ret := &rpcpb.GetEvidenceResponse{}
+	for _, e := range evidences {
+		pe, err := toPbEvidence(e)
+		if err != nil {
+			return nil, err
+		}
+		ret.Evidences = append(ret.Evidences, pe)
+	}
+	return ret, nil
+}
+
+// GetProducerStats returns the block producing statistics of the witness, or of all witnesses if it's empty.
+func (as *APIService) GetProducerStats(ctx context.Context, req *rpcpb.GetProducerStatsRequest) (*rpcpb.GetProducerStatsResponse, error) {
+	ret := &rpcpb.GetProducerStatsResponse{}
+	for _, s := range as.consensus.ProducerStats(req.GetWitness()) {
+		ret.Stats = append(ret.Stats, &rpcpb.ProducerStat{
+			Witness:  s.Witness,
+			Produced: s.Produced,
+			Missed:   s.Missed,
+			Late:     s.Late,
+			Orphaned: s.Orphaned,
+		})
+	}
+	return ret, nil
+}
+
+// GetBlockHeader returns the signed header of the block, and the witness list scheduling its children if it's in the block cache.
+// The light clients prove the witness lists against the state roots instead of trusting the list.
+func (as *APIService) GetBlockHeader(ctx context.Context, req *rpcpb.GetBlockHeaderRequest) (*rpcpb.BlockHeaderResponse, error) {
+	number := req.GetNumber()
+	status := rpcpb.BlockResponse_IRREVERSIBLE
+	blk, err := as.blockchain.GetBlockHeadByNumber(number)
+	if err != nil {
+		status = rpcpb.BlockResponse_PENDING
+		blk, err = as.bc.GetBlockByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+	}
+	header, err := (&block.Block{Head: blk.Head, Sign: blk.Sign}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	ret := &rpcpb.BlockHeaderResponse{
+		Status: status,
+		Header: header,
+	}
+	if node, err := as.bc.Find(blk.HeadHash()); err == nil {
+		ret.WitnessList = node.Active()
+	}
+	return ret, nil
+}
+
+// GetStateDigest returns the digest of the state writes of the irreversible block.
+func (as *APIService) GetStateDigest(ctx context.Context, req *rpcpb.GetStateDigestRequest) (*rpcpb.StateDigestResponse, error) {
+	number := req.GetNumber()
+	hash, err := as.blockchain.GetHashByNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("block %v isn't irreversible yet", number)
+	}
+	b, err := as.blockchain.GetStateDigest(hash)
+	if err != nil {
+		return nil, fmt.Errorf("state digest of block %v not found", number)
+	}
+	digest, err := db.DecodeDigest(b)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.StateDigestResponse{
+		Number:       number,
+		Hash:         common.Base58Encode(hash),
+		WritesDigest: common.Base58Encode(digest.Writes),
+		StateDigest:  common.Base58Encode(digest.State),
+	}, nil
+}
+
+// GetStateProof returns the proof of the key of the state at the block, against the state root in the block head.
+// The state is kept for the blocks from the last irreversible block to the head block.
+func (as *APIService) GetStateProof(ctx context.Context, req *rpcpb.GetStateProofRequest) (*rpcpb.StateProofResponse, error) {
+	number := req.GetNumber()
+	status := rpcpb.BlockResponse_PENDING
+	blk, err := as.bc.GetBlockByNumber(number)
+	if err != nil {
+		status = rpcpb.BlockResponse_IRREVERSIBLE
+		blk, err = as.blockchain.GetBlockHeadByNumber(number)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if blk.Head.Version < block.V1 {
+		return nil, fmt.Errorf("block %v doesn't commit the state root", number)
+	}
+	if blk.Head.Number <= as.bc.LinkedRoot().Head.Number {
+		status = rpcpb.BlockResponse_IRREVERSIBLE
+	}
+	stateDB := as.bv.StateDB().Fork()
+	if !stateDB.Checkout(string(blk.HeadHash())) {
+		return nil, fmt.Errorf("state of block %v isn't kept", number)
+	}
+	exists, err := stateDB.Has(database.StateTable, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	value, err := stateDB.Get(database.StateTable, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	proof, err := stateDB.StateProof(database.StateTable, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	header, err := (&block.Block{Head: blk.Head, Sign: blk.Sign}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.StateProofResponse{
+		Status:    status,
+		Header:    header,
+		Exists:    exists,
+		Value:     value,
+		Siblings:  proof.Siblings,
+		LeafPath:  proof.LeafPath,
+		LeafValue: proof.LeafValue,
+	}, nil
+}
+
 func (as *APIService) getStateDBVisitorByHash(hash []byte) (db *database.Visitor, err error) {
 	stateDB := as.bv.StateDB().Fork()
 	ok := stateDB.Checkout(string(hash))
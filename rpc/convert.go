@@ -5,6 +5,7 @@ import (
 
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/evidence"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/core/tx"
@@ -85,6 +86,9 @@ func toPbBlock(blk *block.Block, complete bool) *rpcpb.Block {
 		GasUsage:            float64(blk.CalculateGasUsage()) / 100,
 		TxCount:             int64(len(blk.Txs)),
 	}
+	if blk.Txs == nil {
+		ret.TxCount = int64(len(blk.TxHashes))
+	}
 	var info verifier.Info
 	json.Unmarshal(blk.Head.Info, &info)
 	ret.Info = &rpcpb.Block_Info{
@@ -102,6 +106,20 @@ func toPbBlock(blk *block.Block, complete bool) *rpcpb.Block {
 	return ret
 }
 
+func toPbEvidence(e *evidence.Evidence) (*rpcpb.Evidence, error) {
+	data, err := e.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.Evidence{
+		Hash:    common.Base58Encode(e.Hash()),
+		Witness: e.Witness(),
+		Number:  e.Number(),
+		Blocks:  []*rpcpb.Block{toPbBlock(e.A, false), toPbBlock(e.B, false)},
+		Data:    data,
+	}, nil
+}
+
 func toPbItem(item *account.Item) *rpcpb.Account_Item {
 	return &rpcpb.Account_Item{
 		Id:         item.ID,
@@ -86,6 +86,19 @@ func (mr *MockApiServiceServerMockRecorder) GetBlockByNumber(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlockByNumber), arg0, arg1)
 }
 
+// GetBlockHeader mocks base method
+func (m *MockApiServiceServer) GetBlockHeader(arg0 context.Context, arg1 *pb.GetBlockHeaderRequest) (*pb.BlockHeaderResponse, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeader", arg0, arg1)
+	ret0, _ := ret[0].(*pb.BlockHeaderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeader indicates an expected call of GetBlockHeader
+func (mr *MockApiServiceServerMockRecorder) GetBlockHeader(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeader", reflect.TypeOf((*MockApiServiceServer)(nil).GetBlockHeader), arg0, arg1)
+}
+
 // GetChainInfo mocks base method
 func (m *MockApiServiceServer) GetChainInfo(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.ChainInfoResponse, error) {
 	ret := m.ctrl.Call(m, "GetChainInfo", arg0, arg1)
@@ -138,6 +151,19 @@ func (mr *MockApiServiceServerMockRecorder) GetContractStorageFields(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractStorageFields", reflect.TypeOf((*MockApiServiceServer)(nil).GetContractStorageFields), arg0, arg1)
 }
 
+// GetEvidence mocks base method
+func (m *MockApiServiceServer) GetEvidence(arg0 context.Context, arg1 *pb.GetEvidenceRequest) (*pb.GetEvidenceResponse, error) {
+	ret := m.ctrl.Call(m, "GetEvidence", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetEvidenceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvidence indicates an expected call of GetEvidence
+func (mr *MockApiServiceServerMockRecorder) GetEvidence(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvidence", reflect.TypeOf((*MockApiServiceServer)(nil).GetEvidence), arg0, arg1)
+}
+
 // GetGasRatio mocks base method
 func (m *MockApiServiceServer) GetGasRatio(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.GasRatioResponse, error) {
 	ret := m.ctrl.Call(m, "GetGasRatio", arg0, arg1)
@@ -164,6 +190,19 @@ func (mr *MockApiServiceServerMockRecorder) GetNodeInfo(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeInfo", reflect.TypeOf((*MockApiServiceServer)(nil).GetNodeInfo), arg0, arg1)
 }
 
+// GetProducerStats mocks base method
+func (m *MockApiServiceServer) GetProducerStats(arg0 context.Context, arg1 *pb.GetProducerStatsRequest) (*pb.GetProducerStatsResponse, error) {
+	ret := m.ctrl.Call(m, "GetProducerStats", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetProducerStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProducerStats indicates an expected call of GetProducerStats
+func (mr *MockApiServiceServerMockRecorder) GetProducerStats(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducerStats", reflect.TypeOf((*MockApiServiceServer)(nil).GetProducerStats), arg0, arg1)
+}
+
 // GetRAMInfo mocks base method
 func (m *MockApiServiceServer) GetRAMInfo(arg0 context.Context, arg1 *pb.EmptyRequest) (*pb.RAMInfoResponse, error) {
 	ret := m.ctrl.Call(m, "GetRAMInfo", arg0, arg1)
@@ -177,6 +216,32 @@ func (mr *MockApiServiceServerMockRecorder) GetRAMInfo(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRAMInfo", reflect.TypeOf((*MockApiServiceServer)(nil).GetRAMInfo), arg0, arg1)
 }
 
+// GetStateDigest mocks base method
+func (m *MockApiServiceServer) GetStateDigest(arg0 context.Context, arg1 *pb.GetStateDigestRequest) (*pb.StateDigestResponse, error) {
+	ret := m.ctrl.Call(m, "GetStateDigest", arg0, arg1)
+	ret0, _ := ret[0].(*pb.StateDigestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateDigest indicates an expected call of GetStateDigest
+func (mr *MockApiServiceServerMockRecorder) GetStateDigest(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateDigest", reflect.TypeOf((*MockApiServiceServer)(nil).GetStateDigest), arg0, arg1)
+}
+
+// GetStateProof mocks base method
+func (m *MockApiServiceServer) GetStateProof(arg0 context.Context, arg1 *pb.GetStateProofRequest) (*pb.StateProofResponse, error) {
+	ret := m.ctrl.Call(m, "GetStateProof", arg0, arg1)
+	ret0, _ := ret[0].(*pb.StateProofResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateProof indicates an expected call of GetStateProof
+func (mr *MockApiServiceServerMockRecorder) GetStateProof(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateProof", reflect.TypeOf((*MockApiServiceServer)(nil).GetStateProof), arg0, arg1)
+}
+
 // GetToken721Balance mocks base method
 func (m *MockApiServiceServer) GetToken721Balance(arg0 context.Context, arg1 *pb.GetTokenBalanceRequest) (*pb.GetToken721BalanceResponse, error) {
 	ret := m.ctrl.Call(m, "GetToken721Balance", arg0, arg1)
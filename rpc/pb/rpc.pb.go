@@ -178,16 +178,20 @@ const (
 	Event_CONTRACT_RECEIPT Event_Topic = 0
 	// contract event
 	Event_CONTRACT_EVENT Event_Topic = 1
+	// equivocation of block producer
+	Event_EQUIVOCATION Event_Topic = 2
 )
 
 var Event_Topic_name = map[int32]string{
 	0: "CONTRACT_RECEIPT",
 	1: "CONTRACT_EVENT",
+	2: "EQUIVOCATION",
 }
 
 var Event_Topic_value = map[string]int32{
 	"CONTRACT_RECEIPT": 0,
 	"CONTRACT_EVENT":   1,
+	"EQUIVOCATION":     2,
 }
 
 func (x Event_Topic) String() string {
@@ -3243,6 +3247,678 @@ func (m *SubscribeResponse) GetEvent() *Event {
 	return nil
 }
 
+// The message defines get evidence request.
+type GetEvidenceRequest struct {
+	// witness of the evidences, all witnesses if empty
+	Witness              string   `protobuf:"bytes,1,opt,name=witness,proto3" json:"witness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEvidenceRequest) Reset()         { *m = GetEvidenceRequest{} }
+func (m *GetEvidenceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEvidenceRequest) ProtoMessage()    {}
+func (*GetEvidenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{39}
+}
+
+func (m *GetEvidenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidenceRequest.Unmarshal(m, b)
+}
+func (m *GetEvidenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidenceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEvidenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidenceRequest.Merge(m, src)
+}
+func (m *GetEvidenceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEvidenceRequest.Size(m)
+}
+func (m *GetEvidenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEvidenceRequest proto.InternalMessageInfo
+
+func (m *GetEvidenceRequest) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+// The message defines the evidence of a witness producing two blocks at the same height.
+type Evidence struct {
+	// evidence hash
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// witness who signed both blocks
+	Witness string `protobuf:"bytes,2,opt,name=witness,proto3" json:"witness,omitempty"`
+	// block number
+	Number int64 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	// the two conflicting block heads
+	Blocks []*Block `protobuf:"bytes,4,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// encoded evidence with both signed heads, for verification
+	Data                 []byte   `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{40}
+}
+
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Evidence.Unmarshal(m, b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+}
+func (m *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(m, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return xxx_messageInfo_Evidence.Size(m)
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+func (m *Evidence) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Evidence) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+func (m *Evidence) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Evidence) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *Evidence) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// The message defines get evidence response.
+type GetEvidenceResponse struct {
+	// evidences
+	Evidences            []*Evidence `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetEvidenceResponse) Reset()         { *m = GetEvidenceResponse{} }
+func (m *GetEvidenceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEvidenceResponse) ProtoMessage()    {}
+func (*GetEvidenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{41}
+}
+
+func (m *GetEvidenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidenceResponse.Unmarshal(m, b)
+}
+func (m *GetEvidenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidenceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEvidenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidenceResponse.Merge(m, src)
+}
+func (m *GetEvidenceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEvidenceResponse.Size(m)
+}
+func (m *GetEvidenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEvidenceResponse proto.InternalMessageInfo
+
+func (m *GetEvidenceResponse) GetEvidences() []*Evidence {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+// The message defines get producer stats request.
+type GetProducerStatsRequest struct {
+	// witness of the stats, all witnesses if empty
+	Witness              string   `protobuf:"bytes,1,opt,name=witness,proto3" json:"witness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetProducerStatsRequest) Reset()         { *m = GetProducerStatsRequest{} }
+func (m *GetProducerStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProducerStatsRequest) ProtoMessage()    {}
+func (*GetProducerStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{42}
+}
+
+func (m *GetProducerStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducerStatsRequest.Unmarshal(m, b)
+}
+func (m *GetProducerStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducerStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetProducerStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducerStatsRequest.Merge(m, src)
+}
+func (m *GetProducerStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetProducerStatsRequest.Size(m)
+}
+func (m *GetProducerStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducerStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProducerStatsRequest proto.InternalMessageInfo
+
+func (m *GetProducerStatsRequest) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+// The message defines the block producing statistics of a witness.
+type ProducerStat struct {
+	// witness
+	Witness string `protobuf:"bytes,1,opt,name=witness,proto3" json:"witness,omitempty"`
+	// number of irreversible blocks produced
+	Produced int64 `protobuf:"varint,2,opt,name=produced,proto3" json:"produced,omitempty"`
+	// number of blocks missed in its slots
+	Missed int64 `protobuf:"varint,3,opt,name=missed,proto3" json:"missed,omitempty"`
+	// number of blocks received late
+	Late int64 `protobuf:"varint,4,opt,name=late,proto3" json:"late,omitempty"`
+	// number of blocks orphaned on forks
+	Orphaned             int64    `protobuf:"varint,5,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProducerStat) Reset()         { *m = ProducerStat{} }
+func (m *ProducerStat) String() string { return proto.CompactTextString(m) }
+func (*ProducerStat) ProtoMessage()    {}
+func (*ProducerStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{43}
+}
+
+func (m *ProducerStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProducerStat.Unmarshal(m, b)
+}
+func (m *ProducerStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProducerStat.Marshal(b, m, deterministic)
+}
+func (m *ProducerStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProducerStat.Merge(m, src)
+}
+func (m *ProducerStat) XXX_Size() int {
+	return xxx_messageInfo_ProducerStat.Size(m)
+}
+func (m *ProducerStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProducerStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProducerStat proto.InternalMessageInfo
+
+func (m *ProducerStat) GetWitness() string {
+	if m != nil {
+		return m.Witness
+	}
+	return ""
+}
+
+func (m *ProducerStat) GetProduced() int64 {
+	if m != nil {
+		return m.Produced
+	}
+	return 0
+}
+
+func (m *ProducerStat) GetMissed() int64 {
+	if m != nil {
+		return m.Missed
+	}
+	return 0
+}
+
+func (m *ProducerStat) GetLate() int64 {
+	if m != nil {
+		return m.Late
+	}
+	return 0
+}
+
+func (m *ProducerStat) GetOrphaned() int64 {
+	if m != nil {
+		return m.Orphaned
+	}
+	return 0
+}
+
+// The message defines get producer stats response.
+type GetProducerStatsResponse struct {
+	// stats
+	Stats                []*ProducerStat `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetProducerStatsResponse) Reset()         { *m = GetProducerStatsResponse{} }
+func (m *GetProducerStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetProducerStatsResponse) ProtoMessage()    {}
+func (*GetProducerStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{44}
+}
+
+func (m *GetProducerStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProducerStatsResponse.Unmarshal(m, b)
+}
+func (m *GetProducerStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProducerStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetProducerStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProducerStatsResponse.Merge(m, src)
+}
+func (m *GetProducerStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetProducerStatsResponse.Size(m)
+}
+func (m *GetProducerStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProducerStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProducerStatsResponse proto.InternalMessageInfo
+
+func (m *GetProducerStatsResponse) GetStats() []*ProducerStat {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// The request message of the block header.
+type GetBlockHeaderRequest struct {
+	// block number
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockHeaderRequest) Reset()         { *m = GetBlockHeaderRequest{} }
+func (m *GetBlockHeaderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockHeaderRequest) ProtoMessage()    {}
+func (*GetBlockHeaderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{45}
+}
+
+func (m *GetBlockHeaderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockHeaderRequest.Unmarshal(m, b)
+}
+func (m *GetBlockHeaderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockHeaderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockHeaderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockHeaderRequest.Merge(m, src)
+}
+func (m *GetBlockHeaderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockHeaderRequest.Size(m)
+}
+func (m *GetBlockHeaderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockHeaderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockHeaderRequest proto.InternalMessageInfo
+
+func (m *GetBlockHeaderRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+// The message defines the signed block header.
+type BlockHeaderResponse struct {
+	// block status
+	Status BlockResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.BlockResponse_Status" json:"status,omitempty"`
+	// the block encoded with only the head and the signature
+	Header []byte `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// the active witness list scheduling the children of the block as the node reports it, not proved, empty if the block is older than the last irreversible block
+	WitnessList          []string `protobuf:"bytes,3,rep,name=witness_list,json=witnessList,proto3" json:"witness_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeaderResponse) Reset()         { *m = BlockHeaderResponse{} }
+func (m *BlockHeaderResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaderResponse) ProtoMessage()    {}
+func (*BlockHeaderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{46}
+}
+
+func (m *BlockHeaderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaderResponse.Unmarshal(m, b)
+}
+func (m *BlockHeaderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaderResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockHeaderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaderResponse.Merge(m, src)
+}
+func (m *BlockHeaderResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaderResponse.Size(m)
+}
+func (m *BlockHeaderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaderResponse proto.InternalMessageInfo
+
+func (m *BlockHeaderResponse) GetStatus() BlockResponse_Status {
+	if m != nil {
+		return m.Status
+	}
+	return BlockResponse_PENDING
+}
+
+func (m *BlockHeaderResponse) GetHeader() []byte {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *BlockHeaderResponse) GetWitnessList() []string {
+	if m != nil {
+		return m.WitnessList
+	}
+	return nil
+}
+
+// The request message of the state digest.
+type GetStateDigestRequest struct {
+	// block number
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateDigestRequest) Reset()         { *m = GetStateDigestRequest{} }
+func (m *GetStateDigestRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateDigestRequest) ProtoMessage()    {}
+func (*GetStateDigestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{47}
+}
+
+func (m *GetStateDigestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateDigestRequest.Unmarshal(m, b)
+}
+func (m *GetStateDigestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateDigestRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateDigestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateDigestRequest.Merge(m, src)
+}
+func (m *GetStateDigestRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateDigestRequest.Size(m)
+}
+func (m *GetStateDigestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateDigestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateDigestRequest proto.InternalMessageInfo
+
+func (m *GetStateDigestRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+// The message defines the digest of the state writes of a block.
+type StateDigestResponse struct {
+	// block number
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// block hash
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the digest of the state writes of the block
+	WritesDigest string `protobuf:"bytes,3,opt,name=writes_digest,json=writesDigest,proto3" json:"writes_digest,omitempty"`
+	// the digest chained from the state writes of all blocks flushed by the node, comparable between the nodes starting from the same state
+	StateDigest          string   `protobuf:"bytes,4,opt,name=state_digest,json=stateDigest,proto3" json:"state_digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateDigestResponse) Reset()         { *m = StateDigestResponse{} }
+func (m *StateDigestResponse) String() string { return proto.CompactTextString(m) }
+func (*StateDigestResponse) ProtoMessage()    {}
+func (*StateDigestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{48}
+}
+
+func (m *StateDigestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateDigestResponse.Unmarshal(m, b)
+}
+func (m *StateDigestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateDigestResponse.Marshal(b, m, deterministic)
+}
+func (m *StateDigestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateDigestResponse.Merge(m, src)
+}
+func (m *StateDigestResponse) XXX_Size() int {
+	return xxx_messageInfo_StateDigestResponse.Size(m)
+}
+func (m *StateDigestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateDigestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateDigestResponse proto.InternalMessageInfo
+
+func (m *StateDigestResponse) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *StateDigestResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *StateDigestResponse) GetWritesDigest() string {
+	if m != nil {
+		return m.WritesDigest
+	}
+	return ""
+}
+
+func (m *StateDigestResponse) GetStateDigest() string {
+	if m != nil {
+		return m.StateDigest
+	}
+	return ""
+}
+
+// The request message of the state proof.
+type GetStateProofRequest struct {
+	// block number
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// the raw key in the state table of the vm
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateProofRequest) Reset()         { *m = GetStateProofRequest{} }
+func (m *GetStateProofRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateProofRequest) ProtoMessage()    {}
+func (*GetStateProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{49}
+}
+
+func (m *GetStateProofRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateProofRequest.Unmarshal(m, b)
+}
+func (m *GetStateProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateProofRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateProofRequest.Merge(m, src)
+}
+func (m *GetStateProofRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateProofRequest.Size(m)
+}
+func (m *GetStateProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateProofRequest proto.InternalMessageInfo
+
+func (m *GetStateProofRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *GetStateProofRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+// The message defines the proof of a key of the state.
+type StateProofResponse struct {
+	// block status
+	Status BlockResponse_Status `protobuf:"varint,1,opt,name=status,proto3,enum=rpcpb.BlockResponse_Status" json:"status,omitempty"`
+	// the block encoded with only the head and the signature, its head commits the state root
+	Header []byte `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// whether the key is in the state
+	Exists bool `protobuf:"varint,3,opt,name=exists,proto3" json:"exists,omitempty"`
+	// the raw value of the key
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// the hashes of the siblings on the path of the key from the state root
+	Siblings [][]byte `protobuf:"bytes,5,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	// the path hash of the leaf of another key at the end of the path, which proves the key isn't in the state
+	LeafPath []byte `protobuf:"bytes,6,opt,name=leaf_path,json=leafPath,proto3" json:"leaf_path,omitempty"`
+	// the value hash of the leaf of another key at the end of the path
+	LeafValue            []byte   `protobuf:"bytes,7,opt,name=leaf_value,json=leafValue,proto3" json:"leaf_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProofResponse) Reset()         { *m = StateProofResponse{} }
+func (m *StateProofResponse) String() string { return proto.CompactTextString(m) }
+func (*StateProofResponse) ProtoMessage()    {}
+func (*StateProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{50}
+}
+
+func (m *StateProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProofResponse.Unmarshal(m, b)
+}
+func (m *StateProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProofResponse.Marshal(b, m, deterministic)
+}
+func (m *StateProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProofResponse.Merge(m, src)
+}
+func (m *StateProofResponse) XXX_Size() int {
+	return xxx_messageInfo_StateProofResponse.Size(m)
+}
+func (m *StateProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProofResponse proto.InternalMessageInfo
+
+func (m *StateProofResponse) GetStatus() BlockResponse_Status {
+	if m != nil {
+		return m.Status
+	}
+	return BlockResponse_PENDING
+}
+
+func (m *StateProofResponse) GetHeader() []byte {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *StateProofResponse) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *StateProofResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *StateProofResponse) GetSiblings() [][]byte {
+	if m != nil {
+		return m.Siblings
+	}
+	return nil
+}
+
+func (m *StateProofResponse) GetLeafPath() []byte {
+	if m != nil {
+		return m.LeafPath
+	}
+	return nil
+}
+
+func (m *StateProofResponse) GetLeafValue() []byte {
+	if m != nil {
+		return m.LeafValue
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("rpcpb.TxReceipt_StatusCode", TxReceipt_StatusCode_name, TxReceipt_StatusCode_value)
 	proto.RegisterEnum("rpcpb.TransactionResponse_Status", TransactionResponse_Status_name, TransactionResponse_Status_value)
@@ -3301,219 +3977,261 @@ func init() {
 	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
 	proto.RegisterType((*SubscribeRequest_Filter)(nil), "rpcpb.SubscribeRequest.Filter")
 	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
+	proto.RegisterType((*GetEvidenceRequest)(nil), "rpcpb.GetEvidenceRequest")
+	proto.RegisterType((*Evidence)(nil), "rpcpb.Evidence")
+	proto.RegisterType((*GetEvidenceResponse)(nil), "rpcpb.GetEvidenceResponse")
+	proto.RegisterType((*GetProducerStatsRequest)(nil), "rpcpb.GetProducerStatsRequest")
+	proto.RegisterType((*ProducerStat)(nil), "rpcpb.ProducerStat")
+	proto.RegisterType((*GetProducerStatsResponse)(nil), "rpcpb.GetProducerStatsResponse")
+	proto.RegisterType((*GetBlockHeaderRequest)(nil), "rpcpb.GetBlockHeaderRequest")
+	proto.RegisterType((*BlockHeaderResponse)(nil), "rpcpb.BlockHeaderResponse")
+	proto.RegisterType((*GetStateDigestRequest)(nil), "rpcpb.GetStateDigestRequest")
+	proto.RegisterType((*StateDigestResponse)(nil), "rpcpb.StateDigestResponse")
+	proto.RegisterType((*GetStateProofRequest)(nil), "rpcpb.GetStateProofRequest")
+	proto.RegisterType((*StateProofResponse)(nil), "rpcpb.StateProofResponse")
 }
 
 func init() { proto.RegisterFile("rpc/pb/rpc.proto", fileDescriptor_1b773bf3e696f610) }
 
 var fileDescriptor_1b773bf3e696f610 = []byte{
-	// 3306 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x39, 0x4d, 0x6f, 0x1b, 0x47,
-	0x96, 0x6e, 0x52, 0xfc, 0xe8, 0xc7, 0x0f, 0xd1, 0x25, 0xc5, 0xa6, 0xe9, 0xef, 0x8e, 0x13, 0x7f,
-	0x20, 0x11, 0x6d, 0x39, 0x8e, 0x63, 0x27, 0xd9, 0x0d, 0x25, 0xd3, 0x8a, 0x60, 0x9b, 0x52, 0x8a,
-	0x94, 0xb3, 0x01, 0x76, 0xd1, 0xdb, 0x24, 0x4b, 0x54, 0xaf, 0xc9, 0x6e, 0x6e, 0x77, 0xd3, 0xa6,
-	0x56, 0xf0, 0x65, 0x8f, 0x8b, 0xc5, 0x2e, 0x82, 0x5c, 0xe6, 0x30, 0x97, 0xb9, 0xe6, 0x07, 0xcc,
-	0x0c, 0x30, 0x3f, 0x63, 0x80, 0xb9, 0xce, 0x61, 0xe6, 0x1f, 0xe4, 0x3c, 0xc0, 0xa0, 0x5e, 0x55,
-	0x75, 0x37, 0xc9, 0xa6, 0xad, 0x39, 0xb1, 0xdf, 0xab, 0x57, 0xef, 0xbd, 0xaa, 0xf7, 0x5d, 0x84,
-	0x8a, 0x37, 0xee, 0xd5, 0xc7, 0xdd, 0xba, 0x37, 0xee, 0x6d, 0x8c, 0x3d, 0x37, 0x70, 0x49, 0xc6,
-	0x1b, 0xf7, 0xc6, 0xdd, 0xda, 0xa5, 0x81, 0xeb, 0x0e, 0x86, 0xac, 0x6e, 0x8d, 0xed, 0xba, 0xe5,
-	0x38, 0x6e, 0x60, 0x05, 0xb6, 0xeb, 0xf8, 0x82, 0xc8, 0x28, 0x43, 0xb1, 0x39, 0x1a, 0x07, 0xc7,
-	0x94, 0xfd, 0xe7, 0x84, 0xf9, 0x81, 0xb1, 0x01, 0xf9, 0x7d, 0xc6, 0xbc, 0x5d, 0xe7, 0xd0, 0x25,
-	0x65, 0x48, 0xd9, 0xfd, 0xaa, 0x76, 0x4d, 0xbb, 0xa5, 0xd3, 0x94, 0xdd, 0x27, 0x04, 0x56, 0xac,
-	0x7e, 0xdf, 0xab, 0xa6, 0x10, 0x83, 0xdf, 0xc6, 0x7f, 0x40, 0xa1, 0xc5, 0x82, 0x37, 0xae, 0xf7,
-	0x2a, 0x71, 0xcb, 0x65, 0x80, 0x31, 0x63, 0x9e, 0xd9, 0x73, 0x27, 0x4e, 0x80, 0x1b, 0x33, 0x54,
-	0xe7, 0x98, 0x6d, 0x8e, 0x20, 0x9f, 0x00, 0x02, 0xa6, 0xed, 0x1c, 0xba, 0xd5, 0xf4, 0xb5, 0xf4,
-	0xad, 0xc2, 0xe6, 0xea, 0x06, 0xaa, 0xbd, 0xa1, 0xb4, 0xa0, 0xf9, 0xb1, 0xfc, 0x32, 0x7e, 0xd6,
-	0x60, 0x95, 0x36, 0x5e, 0x20, 0x96, 0xf9, 0x63, 0xd7, 0xf1, 0x19, 0xb9, 0x00, 0xf9, 0x89, 0xcf,
-	0xfa, 0xa6, 0x67, 0x8d, 0x50, 0x6c, 0x9a, 0xe6, 0x38, 0x4c, 0xad, 0x11, 0xf9, 0x10, 0x4a, 0xd6,
-	0x6b, 0xcb, 0x1e, 0x5a, 0xdd, 0x21, 0xc3, 0xf5, 0x14, 0xae, 0x17, 0x43, 0x24, 0x27, 0xba, 0x08,
-	0x7a, 0xe0, 0x06, 0xd6, 0x10, 0x09, 0xd2, 0x48, 0x90, 0x47, 0x04, 0x5f, 0xbc, 0x0c, 0xe0, 0xb3,
-	0xe1, 0xd0, 0x1c, 0x7b, 0x76, 0x8f, 0x55, 0x57, 0xae, 0x69, 0xb7, 0x34, 0xaa, 0x73, 0xcc, 0x3e,
-	0x47, 0xf0, 0xbd, 0xdd, 0xc9, 0xb1, 0x5c, 0xcd, 0xe0, 0x6a, 0xbe, 0x3b, 0x39, 0xc6, 0x45, 0xe3,
-	0xff, 0x34, 0xa8, 0xb4, 0xdc, 0x3e, 0x9b, 0xd1, 0xf6, 0x32, 0x40, 0x77, 0x62, 0x0f, 0xfb, 0x66,
-	0x60, 0x8f, 0x98, 0xbc, 0x26, 0x1d, 0x31, 0x1d, 0x7b, 0x84, 0x87, 0x19, 0xd8, 0x81, 0x79, 0x64,
-	0xf9, 0x47, 0xf2, 0x92, 0x73, 0x03, 0x3b, 0xf8, 0xd6, 0xf2, 0x8f, 0xf8, 0xdd, 0x8f, 0xdc, 0x3e,
-	0x43, 0x15, 0x75, 0x8a, 0xdf, 0xe4, 0x13, 0xc8, 0x39, 0xe2, 0xee, 0x51, 0xb7, 0xc2, 0x26, 0x91,
-	0x77, 0x17, 0xb3, 0x08, 0x55, 0x24, 0xc6, 0x23, 0x28, 0x34, 0x46, 0xfc, 0xd6, 0x9f, 0xdb, 0x23,
-	0x3b, 0x20, 0xeb, 0x90, 0x09, 0xdc, 0x57, 0xcc, 0x91, 0x5a, 0x08, 0x80, 0x63, 0x5f, 0x5b, 0xc3,
-	0x09, 0x93, 0xe2, 0x05, 0x60, 0xfc, 0x00, 0xd9, 0x46, 0x8f, 0x7b, 0x0d, 0xa9, 0x41, 0xbe, 0xe7,
-	0x3a, 0x81, 0x67, 0xf5, 0x02, 0xb9, 0x31, 0x84, 0xc9, 0x55, 0x28, 0x58, 0x48, 0x65, 0x3a, 0xd6,
-	0x48, 0x71, 0x00, 0x81, 0x6a, 0x59, 0x23, 0xc6, 0xcf, 0xd0, 0xb7, 0x02, 0x4b, 0x9d, 0x81, 0x7f,
-	0x1b, 0x7f, 0x5e, 0x01, 0xbd, 0x33, 0xa5, 0xac, 0xc7, 0xec, 0x71, 0x40, 0xce, 0x43, 0x2e, 0x98,
-	0x8a, 0xf3, 0x0b, 0xee, 0xd9, 0x60, 0x8a, 0xc7, 0xbf, 0x08, 0xfa, 0xc0, 0xf2, 0xcd, 0x89, 0x6f,
-	0x0d, 0x04, 0x67, 0x8d, 0xe6, 0x07, 0x96, 0x7f, 0xc0, 0x61, 0xf2, 0x25, 0xe8, 0x9e, 0x35, 0x92,
-	0x8b, 0xc2, 0x8b, 0xae, 0xc8, 0x9b, 0x08, 0x59, 0x6f, 0x50, 0x6b, 0x84, 0xd4, 0x4d, 0x27, 0xf0,
-	0x8e, 0x69, 0xde, 0x93, 0x20, 0xf9, 0x0a, 0x0a, 0x7e, 0x60, 0x05, 0x13, 0xdf, 0xec, 0xf1, 0xfb,
-	0xe5, 0x17, 0x59, 0xde, 0xbc, 0xb8, 0xb0, 0xbd, 0x8d, 0x34, 0xdb, 0x6e, 0x9f, 0x51, 0xf0, 0xc3,
-	0x6f, 0x52, 0x85, 0xdc, 0x88, 0xf9, 0x28, 0x38, 0x23, 0x0c, 0x26, 0x41, 0xbe, 0xe2, 0xb1, 0x60,
-	0xe2, 0x39, 0x7e, 0x35, 0x7b, 0x2d, 0xcd, 0x57, 0x24, 0x48, 0x3e, 0x83, 0xbc, 0x27, 0xb8, 0xfa,
-	0xd5, 0x1c, 0x6a, 0x5b, 0x5d, 0xd4, 0x56, 0xfc, 0xd2, 0x90, 0xb2, 0xf6, 0x25, 0x94, 0x66, 0x8e,
-	0x40, 0x2a, 0x90, 0x7e, 0xc5, 0x8e, 0xe5, 0x3d, 0xf1, 0xcf, 0x59, 0xe3, 0xa5, 0xa5, 0xf1, 0x1e,
-	0xa7, 0xbe, 0xd0, 0x6a, 0xdf, 0x40, 0x4e, 0x5d, 0xf1, 0x45, 0xd0, 0x0f, 0x27, 0x4e, 0x4f, 0xd8,
-	0x48, 0x9a, 0x90, 0x23, 0xd0, 0x42, 0x55, 0xc8, 0x71, 0x73, 0x32, 0x19, 0xab, 0x3a, 0x55, 0xa0,
-	0xf1, 0x3b, 0x0d, 0x20, 0xba, 0x03, 0x52, 0x80, 0x5c, 0xfb, 0x60, 0x7b, 0xbb, 0xd9, 0x6e, 0x57,
-	0xce, 0x90, 0x55, 0x28, 0xec, 0x34, 0xda, 0x26, 0x3d, 0x68, 0x99, 0x7b, 0x07, 0x9d, 0x8a, 0x46,
-	0xce, 0x01, 0xd9, 0x6a, 0x3c, 0x6f, 0xb4, 0xb6, 0x9b, 0x66, 0x6b, 0xaf, 0x63, 0x36, 0x5b, 0x7b,
-	0x07, 0x3b, 0xdf, 0x56, 0x52, 0x64, 0x0d, 0x56, 0xbf, 0xa7, 0x7b, 0xad, 0x1d, 0x73, 0xbf, 0x41,
-	0x1b, 0x2f, 0x9a, 0x9d, 0x26, 0xad, 0xa4, 0xc9, 0x59, 0x28, 0xd1, 0x83, 0x56, 0x67, 0xf7, 0x45,
-	0xd3, 0x6c, 0x52, 0xba, 0x47, 0x2b, 0x2b, 0x9c, 0x3b, 0x87, 0x39, 0xb3, 0x4c, 0xb4, 0xa9, 0xf3,
-	0x2f, 0xe6, 0xd3, 0x3d, 0xfa, 0xa2, 0xd1, 0xa9, 0x64, 0xb9, 0x84, 0x27, 0x07, 0xfb, 0xcf, 0x77,
-	0xb7, 0x1b, 0x9d, 0xa6, 0xd9, 0x6e, 0x76, 0xcc, 0xed, 0xbd, 0x27, 0xcd, 0x4a, 0x8e, 0x33, 0x3b,
-	0x68, 0x3d, 0x6b, 0xed, 0x7d, 0xdf, 0x92, 0xcc, 0xf2, 0xc6, 0xcf, 0x69, 0x28, 0x74, 0x3c, 0xcb,
-	0xf1, 0x85, 0x27, 0x72, 0x2f, 0x8c, 0x39, 0x18, 0x7e, 0x73, 0x1c, 0x46, 0xa4, 0xb8, 0x38, 0xfc,
-	0x26, 0x57, 0x00, 0xd8, 0x74, 0x6c, 0x7b, 0x98, 0x2e, 0x65, 0x6a, 0x88, 0x61, 0x94, 0x4b, 0x22,
-	0x24, 0x73, 0x03, 0x77, 0x49, 0xca, 0x61, 0xb5, 0x38, 0xe4, 0xa1, 0xa6, 0x52, 0xc3, 0xc0, 0xf2,
-	0xc3, 0xd0, 0xeb, 0xb3, 0xa1, 0x75, 0x5c, 0xcd, 0x0a, 0x3b, 0x21, 0xc0, 0x83, 0xbf, 0x77, 0x64,
-	0xd9, 0x8e, 0x69, 0xf7, 0xab, 0xb9, 0x6b, 0xda, 0xad, 0x12, 0xcd, 0x21, 0xbc, 0xdb, 0x27, 0x37,
-	0x21, 0x27, 0x94, 0xf7, 0xab, 0x79, 0x74, 0x98, 0x92, 0x74, 0x18, 0x11, 0x95, 0x54, 0xad, 0x72,
-	0xfb, 0xf9, 0xf6, 0xc0, 0x61, 0x9e, 0x5f, 0xd5, 0x85, 0xd3, 0x49, 0x90, 0x5c, 0x02, 0x7d, 0x3c,
-	0xe9, 0x0e, 0x6d, 0xff, 0x88, 0x79, 0x55, 0x10, 0x89, 0x27, 0x44, 0xf0, 0xd0, 0xf5, 0xd8, 0x21,
-	0xf3, 0x3c, 0xd6, 0x37, 0x83, 0x69, 0xb5, 0x20, 0x42, 0x57, 0xa1, 0x3a, 0x53, 0xf2, 0x00, 0x8a,
-	0x16, 0x26, 0x0f, 0x79, 0xa4, 0x22, 0xaa, 0xa1, 0xf2, 0x4d, 0x2c, 0xaf, 0xd0, 0x82, 0x15, 0x4b,
-	0x32, 0x75, 0x80, 0x60, 0x6a, 0x4a, 0x1f, 0xae, 0x96, 0x30, 0x49, 0x55, 0xe6, 0x9d, 0x9d, 0xea,
-	0x81, 0xfa, 0x34, 0xfe, 0xa0, 0xc1, 0x5a, 0xcc, 0x58, 0x61, 0xe2, 0x7c, 0x04, 0x59, 0x11, 0x75,
-	0x68, 0xb6, 0xf2, 0xe6, 0x75, 0xc5, 0x64, 0x91, 0x56, 0x86, 0x2a, 0x95, 0x1b, 0xc8, 0x67, 0x50,
-	0x08, 0x22, 0x2a, 0x34, 0x71, 0xa4, 0x79, 0x7c, 0x7f, 0x9c, 0xcc, 0xb8, 0x0f, 0x59, 0xc1, 0x87,
-	0x3b, 0xe3, 0x7e, 0xb3, 0xf5, 0x64, 0xb7, 0xb5, 0x53, 0x39, 0x43, 0x00, 0xb2, 0xfb, 0x8d, 0xed,
-	0x67, 0xcd, 0x27, 0x15, 0x8d, 0x54, 0xa0, 0xb8, 0x4b, 0x69, 0xf3, 0x65, 0x93, 0xb6, 0x77, 0xb7,
-	0x9e, 0x37, 0x2b, 0x29, 0xe3, 0xf7, 0x1a, 0xe8, 0x6d, 0x7b, 0xe0, 0x58, 0xc1, 0xc4, 0x63, 0xe4,
-	0x0b, 0xd0, 0xad, 0xe1, 0xc0, 0xf5, 0xec, 0xe0, 0x68, 0x24, 0xd5, 0xae, 0x49, 0xb1, 0x21, 0xd1,
-	0x46, 0x43, 0x51, 0xd0, 0x88, 0x98, 0x1b, 0xcb, 0x57, 0x14, 0xa8, 0x70, 0x91, 0x46, 0x08, 0xac,
-	0xa9, 0xdc, 0x72, 0x3d, 0x93, 0xc7, 0x7f, 0x5a, 0x2c, 0x0b, 0xcc, 0x33, 0x76, 0x6c, 0x7c, 0x06,
-	0x7a, 0xc8, 0x94, 0x2b, 0x2f, 0xe3, 0xa1, 0x72, 0x86, 0x94, 0x40, 0x6f, 0x37, 0xb7, 0xf7, 0x37,
-	0x1f, 0x7c, 0xfe, 0xec, 0x5e, 0x45, 0xe3, 0x6b, 0xcd, 0x27, 0x9b, 0x0f, 0x1e, 0xdc, 0x7b, 0x54,
-	0x49, 0x19, 0xbf, 0x4d, 0x03, 0x99, 0xb9, 0x4c, 0x6c, 0x07, 0xc2, 0xc0, 0xd0, 0x96, 0x06, 0x46,
-	0xea, 0xdd, 0x81, 0x91, 0x7e, 0x57, 0x60, 0xac, 0x2c, 0x0b, 0x8c, 0xcc, 0xb2, 0xc0, 0xc8, 0x2e,
-	0x0d, 0x8c, 0xdc, 0x3b, 0x03, 0x63, 0xde, 0x7f, 0xf3, 0xa7, 0xf3, 0xdf, 0xe5, 0xf1, 0x74, 0x17,
-	0x20, 0xb4, 0x88, 0x5f, 0x05, 0x64, 0x57, 0x99, 0xb7, 0x2e, 0x8d, 0xd1, 0xcc, 0x46, 0x60, 0x61,
-	0x3e, 0x02, 0x1f, 0x42, 0x39, 0x04, 0x4c, 0xdf, 0x1e, 0xf8, 0x32, 0xc4, 0x16, 0x79, 0x96, 0x42,
-	0xba, 0xb6, 0x3d, 0xf0, 0x8d, 0xbf, 0xa4, 0x21, 0xb3, 0x35, 0x74, 0x7b, 0xaf, 0x12, 0x13, 0x5b,
-	0x15, 0x72, 0xaf, 0x99, 0xe7, 0x47, 0x86, 0x52, 0x20, 0x0f, 0xf9, 0xb1, 0xe5, 0x31, 0x47, 0xb6,
-	0x1b, 0xa2, 0x26, 0x83, 0x40, 0x61, 0xc9, 0xbd, 0x01, 0xe5, 0x60, 0x6a, 0x8e, 0x98, 0xf7, 0x6a,
-	0xc8, 0x04, 0xcd, 0x0a, 0xd2, 0x14, 0x83, 0xe9, 0x0b, 0x44, 0x22, 0xd5, 0x7d, 0x38, 0x17, 0x45,
-	0xf8, 0x0c, 0xb5, 0xa8, 0x87, 0x6b, 0x61, 0x6c, 0xc7, 0x36, 0x9d, 0x83, 0xac, 0x33, 0x19, 0x75,
-	0x99, 0x27, 0x33, 0xa0, 0x84, 0xb8, 0xb6, 0x6f, 0xec, 0xc0, 0x61, 0xbe, 0x8f, 0x19, 0x50, 0xa7,
-	0x0a, 0x0c, 0xfd, 0x30, 0x1f, 0xf3, 0xc3, 0x99, 0x9e, 0x40, 0x9f, 0xeb, 0x09, 0x2e, 0x40, 0x3e,
-	0x98, 0xca, 0xb6, 0x13, 0xc4, 0xc9, 0x83, 0xa9, 0x68, 0x3a, 0x3f, 0x82, 0x15, 0xec, 0x37, 0x0b,
-	0x98, 0x09, 0xce, 0xca, 0x0b, 0xc6, 0x3b, 0xdc, 0xc0, 0x96, 0x09, 0x97, 0xc9, 0xe7, 0x50, 0x8c,
-	0x25, 0x04, 0x7f, 0x2e, 0xe5, 0xc5, 0x63, 0x65, 0x86, 0xae, 0xd6, 0x86, 0x15, 0x6c, 0x85, 0x55,
-	0xc7, 0xa6, 0x61, 0xd3, 0x2b, 0x3a, 0xb6, 0x73, 0x90, 0x0d, 0x8e, 0x3c, 0x66, 0xf5, 0x65, 0x2b,
-	0x2c, 0x21, 0x6e, 0x8c, 0xae, 0x15, 0xf4, 0x8e, 0x4c, 0xdb, 0xe9, 0xb3, 0x29, 0xf6, 0x30, 0x19,
-	0x0a, 0x88, 0xda, 0xe5, 0x18, 0xe3, 0x47, 0x0d, 0x4a, 0xa8, 0x61, 0x98, 0x11, 0xef, 0xcf, 0x65,
-	0xc4, 0x8b, 0xf1, 0x73, 0x2c, 0xcb, 0x85, 0x06, 0x64, 0xba, 0x7c, 0x5d, 0x66, 0xc1, 0xe2, 0xcc,
-	0x1e, 0xb1, 0x64, 0xdc, 0x4c, 0xce, 0x7c, 0xf3, 0xd9, 0x4e, 0x33, 0x7e, 0x93, 0x82, 0xb3, 0xdb,
-	0x18, 0x88, 0x73, 0x0d, 0xb9, 0xc3, 0x82, 0x78, 0x7b, 0xc1, 0x3b, 0x50, 0xec, 0x2e, 0x6e, 0x43,
-	0x05, 0x87, 0x8e, 0x9e, 0x3b, 0x34, 0xe3, 0x5e, 0xa9, 0xd3, 0x55, 0x85, 0x7f, 0x29, 0xbd, 0x33,
-	0x1e, 0xf3, 0xe9, 0xd9, 0x98, 0xbf, 0x0c, 0x70, 0xc4, 0xac, 0xbe, 0x29, 0x0e, 0xb2, 0x82, 0xb6,
-	0xd5, 0x39, 0x46, 0x44, 0xc1, 0xc7, 0xb0, 0x1a, 0x2d, 0xc7, 0x3d, 0xb1, 0x14, 0xd2, 0xa8, 0x8e,
-	0x72, 0x68, 0x77, 0x25, 0x17, 0xe1, 0x86, 0xf9, 0xa1, 0xdd, 0x15, 0x4c, 0x6e, 0x40, 0x39, 0x5c,
-	0x14, 0x3c, 0x84, 0x3f, 0x16, 0x15, 0x05, 0xb2, 0xb8, 0x0e, 0x45, 0xe9, 0x9f, 0xe6, 0xd0, 0xf6,
-	0x45, 0x52, 0xd1, 0x69, 0x41, 0xe2, 0x9e, 0xdb, 0x7e, 0x60, 0x7c, 0x08, 0xa5, 0x0e, 0x76, 0xb0,
-	0xb1, 0x84, 0x3a, 0x1f, 0xa4, 0xc6, 0x0e, 0x7c, 0xb0, 0xc3, 0x02, 0xe4, 0xbb, 0x75, 0xfc, 0x1e,
-	0x62, 0xd1, 0x81, 0x8f, 0xc6, 0x43, 0x16, 0x88, 0xd2, 0x90, 0xa7, 0x21, 0x6c, 0xbc, 0x80, 0xf3,
-	0x11, 0xa3, 0x16, 0xc6, 0x94, 0x62, 0x15, 0x85, 0x9c, 0x36, 0x13, 0x72, 0xef, 0x62, 0xf7, 0x25,
-	0x94, 0x9e, 0x7a, 0xee, 0x7f, 0x31, 0x67, 0xcb, 0x1a, 0x5a, 0x4e, 0x0f, 0xdd, 0x57, 0x64, 0x47,
-	0x64, 0xa2, 0x51, 0x09, 0x25, 0xb5, 0x4f, 0xc6, 0xbf, 0x41, 0xfe, 0xa5, 0x1b, 0xe0, 0xf8, 0xc3,
-	0xf7, 0xb9, 0x63, 0xac, 0x16, 0xb2, 0xab, 0x17, 0x10, 0x36, 0xac, 0x6e, 0xc0, 0xfc, 0x70, 0xda,
-	0xe0, 0x00, 0x9f, 0xdb, 0x7a, 0x43, 0x66, 0xf1, 0x5e, 0x44, 0xac, 0x8a, 0xdc, 0x54, 0x94, 0x48,
-	0xce, 0xd5, 0x37, 0x0e, 0xa1, 0xb2, 0x23, 0x6b, 0x4a, 0xe8, 0x7a, 0xb7, 0xa0, 0x32, 0x74, 0xdf,
-	0x30, 0x3f, 0x30, 0xa3, 0xfa, 0x23, 0x14, 0x2d, 0x0b, 0xbc, 0xda, 0xc1, 0x29, 0x47, 0xac, 0x6f,
-	0x5b, 0x4e, 0x8c, 0x52, 0x4c, 0x15, 0x65, 0x81, 0x57, 0x94, 0xc6, 0xdf, 0x74, 0xc8, 0x35, 0x7a,
-	0x3d, 0x75, 0xcc, 0x98, 0x5b, 0xe3, 0x37, 0x4f, 0x59, 0x5d, 0x71, 0x3b, 0x92, 0x81, 0x02, 0xc9,
-	0x3d, 0xe0, 0xd9, 0x48, 0x8d, 0xb6, 0x3c, 0xdc, 0xce, 0x85, 0xc5, 0x09, 0xf9, 0x6d, 0xec, 0x58,
-	0xbe, 0x18, 0xd1, 0x06, 0xe2, 0x83, 0x6f, 0xe1, 0x83, 0x0c, 0x6e, 0x59, 0x49, 0xdc, 0xa2, 0xc6,
-	0xdf, 0x9c, 0x67, 0x8d, 0x70, 0x4b, 0x03, 0x0a, 0x63, 0xe6, 0x8d, 0x6c, 0xdf, 0xc7, 0x24, 0x95,
-	0xc1, 0x24, 0x75, 0x75, 0x6e, 0xd7, 0x7e, 0x44, 0x21, 0xc6, 0x9f, 0xf8, 0x1e, 0xb2, 0x09, 0xd9,
-	0x81, 0xe7, 0x4e, 0xc6, 0x62, 0x50, 0x29, 0x84, 0x4d, 0x4a, 0xa8, 0x26, 0x2e, 0x8a, 0x8d, 0x92,
-	0x92, 0x7c, 0x0d, 0xab, 0x87, 0xe8, 0x1a, 0xa6, 0x3c, 0xae, 0x2a, 0xc0, 0xeb, 0x72, 0xf3, 0x8c,
-	0xe3, 0xd0, 0xf2, 0x61, 0x1c, 0xf4, 0xc9, 0x06, 0x00, 0x37, 0x2d, 0x9e, 0x54, 0xf5, 0xb4, 0x6a,
-	0xf0, 0x57, 0x5e, 0x43, 0xf5, 0xd7, 0xf2, 0xcb, 0xaf, 0xfd, 0x13, 0xc0, 0xfe, 0x90, 0xf5, 0x07,
-	0xc2, 0x9d, 0xaa, 0x90, 0x1b, 0x23, 0xe4, 0xa9, 0x0c, 0x23, 0xc1, 0x98, 0x83, 0xa6, 0xe2, 0x0e,
-	0x5a, 0xfb, 0x45, 0x83, 0x9c, 0xbc, 0x6d, 0x74, 0xaf, 0x89, 0x87, 0x95, 0x0f, 0x07, 0x7d, 0xe9,
-	0x22, 0x45, 0x89, 0xec, 0x70, 0x1c, 0x4f, 0x55, 0x98, 0xd4, 0x0f, 0x99, 0x87, 0xcf, 0x07, 0x03,
-	0xcb, 0x97, 0x2c, 0x57, 0xe3, 0xf8, 0x1d, 0xcb, 0xc7, 0x76, 0x0c, 0xc5, 0x23, 0x91, 0xe8, 0x77,
-	0x74, 0x81, 0xe1, 0xcb, 0x1f, 0x41, 0xd9, 0x76, 0x7a, 0x1e, 0xb3, 0x7c, 0x66, 0xfa, 0x63, 0xc6,
-	0xfa, 0xb2, 0xeb, 0x29, 0x29, 0x6c, 0x9b, 0x23, 0x79, 0x28, 0xc4, 0x87, 0x05, 0x01, 0x90, 0xaf,
-	0xa0, 0x28, 0x38, 0xf5, 0x85, 0x53, 0x08, 0x03, 0x5d, 0x98, 0x37, 0x6f, 0x78, 0x35, 0xb4, 0x20,
-	0xc9, 0x39, 0x50, 0xfb, 0x0e, 0x72, 0xd2, 0x5f, 0x78, 0xf3, 0x11, 0x3e, 0x7b, 0xc8, 0x0c, 0x10,
-	0x21, 0xb8, 0x63, 0x4f, 0x7c, 0xd6, 0x57, 0xf1, 0xcb, 0xbf, 0xc5, 0xfb, 0x00, 0xbf, 0x1e, 0x31,
-	0xf9, 0x08, 0xa0, 0xe6, 0xc0, 0xca, 0x6e, 0xc0, 0x46, 0x0b, 0xef, 0x3c, 0x57, 0xa0, 0x60, 0xfb,
-	0xbc, 0x1f, 0x35, 0xc7, 0x96, 0xed, 0xc9, 0x4c, 0xa2, 0xdb, 0xfe, 0x33, 0x76, 0xbc, 0x6f, 0xd9,
-	0x68, 0x98, 0x37, 0xcc, 0x1e, 0x1c, 0x05, 0x92, 0x9d, 0x84, 0x78, 0x2f, 0x19, 0xb9, 0xa2, 0x6c,
-	0x30, 0x62, 0x98, 0xda, 0x53, 0xc8, 0xa0, 0xfb, 0x25, 0xc6, 0xde, 0x6d, 0xc8, 0xd8, 0x01, 0x1b,
-	0x71, 0xcb, 0xf0, 0x6b, 0x59, 0x9b, 0xbb, 0x16, 0xae, 0x28, 0x15, 0x14, 0xb5, 0xff, 0xd1, 0x00,
-	0xa2, 0x28, 0x48, 0xe4, 0x76, 0x15, 0x0a, 0xe8, 0xdc, 0x58, 0xba, 0x04, 0x4f, 0x9d, 0x02, 0xa2,
-	0x78, 0xf5, 0xf2, 0x23, 0x71, 0xe9, 0xf7, 0x89, 0xe3, 0xd7, 0xcd, 0x2b, 0xbb, 0x7f, 0xe4, 0x0e,
-	0xfb, 0xaa, 0x44, 0x85, 0x88, 0xda, 0x0f, 0x50, 0x99, 0x8f, 0xc8, 0x84, 0x69, 0xbe, 0x1e, 0x9f,
-	0xe6, 0x13, 0x8c, 0x1e, 0x72, 0x88, 0x0f, 0xfa, 0x7b, 0x50, 0x88, 0x85, 0x6b, 0x02, 0xd7, 0x3b,
-	0xb3, 0x5c, 0xd7, 0x93, 0x62, 0x3d, 0xc6, 0xd0, 0xf8, 0x0e, 0xce, 0xee, 0xb0, 0x40, 0x2e, 0xc7,
-	0xea, 0xd2, 0xc2, 0xf5, 0xdd, 0x82, 0x4a, 0xf7, 0xd8, 0x1c, 0xba, 0xce, 0x80, 0x27, 0x60, 0x2c,
-	0xd6, 0xd2, 0x0d, 0xca, 0xdd, 0xe3, 0xe7, 0x02, 0x8d, 0xdd, 0x82, 0xf1, 0x8b, 0x06, 0xf9, 0x6d,
-	0xf5, 0x68, 0x94, 0xf0, 0xc6, 0x88, 0xef, 0x30, 0xf2, 0x8d, 0x91, 0x7f, 0xf3, 0x1a, 0x35, 0xb4,
-	0x9c, 0xc1, 0x44, 0x3c, 0xef, 0xe0, 0x8b, 0x85, 0x82, 0xe3, 0x0d, 0xae, 0xf0, 0x9e, 0xb0, 0xc1,
-	0xbd, 0x09, 0x2b, 0x56, 0xd7, 0x56, 0x29, 0x51, 0x59, 0x4b, 0x09, 0xde, 0x68, 0x6c, 0xed, 0x52,
-	0x24, 0xa8, 0xf5, 0x21, 0xdd, 0xd8, 0xda, 0x4d, 0x3c, 0x14, 0x81, 0x15, 0xcb, 0x1b, 0x28, 0x67,
-	0xc0, 0xef, 0x85, 0x51, 0x22, 0x7d, 0xaa, 0x51, 0xc2, 0x68, 0x01, 0xd9, 0x61, 0x81, 0x12, 0xaf,
-	0x6e, 0x72, 0xfe, 0xf8, 0xa7, 0xbf, 0xc5, 0xb7, 0x70, 0x21, 0xc6, 0xaf, 0x1d, 0xb8, 0x9e, 0x35,
-	0x60, 0xcb, 0xd8, 0x4a, 0x3f, 0x48, 0xcd, 0xbc, 0x15, 0x1d, 0xda, 0x6c, 0xd8, 0x97, 0x17, 0x2a,
-	0x80, 0x44, 0xf1, 0x2b, 0x89, 0xe2, 0xef, 0x42, 0x2d, 0x49, 0xbc, 0xac, 0xc4, 0xea, 0xa5, 0x4f,
-	0x8b, 0xbd, 0xf4, 0x8d, 0xe0, 0xea, 0xe2, 0x8e, 0xa7, 0x5c, 0xac, 0x7f, 0x7a, 0xb5, 0x93, 0x14,
-	0x4c, 0x27, 0x2a, 0xf8, 0x18, 0xae, 0x2d, 0x17, 0x27, 0xd5, 0x3c, 0x07, 0x59, 0x3c, 0x37, 0xef,
-	0xa1, 0xb9, 0x81, 0x25, 0x64, 0x7c, 0x0a, 0xe7, 0xdb, 0xcc, 0xe9, 0x27, 0x3d, 0x44, 0x24, 0xf5,
-	0x6f, 0x1e, 0xb6, 0x5d, 0x1d, 0xf7, 0x55, 0x54, 0xf0, 0x14, 0x79, 0xac, 0x3d, 0xd0, 0x66, 0xdb,
-	0x83, 0x84, 0x0a, 0x9a, 0x3a, 0x7d, 0x05, 0x35, 0x3c, 0x38, 0xb7, 0x20, 0x53, 0x5c, 0x62, 0x95,
-	0xcf, 0xc4, 0xbd, 0xb0, 0x4b, 0xd3, 0xa9, 0x02, 0xa3, 0x27, 0xdf, 0x54, 0xfc, 0xc9, 0xf7, 0xf4,
-	0x57, 0x4a, 0xd1, 0xe6, 0x28, 0xf3, 0xe1, 0xe6, 0xbd, 0xf7, 0x1c, 0x35, 0x1d, 0x1d, 0xb5, 0x06,
-	0x79, 0x14, 0xb5, 0xfb, 0x44, 0x45, 0x52, 0x08, 0x1b, 0x7e, 0x74, 0x8e, 0x87, 0x9b, 0xf7, 0xc4,
-	0x24, 0x21, 0xce, 0x91, 0xfc, 0x40, 0x7d, 0x41, 0xf2, 0xe2, 0x83, 0x81, 0x7c, 0xa2, 0x14, 0xbc,
-	0xfa, 0xff, 0xc0, 0x41, 0x1e, 0xc1, 0xc5, 0x98, 0xd0, 0x17, 0x2c, 0xb0, 0xb8, 0x87, 0x86, 0x27,
-	0xa9, 0x41, 0x7e, 0x24, 0x71, 0xea, 0x85, 0x54, 0xc1, 0xc6, 0x5d, 0xa8, 0xc6, 0xb6, 0xee, 0xbd,
-	0x71, 0x78, 0x8b, 0x2d, 0xf7, 0xad, 0x43, 0xc6, 0xe5, 0x08, 0xa5, 0x31, 0x02, 0xc6, 0xff, 0x6a,
-	0x90, 0x69, 0xbe, 0x66, 0x4e, 0x40, 0x6e, 0xf1, 0x13, 0x8d, 0xed, 0x9e, 0x9c, 0xd8, 0x54, 0xca,
-	0xc0, 0xc5, 0x8d, 0x0e, 0x5f, 0xa1, 0x82, 0x20, 0x8c, 0x9f, 0x54, 0x14, 0x3f, 0x61, 0x93, 0x9d,
-	0x8e, 0x35, 0xd9, 0xf7, 0x20, 0x83, 0xfb, 0xc8, 0x3a, 0x54, 0xb6, 0xf7, 0x5a, 0x1d, 0xda, 0xd8,
-	0xee, 0x98, 0xb4, 0xb9, 0xdd, 0xdc, 0xdd, 0xef, 0x54, 0xce, 0x10, 0x02, 0xe5, 0x10, 0xdb, 0x7c,
-	0xd9, 0x6c, 0x75, 0xf8, 0xd4, 0xa6, 0x41, 0xa5, 0x3d, 0xe9, 0xfa, 0x3d, 0xcf, 0xee, 0x86, 0x3e,
-	0x73, 0x07, 0xb2, 0x28, 0x58, 0x04, 0x42, 0xb2, 0x6a, 0x92, 0x82, 0x7c, 0xce, 0x83, 0x66, 0x18,
-	0x30, 0x4f, 0x96, 0x10, 0xf5, 0xd4, 0x3e, 0xcf, 0x74, 0xe3, 0x29, 0x52, 0x51, 0x49, 0x5d, 0xbb,
-	0x0d, 0x59, 0x81, 0xe1, 0x95, 0x56, 0xfd, 0x69, 0x60, 0x86, 0xf1, 0x0e, 0x0a, 0xb5, 0xdb, 0x37,
-	0x1e, 0xc2, 0xd9, 0x18, 0x37, 0x79, 0xbb, 0x06, 0x64, 0x18, 0x57, 0x07, 0xe9, 0xa3, 0xd9, 0x15,
-	0x55, 0xa4, 0x62, 0x69, 0xf3, 0x4f, 0x15, 0x80, 0xc6, 0xd8, 0x6e, 0x33, 0xef, 0xb5, 0xdd, 0x63,
-	0xe4, 0x3b, 0x28, 0xec, 0xb0, 0x40, 0xfd, 0x0b, 0x43, 0x54, 0x0d, 0x88, 0xff, 0xe1, 0x55, 0x3b,
-	0xaf, 0xfe, 0x33, 0x99, 0xfb, 0xaf, 0xc6, 0x58, 0xff, 0xef, 0x3f, 0xfe, 0xf5, 0xa7, 0x54, 0x99,
-	0x14, 0xeb, 0x83, 0x18, 0x8f, 0x0e, 0x14, 0x79, 0x5a, 0x51, 0x63, 0x6f, 0x32, 0x4f, 0xf5, 0x9e,
-	0xbf, 0x30, 0x1d, 0x1b, 0x1f, 0x20, 0xd3, 0x55, 0x52, 0xe2, 0x4c, 0x23, 0x2e, 0x2d, 0x80, 0x1d,
-	0x16, 0xa8, 0x66, 0x2d, 0x91, 0xa7, 0x9a, 0x04, 0xe6, 0xfe, 0x00, 0x33, 0xd6, 0x90, 0x63, 0x89,
-	0x14, 0x38, 0x47, 0xc5, 0xe1, 0x5f, 0xf1, 0xe0, 0x9d, 0xa9, 0x18, 0x27, 0xc9, 0x7a, 0xf8, 0xe4,
-	0x1a, 0x9b, 0x2e, 0x6b, 0xb5, 0xe5, 0x6f, 0xa8, 0xc6, 0x45, 0xe4, 0xfa, 0x01, 0x59, 0xe3, 0x5c,
-	0x15, 0x9f, 0xfa, 0x09, 0x4f, 0x77, 0x6f, 0x49, 0x1f, 0xd6, 0x91, 0xbb, 0x7c, 0xd8, 0xd9, 0x3a,
-	0x16, 0x7c, 0x97, 0x88, 0x59, 0x78, 0xef, 0x35, 0x6e, 0x20, 0xf3, 0x2b, 0xe4, 0x92, 0x60, 0x3e,
-	0xc7, 0x46, 0x49, 0x71, 0xa1, 0x3c, 0x3b, 0x15, 0x93, 0x4b, 0x92, 0x53, 0xe2, 0xb0, 0x5c, 0x5b,
-	0x4f, 0x7a, 0x00, 0x31, 0x6e, 0xa3, 0xac, 0x0f, 0xc9, 0x75, 0x2e, 0x2b, 0xb6, 0x4b, 0x4a, 0xa9,
-	0x9f, 0xa8, 0x69, 0xf7, 0x2d, 0x79, 0x03, 0x95, 0xf9, 0xe9, 0x99, 0x5c, 0x59, 0x10, 0x39, 0x33,
-	0x56, 0x2f, 0x11, 0xfa, 0x29, 0x0a, 0xbd, 0x49, 0x3e, 0x8a, 0x09, 0x15, 0xfb, 0xea, 0x27, 0x62,
-	0xee, 0x9e, 0x11, 0xcc, 0xd0, 0xfa, 0x6a, 0xca, 0xac, 0x46, 0x22, 0x67, 0xdb, 0xae, 0x5a, 0x79,
-	0xb6, 0x59, 0x9b, 0x15, 0x23, 0x91, 0xf5, 0x13, 0xde, 0xb8, 0xbc, 0xad, 0x9f, 0xcc, 0xa7, 0xc2,
-	0xb7, 0xe4, 0xff, 0x35, 0x58, 0x9d, 0xab, 0x19, 0xe4, 0x72, 0x24, 0x2c, 0xa1, 0x96, 0xd4, 0xae,
-	0x2c, 0x5b, 0x96, 0x07, 0xfd, 0x1a, 0x35, 0x78, 0x48, 0x1e, 0xa0, 0x25, 0x63, 0x14, 0xf5, 0x13,
-	0x59, 0x74, 0xde, 0xd6, 0x4f, 0x30, 0x3f, 0x27, 0x6a, 0xf4, 0x2b, 0x0d, 0x9b, 0xa2, 0xb9, 0x8a,
-	0xf2, 0x3e, 0xa5, 0xae, 0xcf, 0x2d, 0x2f, 0xd6, 0x22, 0xe3, 0x1b, 0xd4, 0xeb, 0x31, 0xf9, 0x22,
-	0xd4, 0x2b, 0x22, 0x3a, 0x9d, 0x6a, 0xbf, 0xd6, 0x60, 0x2d, 0xa1, 0x46, 0x2c, 0xe8, 0x36, 0x5b,
-	0xb4, 0x6a, 0xc6, 0xe2, 0xf2, 0x7c, 0x79, 0x31, 0xb6, 0x50, 0xb9, 0xaf, 0xc8, 0xe3, 0xb8, 0x72,
-	0x8a, 0x2a, 0xd2, 0x49, 0x95, 0xb9, 0x44, 0xf5, 0x7e, 0xd2, 0xd0, 0x59, 0x67, 0xea, 0xd0, 0xfb,
-	0x74, 0xbb, 0xba, 0xb8, 0x3c, 0x53, 0xbf, 0x8c, 0x7f, 0x46, 0xc5, 0x1e, 0x91, 0x87, 0x71, 0xc5,
-	0x90, 0xe4, 0x94, 0x5a, 0x89, 0x7c, 0x1b, 0xbe, 0xb2, 0xbc, 0x33, 0xdf, 0xce, 0xbf, 0xde, 0xcc,
-	0xe6, 0xdb, 0x90, 0xc7, 0x00, 0x59, 0x86, 0xe3, 0xc2, 0x85, 0xe8, 0x0c, 0x73, 0xad, 0x74, 0x6d,
-	0x75, 0xae, 0xc3, 0x37, 0x3e, 0x41, 0x86, 0x1f, 0x93, 0x1b, 0x98, 0x6b, 0x25, 0xb6, 0x7e, 0xb2,
-	0x44, 0xf7, 0xe3, 0x99, 0xfe, 0x5c, 0xf6, 0x8b, 0xe4, 0xda, 0xa2, 0xbc, 0xd9, 0x56, 0x3b, 0xee,
-	0x8d, 0x4b, 0xba, 0x61, 0xe3, 0x0a, 0x2a, 0x52, 0x35, 0xd6, 0xe2, 0x8a, 0x48, 0xa2, 0xc7, 0xda,
-	0x1d, 0xf2, 0xa3, 0x86, 0x4d, 0x45, 0x62, 0xaf, 0x4a, 0x3e, 0x5e, 0xca, 0x7f, 0xa6, 0x77, 0xae,
-	0xdd, 0x7c, 0x2f, 0x9d, 0xd4, 0x46, 0x66, 0x5f, 0xe3, 0x42, 0x82, 0x36, 0x82, 0x94, 0xeb, 0xf4,
-	0xef, 0xb0, 0x3a, 0xd7, 0x02, 0x87, 0x77, 0xbf, 0xf8, 0x37, 0x51, 0x98, 0x27, 0x96, 0x74, 0xcd,
-	0x06, 0x41, 0x99, 0x45, 0x23, 0x57, 0xf7, 0x39, 0xc5, 0x94, 0x4b, 0xa0, 0xb0, 0xda, 0x9c, 0xb2,
-	0xde, 0x29, 0x25, 0x2c, 0x56, 0x91, 0x88, 0x27, 0xe3, 0x6c, 0x90, 0xe7, 0xf7, 0xa0, 0x87, 0x8d,
-	0x03, 0x39, 0xbf, 0xa4, 0x31, 0x09, 0xcb, 0xf3, 0x42, 0x8f, 0xa1, 0xca, 0xb3, 0x01, 0x75, 0x5f,
-	0xad, 0x3d, 0xd6, 0xee, 0xdc, 0xd5, 0xba, 0x59, 0x7c, 0xa0, 0xbe, 0xff, 0xf7, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xba, 0x80, 0x7d, 0xb5, 0x66, 0x23, 0x00, 0x00,
+	// 3788 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x39, 0x5d, 0x6f, 0x1b, 0x49,
+	0x72, 0x3b, 0xa4, 0xf8, 0x55, 0xa4, 0x24, 0xba, 0xa5, 0xb3, 0xa9, 0xf1, 0xda, 0x96, 0x67, 0xbd,
+	0xeb, 0x0f, 0xec, 0x8a, 0x6b, 0x79, 0xbd, 0x5e, 0x7b, 0xf7, 0x92, 0xa5, 0x64, 0x5a, 0x2b, 0xd8,
+	0xa6, 0xb4, 0x23, 0xca, 0x9b, 0x0b, 0x12, 0x4c, 0x86, 0x9c, 0xd6, 0x68, 0x62, 0x72, 0x86, 0x99,
+	0x19, 0xda, 0x54, 0x0c, 0xbf, 0x04, 0xc8, 0x43, 0x0e, 0x41, 0x82, 0xc3, 0xbd, 0xe4, 0x21, 0x08,
+	0x70, 0xaf, 0xf7, 0x03, 0x92, 0x00, 0xf9, 0x19, 0xf9, 0x01, 0x01, 0x92, 0x00, 0xf9, 0x01, 0xf7,
+	0x1c, 0x20, 0xe8, 0xea, 0xee, 0xf9, 0xe2, 0xd0, 0x52, 0x10, 0xe4, 0x89, 0x53, 0xd5, 0xd5, 0x55,
+	0xd5, 0xd5, 0x55, 0xd5, 0x55, 0x45, 0x68, 0xfa, 0x93, 0x61, 0x7b, 0x32, 0x68, 0xfb, 0x93, 0xe1,
+	0xd6, 0xc4, 0xf7, 0x42, 0x8f, 0x94, 0xfc, 0xc9, 0x70, 0x32, 0x50, 0x3f, 0xb6, 0x3d, 0xcf, 0x1e,
+	0xd1, 0xb6, 0x39, 0x71, 0xda, 0xa6, 0xeb, 0x7a, 0xa1, 0x19, 0x3a, 0x9e, 0x1b, 0x70, 0x22, 0x6d,
+	0x05, 0x1a, 0xdd, 0xf1, 0x24, 0x3c, 0xd3, 0xe9, 0x9f, 0x4d, 0x69, 0x10, 0x6a, 0x5b, 0x50, 0x3d,
+	0xa4, 0xd4, 0xdf, 0x77, 0x4f, 0x3c, 0xb2, 0x02, 0x05, 0xc7, 0x6a, 0x29, 0x9b, 0xca, 0x9d, 0x9a,
+	0x5e, 0x70, 0x2c, 0x42, 0x60, 0xc9, 0xb4, 0x2c, 0xbf, 0x55, 0x40, 0x0c, 0x7e, 0x6b, 0x7f, 0x0a,
+	0xf5, 0x1e, 0x0d, 0xdf, 0x7a, 0xfe, 0xeb, 0xdc, 0x2d, 0xd7, 0x00, 0x26, 0x94, 0xfa, 0xc6, 0xd0,
+	0x9b, 0xba, 0x21, 0x6e, 0x2c, 0xe9, 0x35, 0x86, 0xd9, 0x65, 0x08, 0xf2, 0x39, 0x20, 0x60, 0x38,
+	0xee, 0x89, 0xd7, 0x2a, 0x6e, 0x16, 0xef, 0xd4, 0xb7, 0x57, 0xb7, 0x50, 0xed, 0x2d, 0xa9, 0x85,
+	0x5e, 0x9d, 0x88, 0x2f, 0xed, 0xb7, 0x0a, 0xac, 0xea, 0x9d, 0x97, 0x88, 0xa5, 0xc1, 0xc4, 0x73,
+	0x03, 0x4a, 0x36, 0xa0, 0x3a, 0x0d, 0xa8, 0x65, 0xf8, 0xe6, 0x18, 0xc5, 0x16, 0xf5, 0x0a, 0x83,
+	0x75, 0x73, 0x4c, 0x3e, 0x81, 0x65, 0xf3, 0x8d, 0xe9, 0x8c, 0xcc, 0xc1, 0x88, 0xe2, 0x7a, 0x01,
+	0xd7, 0x1b, 0x11, 0x92, 0x11, 0x5d, 0x85, 0x5a, 0xe8, 0x85, 0xe6, 0x08, 0x09, 0x8a, 0x48, 0x50,
+	0x45, 0x04, 0x5b, 0xbc, 0x06, 0x10, 0xd0, 0xd1, 0xc8, 0x98, 0xf8, 0xce, 0x90, 0xb6, 0x96, 0x36,
+	0x95, 0x3b, 0x8a, 0x5e, 0x63, 0x98, 0x43, 0x86, 0x60, 0x7b, 0x07, 0xd3, 0x33, 0xb1, 0x5a, 0xc2,
+	0xd5, 0xea, 0x60, 0x7a, 0x86, 0x8b, 0xda, 0xdf, 0x28, 0xd0, 0xec, 0x79, 0x16, 0x4d, 0x69, 0x7b,
+	0x0d, 0x60, 0x30, 0x75, 0x46, 0x96, 0x11, 0x3a, 0x63, 0x2a, 0xcc, 0x54, 0x43, 0x4c, 0xdf, 0x19,
+	0xe3, 0x61, 0x6c, 0x27, 0x34, 0x4e, 0xcd, 0xe0, 0x54, 0x18, 0xb9, 0x62, 0x3b, 0xe1, 0x0f, 0x66,
+	0x70, 0xca, 0x6c, 0x3f, 0xf6, 0x2c, 0x8a, 0x2a, 0xd6, 0x74, 0xfc, 0x26, 0x9f, 0x43, 0xc5, 0xe5,
+	0xb6, 0x47, 0xdd, 0xea, 0xdb, 0x44, 0xd8, 0x2e, 0x71, 0x23, 0xba, 0x24, 0xd1, 0x1e, 0x43, 0xbd,
+	0x33, 0x66, 0x56, 0x7f, 0xe1, 0x8c, 0x9d, 0x90, 0xac, 0x43, 0x29, 0xf4, 0x5e, 0x53, 0x57, 0x68,
+	0xc1, 0x01, 0x86, 0x7d, 0x63, 0x8e, 0xa6, 0x54, 0x88, 0xe7, 0x80, 0xf6, 0x0b, 0x28, 0x77, 0x86,
+	0xcc, 0x6b, 0x88, 0x0a, 0xd5, 0xa1, 0xe7, 0x86, 0xbe, 0x39, 0x0c, 0xc5, 0xc6, 0x08, 0x26, 0x37,
+	0xa0, 0x6e, 0x22, 0x95, 0xe1, 0x9a, 0x63, 0xc9, 0x01, 0x38, 0xaa, 0x67, 0x8e, 0x29, 0x3b, 0x83,
+	0x65, 0x86, 0xa6, 0x3c, 0x03, 0xfb, 0xd6, 0xfe, 0x6d, 0x09, 0x6a, 0xfd, 0x99, 0x4e, 0x87, 0xd4,
+	0x99, 0x84, 0xe4, 0x0a, 0x54, 0xc2, 0x19, 0x3f, 0x3f, 0xe7, 0x5e, 0x0e, 0x67, 0x78, 0xfc, 0xab,
+	0x50, 0xb3, 0xcd, 0xc0, 0x98, 0x06, 0xa6, 0xcd, 0x39, 0x2b, 0x7a, 0xd5, 0x36, 0x83, 0x63, 0x06,
+	0x93, 0x6f, 0xa1, 0xe6, 0x9b, 0x63, 0xb1, 0xc8, 0xbd, 0xe8, 0xba, 0xb0, 0x44, 0xc4, 0x7a, 0x4b,
+	0x37, 0xc7, 0x48, 0xdd, 0x75, 0x43, 0xff, 0x4c, 0xaf, 0xfa, 0x02, 0x24, 0xdf, 0x41, 0x3d, 0x08,
+	0xcd, 0x70, 0x1a, 0x18, 0x43, 0x66, 0x5f, 0x66, 0xc8, 0x95, 0xed, 0xab, 0x73, 0xdb, 0x8f, 0x90,
+	0x66, 0xd7, 0xb3, 0xa8, 0x0e, 0x41, 0xf4, 0x4d, 0x5a, 0x50, 0x19, 0xd3, 0x00, 0x05, 0x97, 0xf8,
+	0x85, 0x09, 0x90, 0xad, 0xf8, 0x34, 0x9c, 0xfa, 0x6e, 0xd0, 0x2a, 0x6f, 0x16, 0xd9, 0x8a, 0x00,
+	0xc9, 0x57, 0x50, 0xf5, 0x39, 0xd7, 0xa0, 0x55, 0x41, 0x6d, 0x5b, 0xf3, 0xda, 0xf2, 0x5f, 0x3d,
+	0xa2, 0x54, 0xbf, 0x85, 0xe5, 0xd4, 0x11, 0x48, 0x13, 0x8a, 0xaf, 0xe9, 0x99, 0xb0, 0x13, 0xfb,
+	0x4c, 0x5f, 0x5e, 0x51, 0x5c, 0xde, 0x93, 0xc2, 0x37, 0x8a, 0xfa, 0x3d, 0x54, 0xa4, 0x89, 0xaf,
+	0x42, 0xed, 0x64, 0xea, 0x0e, 0xf9, 0x1d, 0x89, 0x2b, 0x64, 0x08, 0xbc, 0xa1, 0x16, 0x54, 0xd8,
+	0x75, 0x52, 0x11, 0xab, 0x35, 0x5d, 0x82, 0xda, 0x3f, 0x29, 0x00, 0xb1, 0x0d, 0x48, 0x1d, 0x2a,
+	0x47, 0xc7, 0xbb, 0xbb, 0xdd, 0xa3, 0xa3, 0xe6, 0x47, 0x64, 0x15, 0xea, 0x7b, 0x9d, 0x23, 0x43,
+	0x3f, 0xee, 0x19, 0x07, 0xc7, 0xfd, 0xa6, 0x42, 0x2e, 0x03, 0xd9, 0xe9, 0xbc, 0xe8, 0xf4, 0x76,
+	0xbb, 0x46, 0xef, 0xa0, 0x6f, 0x74, 0x7b, 0x07, 0xc7, 0x7b, 0x3f, 0x34, 0x0b, 0x64, 0x0d, 0x56,
+	0x7f, 0xd2, 0x0f, 0x7a, 0x7b, 0xc6, 0x61, 0x47, 0xef, 0xbc, 0xec, 0xf6, 0xbb, 0x7a, 0xb3, 0x48,
+	0x2e, 0xc1, 0xb2, 0x7e, 0xdc, 0xeb, 0xef, 0xbf, 0xec, 0x1a, 0x5d, 0x5d, 0x3f, 0xd0, 0x9b, 0x4b,
+	0x8c, 0x3b, 0x83, 0x19, 0xb3, 0x52, 0xbc, 0xa9, 0xff, 0x07, 0xc6, 0xb3, 0x03, 0xfd, 0x65, 0xa7,
+	0xdf, 0x2c, 0x33, 0x09, 0x4f, 0x8f, 0x0f, 0x5f, 0xec, 0xef, 0x76, 0xfa, 0x5d, 0xe3, 0xa8, 0xdb,
+	0x37, 0x76, 0x0f, 0x9e, 0x76, 0x9b, 0x15, 0xc6, 0xec, 0xb8, 0xf7, 0xbc, 0x77, 0xf0, 0x53, 0x4f,
+	0x30, 0xab, 0x6a, 0xbf, 0x2d, 0x42, 0xbd, 0xef, 0x9b, 0x6e, 0xc0, 0x3d, 0x91, 0x79, 0x61, 0xc2,
+	0xc1, 0xf0, 0x9b, 0xe1, 0x30, 0x22, 0xb9, 0xe1, 0xf0, 0x9b, 0x5c, 0x07, 0xa0, 0xb3, 0x89, 0xe3,
+	0x63, 0xba, 0x14, 0xa9, 0x21, 0x81, 0x91, 0x2e, 0x89, 0x50, 0x6b, 0x29, 0x72, 0x49, 0x9d, 0xc1,
+	0x72, 0x71, 0xc4, 0x42, 0x4d, 0xa6, 0x06, 0xdb, 0x0c, 0xa2, 0xd0, 0xb3, 0xe8, 0xc8, 0x3c, 0x6b,
+	0x95, 0xf9, 0x3d, 0x21, 0xc0, 0x82, 0x7f, 0x78, 0x6a, 0x3a, 0xae, 0xe1, 0x58, 0xad, 0xca, 0xa6,
+	0x72, 0x67, 0x59, 0xaf, 0x20, 0xbc, 0x6f, 0x91, 0xdb, 0x50, 0xe1, 0xca, 0x07, 0xad, 0x2a, 0x3a,
+	0xcc, 0xb2, 0x70, 0x18, 0x1e, 0x95, 0xba, 0x5c, 0x65, 0xf7, 0x17, 0x38, 0xb6, 0x4b, 0xfd, 0xa0,
+	0x55, 0xe3, 0x4e, 0x27, 0x40, 0xf2, 0x31, 0xd4, 0x26, 0xd3, 0xc1, 0xc8, 0x09, 0x4e, 0xa9, 0xdf,
+	0x02, 0x9e, 0x78, 0x22, 0x04, 0x0b, 0x5d, 0x9f, 0x9e, 0x50, 0xdf, 0xa7, 0x96, 0x11, 0xce, 0x5a,
+	0x75, 0x1e, 0xba, 0x12, 0xd5, 0x9f, 0x91, 0x87, 0xd0, 0x30, 0x31, 0x79, 0x88, 0x23, 0x35, 0x36,
+	0x8b, 0x89, 0x7c, 0x93, 0xc8, 0x2b, 0x7a, 0xdd, 0x8c, 0x01, 0xd2, 0x06, 0x08, 0x67, 0x86, 0xf0,
+	0xe1, 0xd6, 0x32, 0x26, 0xa9, 0x66, 0xd6, 0xd9, 0xf5, 0x5a, 0x28, 0x3f, 0xb5, 0x7f, 0x51, 0x60,
+	0x2d, 0x71, 0x59, 0x51, 0xe2, 0x7c, 0x0c, 0x65, 0x1e, 0x75, 0x78, 0x6d, 0x2b, 0xdb, 0x37, 0x25,
+	0x93, 0x79, 0x5a, 0x11, 0xaa, 0xba, 0xd8, 0x40, 0xbe, 0x82, 0x7a, 0x18, 0x53, 0xe1, 0x15, 0xc7,
+	0x9a, 0x27, 0xf7, 0x27, 0xc9, 0xb4, 0x07, 0x50, 0xe6, 0x7c, 0x98, 0x33, 0x1e, 0x76, 0x7b, 0x4f,
+	0xf7, 0x7b, 0x7b, 0xcd, 0x8f, 0x08, 0x40, 0xf9, 0xb0, 0xb3, 0xfb, 0xbc, 0xfb, 0xb4, 0xa9, 0x90,
+	0x26, 0x34, 0xf6, 0x75, 0xbd, 0xfb, 0xaa, 0xab, 0x1f, 0xed, 0xef, 0xbc, 0xe8, 0x36, 0x0b, 0xda,
+	0x3f, 0x2b, 0x50, 0x3b, 0x72, 0x6c, 0xd7, 0x0c, 0xa7, 0x3e, 0x25, 0xdf, 0x40, 0xcd, 0x1c, 0xd9,
+	0x9e, 0xef, 0x84, 0xa7, 0x63, 0xa1, 0xb6, 0x2a, 0xc4, 0x46, 0x44, 0x5b, 0x1d, 0x49, 0xa1, 0xc7,
+	0xc4, 0xec, 0xb2, 0x02, 0x49, 0x81, 0x0a, 0x37, 0xf4, 0x18, 0x81, 0x6f, 0x2a, 0xbb, 0xb9, 0xa1,
+	0xc1, 0xe2, 0xbf, 0xc8, 0x97, 0x39, 0xe6, 0x39, 0x3d, 0xd3, 0xbe, 0x82, 0x5a, 0xc4, 0x94, 0x29,
+	0x2f, 0xe2, 0xa1, 0xf9, 0x11, 0x59, 0x86, 0xda, 0x51, 0x77, 0xf7, 0x70, 0xfb, 0xe1, 0xd7, 0xcf,
+	0xef, 0x37, 0x15, 0xb6, 0xd6, 0x7d, 0xba, 0xfd, 0xf0, 0xe1, 0xfd, 0xc7, 0xcd, 0x82, 0xf6, 0x8f,
+	0x45, 0x20, 0x29, 0x63, 0x62, 0x39, 0x10, 0x05, 0x86, 0xb2, 0x30, 0x30, 0x0a, 0x1f, 0x0e, 0x8c,
+	0xe2, 0x87, 0x02, 0x63, 0x69, 0x51, 0x60, 0x94, 0x16, 0x05, 0x46, 0x79, 0x61, 0x60, 0x54, 0x3e,
+	0x18, 0x18, 0x59, 0xff, 0xad, 0x5e, 0xcc, 0x7f, 0x17, 0xc7, 0xd3, 0x97, 0x00, 0xd1, 0x8d, 0x04,
+	0x2d, 0xd8, 0x2c, 0x26, 0x3c, 0x3b, 0xba, 0x5d, 0x3d, 0x41, 0x93, 0x8e, 0xc0, 0x7a, 0x36, 0x02,
+	0x1f, 0xc1, 0x4a, 0x04, 0x18, 0x81, 0x63, 0x07, 0xad, 0xc6, 0x02, 0x9e, 0xcb, 0x11, 0xdd, 0x91,
+	0x63, 0x07, 0xda, 0x7f, 0x14, 0xa1, 0xb4, 0x33, 0xf2, 0x86, 0xaf, 0x73, 0x13, 0x5b, 0x0b, 0x2a,
+	0x6f, 0xa8, 0x1f, 0xc4, 0x17, 0x25, 0x41, 0x16, 0xf2, 0x13, 0xd3, 0xa7, 0xae, 0x28, 0x37, 0xf8,
+	0x9b, 0x0c, 0x1c, 0x85, 0x4f, 0xee, 0x2d, 0x58, 0x09, 0x67, 0xc6, 0x98, 0xfa, 0xaf, 0x47, 0x94,
+	0xd3, 0x2c, 0x21, 0x4d, 0x23, 0x9c, 0xbd, 0x44, 0x24, 0x52, 0x3d, 0x80, 0xcb, 0x71, 0x84, 0xa7,
+	0xa8, 0xf9, 0x7b, 0xb8, 0x16, 0xc5, 0x76, 0x62, 0xd3, 0x65, 0x28, 0xbb, 0xd3, 0xf1, 0x80, 0xfa,
+	0x22, 0x03, 0x0a, 0x88, 0x69, 0xfb, 0xd6, 0x09, 0x5d, 0x1a, 0x04, 0x98, 0x01, 0x6b, 0xba, 0x04,
+	0x23, 0x3f, 0xac, 0x26, 0xfc, 0x30, 0x55, 0x13, 0xd4, 0x32, 0x35, 0xc1, 0x06, 0x54, 0xc3, 0x99,
+	0x28, 0x3b, 0x81, 0x9f, 0x3c, 0x9c, 0xf1, 0xa2, 0xf3, 0x53, 0x58, 0xc2, 0x7a, 0xb3, 0x8e, 0x99,
+	0xe0, 0x92, 0x30, 0x30, 0xda, 0x70, 0x0b, 0x4b, 0x26, 0x5c, 0x26, 0x5f, 0x43, 0x23, 0x91, 0x10,
+	0x82, 0x4c, 0xca, 0x4b, 0xc6, 0x4a, 0x8a, 0x4e, 0x3d, 0x82, 0x25, 0xc6, 0x25, 0xaa, 0xd8, 0x14,
+	0x2c, 0x7a, 0xf1, 0x9b, 0x1d, 0x3c, 0x3c, 0xf5, 0xa9, 0x69, 0x89, 0x52, 0x58, 0x40, 0xec, 0x32,
+	0x06, 0x66, 0x38, 0x3c, 0x35, 0x1c, 0xd7, 0xa2, 0x33, 0xac, 0x61, 0x4a, 0x3a, 0x20, 0x6a, 0x9f,
+	0x61, 0xb4, 0x5f, 0x29, 0xb0, 0x8c, 0x1a, 0x46, 0x19, 0xf1, 0x41, 0x26, 0x23, 0x5e, 0x4d, 0x9e,
+	0x63, 0x51, 0x2e, 0xd4, 0xa0, 0x34, 0x60, 0xeb, 0x22, 0x0b, 0x36, 0x52, 0x7b, 0xf8, 0x92, 0x76,
+	0x3b, 0x3f, 0xf3, 0x65, 0xb3, 0x9d, 0xa2, 0xfd, 0xa6, 0x00, 0x97, 0x76, 0x31, 0x10, 0x33, 0x05,
+	0xb9, 0x4b, 0xc3, 0x64, 0x79, 0xc1, 0x2a, 0x50, 0xac, 0x2e, 0xee, 0x42, 0x13, 0x9b, 0x8e, 0xa1,
+	0x37, 0x32, 0x92, 0x5e, 0x59, 0xd3, 0x57, 0x25, 0xfe, 0x15, 0x47, 0xa7, 0x62, 0xbe, 0x98, 0x8e,
+	0xf9, 0x6b, 0x00, 0xa7, 0xd4, 0xb4, 0x0c, 0x7e, 0x90, 0x25, 0xbc, 0xdb, 0x1a, 0xc3, 0xf0, 0x28,
+	0xf8, 0x0c, 0x56, 0xe3, 0xe5, 0xa4, 0x27, 0x2e, 0x47, 0x34, 0xb2, 0xa2, 0x1c, 0x39, 0x03, 0xc1,
+	0x85, 0xbb, 0x61, 0x75, 0xe4, 0x0c, 0x38, 0x93, 0x5b, 0xb0, 0x12, 0x2d, 0x72, 0x1e, 0xdc, 0x1f,
+	0x1b, 0x92, 0x02, 0x59, 0xdc, 0x84, 0x86, 0xf0, 0x4f, 0x63, 0xe4, 0x04, 0x3c, 0xa9, 0xd4, 0xf4,
+	0xba, 0xc0, 0xbd, 0x70, 0x82, 0x50, 0xfb, 0x04, 0x96, 0xfb, 0x58, 0xc1, 0x26, 0x12, 0x6a, 0x36,
+	0x48, 0xb5, 0x3d, 0xf8, 0xd9, 0x1e, 0x0d, 0x91, 0xef, 0xce, 0xd9, 0x39, 0xc4, 0xbc, 0x02, 0x1f,
+	0x4f, 0x46, 0x34, 0xe4, 0x4f, 0x43, 0x55, 0x8f, 0x60, 0xed, 0x25, 0x5c, 0x89, 0x19, 0xf5, 0x30,
+	0xa6, 0x24, 0xab, 0x38, 0xe4, 0x94, 0x54, 0xc8, 0x7d, 0x88, 0xdd, 0xb7, 0xb0, 0xfc, 0xcc, 0xf7,
+	0xfe, 0x9c, 0xba, 0x3b, 0xe6, 0xc8, 0x74, 0x87, 0xe8, 0xbe, 0x3c, 0x3b, 0x22, 0x13, 0x45, 0x17,
+	0x50, 0x5e, 0xf9, 0xa4, 0xfd, 0x31, 0x54, 0x5f, 0x79, 0x21, 0xb6, 0x3f, 0x6c, 0x9f, 0x37, 0xc1,
+	0xd7, 0x42, 0x54, 0xf5, 0x1c, 0xc2, 0x82, 0xd5, 0x0b, 0x69, 0x10, 0x75, 0x1b, 0x0c, 0x60, 0x7d,
+	0xdb, 0x70, 0x44, 0x4d, 0x56, 0x8b, 0xf0, 0x55, 0x9e, 0x9b, 0x1a, 0x02, 0xc9, 0xb8, 0x06, 0xda,
+	0x09, 0x34, 0xf7, 0xc4, 0x9b, 0x12, 0xb9, 0xde, 0x1d, 0x68, 0x8e, 0xbc, 0xb7, 0x34, 0x08, 0x8d,
+	0xf8, 0xfd, 0xe1, 0x8a, 0xae, 0x70, 0xbc, 0xdc, 0xc1, 0x28, 0xc7, 0xd4, 0x72, 0x4c, 0x37, 0x41,
+	0xc9, 0xbb, 0x8a, 0x15, 0x8e, 0x97, 0x94, 0xda, 0x7f, 0xd7, 0xa0, 0xd2, 0x19, 0x0e, 0xe5, 0x31,
+	0x13, 0x6e, 0x8d, 0xdf, 0x2c, 0x65, 0x0d, 0xb8, 0x75, 0x04, 0x03, 0x09, 0x92, 0xfb, 0xc0, 0xb2,
+	0x91, 0x6c, 0x6d, 0x59, 0xb8, 0x5d, 0x8e, 0x1e, 0x27, 0xe4, 0xb7, 0xb5, 0x67, 0x06, 0xbc, 0x45,
+	0xb3, 0xf9, 0x07, 0xdb, 0xc2, 0x1a, 0x19, 0xdc, 0xb2, 0x94, 0xbb, 0x45, 0xb6, 0xbf, 0x15, 0xdf,
+	0x1c, 0xe3, 0x96, 0x0e, 0xd4, 0x27, 0xd4, 0x1f, 0x3b, 0x41, 0x80, 0x49, 0xaa, 0x84, 0x49, 0xea,
+	0x46, 0x66, 0xd7, 0x61, 0x4c, 0xc1, 0xdb, 0x9f, 0xe4, 0x1e, 0xb2, 0x0d, 0x65, 0xdb, 0xf7, 0xa6,
+	0x13, 0xde, 0xa8, 0xd4, 0xb7, 0xd5, 0xcc, 0xee, 0x3d, 0x5c, 0xe4, 0x1b, 0x05, 0x25, 0xf9, 0x39,
+	0xac, 0x9e, 0xa0, 0x6b, 0x18, 0xe2, 0xb8, 0xf2, 0x01, 0x5e, 0x17, 0x9b, 0x53, 0x8e, 0xa3, 0xaf,
+	0x9c, 0x24, 0xc1, 0x80, 0x6c, 0x01, 0xb0, 0xab, 0xc5, 0x93, 0xca, 0x9a, 0x56, 0x36, 0xfe, 0xd2,
+	0x6b, 0xf4, 0xda, 0x1b, 0xf1, 0x15, 0xa8, 0xbf, 0x07, 0x70, 0x38, 0xa2, 0x96, 0x8d, 0x20, 0xb3,
+	0xf9, 0x04, 0x21, 0x5f, 0x66, 0x18, 0x01, 0x26, 0x1c, 0xb4, 0x90, 0x74, 0x50, 0xf5, 0x77, 0x0a,
+	0x54, 0x84, 0xb5, 0xd1, 0xbd, 0xa6, 0x3e, 0xbe, 0x7c, 0xd8, 0xe8, 0x0b, 0x17, 0x69, 0x08, 0x64,
+	0x9f, 0xe1, 0x58, 0xaa, 0xc2, 0xa4, 0x7e, 0x42, 0x7d, 0x1c, 0x1f, 0xd8, 0x66, 0x20, 0x58, 0xae,
+	0x26, 0xf1, 0x7b, 0x66, 0x80, 0xe5, 0x18, 0x8a, 0x47, 0x22, 0x5e, 0xef, 0xd4, 0x38, 0x86, 0x2d,
+	0x7f, 0x0a, 0x2b, 0x8e, 0x3b, 0xf4, 0xa9, 0x19, 0x50, 0x23, 0x98, 0x50, 0x6a, 0x89, 0xaa, 0x67,
+	0x59, 0x62, 0x8f, 0x18, 0x92, 0x85, 0x42, 0xb2, 0x59, 0xe0, 0x00, 0xf9, 0x0e, 0x1a, 0x9c, 0x93,
+	0xc5, 0x9d, 0x82, 0x5f, 0xd0, 0x46, 0xf6, 0x7a, 0x23, 0xd3, 0xe8, 0x75, 0x41, 0xce, 0x00, 0xf5,
+	0x47, 0xa8, 0x08, 0x7f, 0x61, 0xc5, 0x47, 0x34, 0xf6, 0x10, 0x19, 0x20, 0x46, 0x30, 0xc7, 0x66,
+	0x43, 0x13, 0x19, 0xbf, 0xd3, 0x80, 0x2b, 0xc4, 0xcd, 0xc3, 0x3b, 0x1f, 0x0e, 0xa8, 0x2e, 0x2c,
+	0xed, 0x87, 0x74, 0x3c, 0x37, 0xe7, 0xb9, 0x0e, 0x75, 0x27, 0x60, 0xf5, 0xa8, 0x31, 0x31, 0x1d,
+	0x5f, 0x64, 0x92, 0x9a, 0x13, 0x3c, 0xa7, 0x67, 0x87, 0xa6, 0x83, 0x17, 0xf3, 0x96, 0x3a, 0xf6,
+	0x69, 0x28, 0xd8, 0x09, 0x88, 0xd5, 0x92, 0xb1, 0x2b, 0x8a, 0x02, 0x23, 0x81, 0x51, 0x9f, 0x41,
+	0x09, 0xdd, 0x2f, 0x37, 0xf6, 0xee, 0x42, 0xc9, 0x09, 0xe9, 0x98, 0xdd, 0x0c, 0x33, 0xcb, 0x5a,
+	0xc6, 0x2c, 0x4c, 0x51, 0x9d, 0x53, 0xa8, 0xbf, 0x54, 0x00, 0xe2, 0x28, 0xc8, 0xe5, 0x76, 0x03,
+	0xea, 0xe8, 0xdc, 0xf8, 0x74, 0x71, 0x9e, 0x35, 0x1d, 0x10, 0xc5, 0x5e, 0xaf, 0x20, 0x16, 0x57,
+	0x3c, 0x4f, 0x1c, 0x33, 0x37, 0x7b, 0xd9, 0x83, 0x53, 0x6f, 0x64, 0xc9, 0x27, 0x2a, 0x42, 0xa8,
+	0xbf, 0x80, 0x66, 0x36, 0x22, 0x73, 0xba, 0xf9, 0x76, 0xb2, 0x9b, 0xcf, 0xb9, 0xf4, 0x88, 0x43,
+	0xb2, 0xd1, 0x3f, 0x80, 0x7a, 0x22, 0x5c, 0x73, 0xb8, 0xde, 0x4b, 0x73, 0x5d, 0xcf, 0x8b, 0xf5,
+	0x04, 0x43, 0xed, 0x47, 0xb8, 0xb4, 0x47, 0x43, 0xb1, 0x9c, 0x78, 0x97, 0xe6, 0xcc, 0x77, 0x07,
+	0x9a, 0x83, 0x33, 0x63, 0xe4, 0xb9, 0x36, 0x4b, 0xc0, 0xf8, 0x58, 0x0b, 0x37, 0x58, 0x19, 0x9c,
+	0xbd, 0xe0, 0x68, 0xac, 0x16, 0xb4, 0xdf, 0x29, 0x50, 0xdd, 0x95, 0x43, 0xa3, 0x9c, 0x19, 0x23,
+	0xce, 0x61, 0xc4, 0x8c, 0x91, 0x7d, 0xb3, 0x37, 0x6a, 0x64, 0xba, 0xf6, 0x94, 0x8f, 0x77, 0x18,
+	0x3e, 0x82, 0x93, 0x05, 0x2e, 0xf7, 0x1e, 0x09, 0x92, 0xdb, 0xb0, 0x64, 0x0e, 0x1c, 0x99, 0x12,
+	0xe5, 0x6d, 0x49, 0xc1, 0x5b, 0x9d, 0x9d, 0x7d, 0x1d, 0x09, 0x54, 0x0b, 0x8a, 0x9d, 0x9d, 0xfd,
+	0xdc, 0x43, 0xb1, 0x89, 0xa7, 0x6f, 0x4b, 0x67, 0xc0, 0xef, 0xb9, 0x56, 0xa2, 0x78, 0xa1, 0x56,
+	0x42, 0xeb, 0x01, 0xd9, 0xa3, 0xa1, 0x14, 0x2f, 0x2d, 0x99, 0x3d, 0xfe, 0xc5, 0xad, 0xf8, 0x1e,
+	0x36, 0x12, 0xfc, 0x8e, 0x42, 0xcf, 0x37, 0x6d, 0xba, 0x88, 0xad, 0xf0, 0x83, 0x42, 0x6a, 0x56,
+	0x74, 0xe2, 0xd0, 0x91, 0x25, 0x0c, 0xca, 0x81, 0x5c, 0xf1, 0x4b, 0xb9, 0xe2, 0xbf, 0x04, 0x35,
+	0x4f, 0xbc, 0x78, 0x89, 0xe5, 0xa4, 0x4f, 0x49, 0x4c, 0xfa, 0xc6, 0x70, 0x63, 0x7e, 0xc7, 0x33,
+	0x26, 0x36, 0xb8, 0xb8, 0xda, 0x79, 0x0a, 0x16, 0x73, 0x15, 0x7c, 0x02, 0x9b, 0x8b, 0xc5, 0x09,
+	0x35, 0x2f, 0x43, 0x19, 0xcf, 0xcd, 0x6a, 0x68, 0x76, 0xc1, 0x02, 0xd2, 0xbe, 0x80, 0x2b, 0x47,
+	0xd4, 0xb5, 0xf2, 0x06, 0x11, 0x79, 0xf5, 0x9b, 0x8f, 0x65, 0x57, 0xdf, 0x7b, 0x1d, 0xbd, 0x70,
+	0x11, 0x79, 0xa2, 0x3c, 0x50, 0xd2, 0xe5, 0x41, 0xce, 0x0b, 0x5a, 0xb8, 0xf8, 0x0b, 0xaa, 0xf9,
+	0x70, 0x79, 0x4e, 0x26, 0x37, 0x62, 0x8b, 0xf5, 0xc4, 0xc3, 0xa8, 0x4a, 0xab, 0xe9, 0x12, 0x8c,
+	0x47, 0xbe, 0x85, 0xe4, 0xc8, 0xf7, 0xe2, 0x26, 0xd5, 0x41, 0x95, 0x32, 0x1f, 0x6d, 0xdf, 0x3f,
+	0xe7, 0xa8, 0xc5, 0xf8, 0xa8, 0x2a, 0x54, 0x51, 0xd4, 0xfe, 0x53, 0x19, 0x49, 0x11, 0xac, 0x05,
+	0xf1, 0x39, 0x1e, 0x6d, 0xdf, 0xe7, 0x9d, 0x04, 0x3f, 0x47, 0xfe, 0x80, 0x7a, 0x43, 0xf0, 0x62,
+	0x8d, 0x81, 0x18, 0x51, 0x72, 0x5e, 0xd6, 0xff, 0xe2, 0x20, 0x8f, 0xe1, 0x6a, 0x42, 0xe8, 0x4b,
+	0x1a, 0x9a, 0xcc, 0x43, 0xa3, 0x93, 0xa8, 0x50, 0x1d, 0x0b, 0x9c, 0x9c, 0x90, 0x4a, 0x58, 0xfb,
+	0x12, 0x5a, 0x89, 0xad, 0x07, 0x6f, 0x5d, 0xea, 0x47, 0xfb, 0xd6, 0xa1, 0xe4, 0x31, 0x84, 0xd4,
+	0x18, 0x01, 0xed, 0x1f, 0x14, 0x28, 0x75, 0xdf, 0x50, 0x37, 0x24, 0x77, 0xd8, 0x89, 0x26, 0xce,
+	0x50, 0x74, 0x6c, 0x32, 0x65, 0xe0, 0xe2, 0x56, 0x9f, 0xad, 0xe8, 0x9c, 0x20, 0x8a, 0x9f, 0x42,
+	0x1c, 0x3f, 0x51, 0x91, 0x5d, 0x4c, 0x14, 0xd9, 0xbb, 0x50, 0xc2, 0x7d, 0x64, 0x1d, 0x9a, 0xbb,
+	0x07, 0xbd, 0xbe, 0xde, 0xd9, 0xed, 0x1b, 0x7a, 0x77, 0xb7, 0xbb, 0x7f, 0xd8, 0x6f, 0x7e, 0x44,
+	0x08, 0xac, 0x44, 0xd8, 0xee, 0xab, 0x6e, 0xaf, 0xcf, 0xa7, 0x56, 0xdd, 0x1f, 0x8f, 0xf7, 0x5f,
+	0x1d, 0xec, 0x76, 0xfa, 0xfb, 0x07, 0xbd, 0x66, 0x41, 0xfb, 0x8d, 0x02, 0xcd, 0xa3, 0xe9, 0x20,
+	0x18, 0xfa, 0xce, 0x20, 0xf2, 0xa2, 0x7b, 0x50, 0x46, 0x55, 0x78, 0x68, 0xe4, 0x2b, 0x2b, 0x28,
+	0xc8, 0xd7, 0x2c, 0x8c, 0x46, 0x21, 0xf5, 0xc5, 0xa3, 0x22, 0x87, 0xef, 0x59, 0xa6, 0x5b, 0xcf,
+	0x90, 0x4a, 0x17, 0xd4, 0xea, 0x5d, 0x28, 0x73, 0x0c, 0x7b, 0x7b, 0xe5, 0xdf, 0x08, 0x46, 0x94,
+	0x01, 0x40, 0xa2, 0xf6, 0x2d, 0xed, 0x11, 0x5c, 0x4a, 0x70, 0x13, 0xf6, 0xd6, 0xa0, 0x44, 0x99,
+	0x3a, 0x2d, 0x25, 0xd5, 0xcd, 0xa2, 0x8a, 0x3a, 0x5f, 0xd2, 0xb6, 0x30, 0xed, 0x76, 0xdf, 0x38,
+	0x16, 0x4d, 0xc7, 0x88, 0x1c, 0x34, 0x28, 0xa9, 0x41, 0x83, 0xf6, 0x4b, 0x05, 0xaa, 0x92, 0x7a,
+	0xd1, 0x44, 0x45, 0x6e, 0x2d, 0xa4, 0xb6, 0x26, 0x5a, 0xac, 0x62, 0xaa, 0xc5, 0xba, 0x05, 0x65,
+	0x6c, 0x24, 0x83, 0xd6, 0xd2, 0x66, 0x31, 0xa1, 0x27, 0xef, 0xba, 0xc5, 0x5a, 0x74, 0xe5, 0x25,
+	0x9c, 0xe7, 0xe1, 0xb7, 0xf6, 0x14, 0xd6, 0x52, 0xca, 0x8b, 0x73, 0x7f, 0x01, 0x35, 0x2a, 0x70,
+	0xfc, 0x7a, 0xe2, 0xe2, 0x39, 0xa2, 0x8d, 0x29, 0xb4, 0x07, 0x98, 0x9e, 0x0e, 0x7d, 0xcf, 0x9a,
+	0x0e, 0xa9, 0xcf, 0x7a, 0xfb, 0xe0, 0x7c, 0x3b, 0xfc, 0xb5, 0x02, 0x8d, 0xe4, 0x96, 0xc5, 0xa4,
+	0x2c, 0x5c, 0x26, 0x9c, 0x52, 0x56, 0x90, 0x11, 0xcc, 0x6c, 0xc2, 0xaa, 0x14, 0x6a, 0x49, 0x9b,
+	0x70, 0x88, 0x9d, 0x76, 0x64, 0x86, 0x54, 0xd4, 0x46, 0xf8, 0xcd, 0xf8, 0x78, 0xfe, 0xe4, 0xd4,
+	0x74, 0xa9, 0x25, 0x06, 0x80, 0x11, 0xac, 0x75, 0x31, 0xec, 0x32, 0x67, 0x10, 0xe6, 0xb8, 0x0b,
+	0xa5, 0x80, 0x21, 0x5a, 0x4a, 0xea, 0xa5, 0x4f, 0x12, 0xeb, 0x9c, 0x42, 0x6b, 0xc7, 0x9d, 0xf6,
+	0x0f, 0xd4, 0xb4, 0xce, 0x6d, 0x8f, 0xb5, 0xbf, 0x54, 0x60, 0x2d, 0x45, 0xfe, 0x7f, 0x99, 0xbe,
+	0x5c, 0x86, 0xf2, 0x29, 0xb2, 0x11, 0x33, 0x5d, 0x01, 0xcd, 0xcd, 0x11, 0x8a, 0xf3, 0x73, 0x04,
+	0xae, 0x38, 0xe3, 0x47, 0x9f, 0x3a, 0x2c, 0x91, 0x9d, 0xa7, 0xf8, 0x5f, 0x29, 0xb0, 0x96, 0x22,
+	0x8f, 0x9f, 0xbc, 0x3c, 0xfa, 0xc8, 0xd5, 0x0b, 0x09, 0x57, 0xff, 0x04, 0x96, 0xdf, 0xfa, 0x4e,
+	0x48, 0x03, 0xc3, 0x42, 0x26, 0xb2, 0x11, 0xe7, 0x48, 0xce, 0x98, 0x29, 0xcf, 0x8e, 0x47, 0x25,
+	0x0d, 0xaf, 0xc2, 0xea, 0x41, 0x2c, 0x5b, 0xfb, 0x1e, 0xd6, 0xa5, 0xf2, 0x87, 0xbe, 0xe7, 0x9d,
+	0x9c, 0xa3, 0xfb, 0xfc, 0xb3, 0xaf, 0xfd, 0x97, 0x02, 0x24, 0xb9, 0xff, 0xff, 0xe3, 0x16, 0x2e,
+	0x43, 0x99, 0xce, 0x9c, 0x20, 0x0c, 0xc4, 0xa3, 0x21, 0xa0, 0xf8, 0x5f, 0xb5, 0xa5, 0xc4, 0x5f,
+	0xa2, 0xcc, 0x59, 0x03, 0x67, 0x30, 0x72, 0x5c, 0x9b, 0x57, 0x98, 0x0d, 0x3d, 0x82, 0x71, 0xb4,
+	0x44, 0xcd, 0x13, 0x63, 0x62, 0x86, 0xa7, 0x38, 0x5a, 0x6a, 0xe8, 0x55, 0x86, 0x38, 0x34, 0xc3,
+	0x53, 0xd6, 0x2e, 0xe2, 0x22, 0xe7, 0x59, 0xc1, 0x55, 0x24, 0x7f, 0xc5, 0x10, 0xdb, 0xff, 0xbe,
+	0x0e, 0xd0, 0x99, 0x38, 0x47, 0xd4, 0x7f, 0xc3, 0xfe, 0x62, 0xfe, 0x11, 0xea, 0x7b, 0x34, 0x94,
+	0xff, 0x23, 0x13, 0xe9, 0xdb, 0xc9, 0xbf, 0xec, 0xd5, 0x2b, 0x02, 0x99, 0xfd, 0xb7, 0x59, 0x5b,
+	0xff, 0x8b, 0x7f, 0xfd, 0xcf, 0x5f, 0x17, 0x56, 0x48, 0xa3, 0x6d, 0x27, 0x78, 0xf4, 0xa1, 0xb1,
+	0x47, 0xf9, 0x43, 0xb8, 0x98, 0xa7, 0xfc, 0x47, 0x72, 0x6e, 0xbe, 0xa7, 0xfd, 0x0c, 0x99, 0xae,
+	0x92, 0x65, 0xc6, 0x34, 0xe6, 0xd2, 0x03, 0xd8, 0xa3, 0xa1, 0x6c, 0x37, 0x73, 0x79, 0xca, 0x59,
+	0x46, 0xe6, 0x2f, 0x7c, 0x6d, 0x0d, 0x39, 0x2e, 0x93, 0x3a, 0xe3, 0x28, 0x39, 0xfc, 0x11, 0x1e,
+	0xbc, 0x3f, 0xe3, 0x03, 0x31, 0xb2, 0x1e, 0xfd, 0x69, 0x94, 0x98, 0x8f, 0xa9, 0xea, 0xe2, 0x7f,
+	0x81, 0xb4, 0xab, 0xc8, 0xf5, 0x67, 0x64, 0xad, 0x6d, 0xc7, 0x7c, 0xda, 0xef, 0x98, 0x63, 0xbf,
+	0x27, 0x16, 0x7a, 0x64, 0xf4, 0x0f, 0xd4, 0xce, 0x59, 0x7f, 0xf6, 0x01, 0x31, 0x73, 0xff, 0x58,
+	0x69, 0xb7, 0x90, 0xf9, 0x75, 0xf2, 0x31, 0x67, 0x9e, 0x61, 0x23, 0xa5, 0x78, 0xb0, 0x92, 0x9e,
+	0xeb, 0x91, 0x8f, 0x05, 0xa7, 0xdc, 0x71, 0x9f, 0xba, 0x9e, 0xe7, 0xbe, 0xda, 0x5d, 0x94, 0xf5,
+	0x09, 0xb9, 0xc9, 0x64, 0x25, 0x76, 0x09, 0x29, 0xed, 0x77, 0x72, 0x5e, 0xf7, 0x9e, 0xbc, 0x85,
+	0x66, 0x76, 0xfe, 0x47, 0xae, 0xcf, 0x89, 0x4c, 0x0d, 0x06, 0x17, 0x08, 0xfd, 0x02, 0x85, 0xde,
+	0x26, 0x9f, 0xb6, 0xed, 0xcc, 0xbe, 0xf6, 0x3b, 0x1e, 0xa5, 0x29, 0xc1, 0x14, 0x20, 0xee, 0x12,
+	0x49, 0x2b, 0x16, 0x99, 0x6e, 0x1c, 0xd5, 0x95, 0x74, 0xbb, 0x99, 0x16, 0x23, 0x90, 0xed, 0x77,
+	0xac, 0xf5, 0x7a, 0xdf, 0x7e, 0x97, 0x2d, 0xe6, 0xde, 0x93, 0xbf, 0x55, 0x60, 0x35, 0x53, 0xf5,
+	0x92, 0x6b, 0xb1, 0xb0, 0x9c, 0x6a, 0x58, 0xbd, 0xbe, 0x68, 0x59, 0x1c, 0xf4, 0xe7, 0xa8, 0xc1,
+	0x23, 0xf2, 0xb0, 0x6d, 0xa7, 0x29, 0xda, 0xef, 0x44, 0xd9, 0xfc, 0xbe, 0xfd, 0x0e, 0x2b, 0xcc,
+	0x5c, 0x8d, 0xfe, 0x4e, 0xc1, 0xfa, 0x22, 0x53, 0x13, 0x9f, 0xa7, 0xd4, 0xcd, 0xcc, 0xf2, 0x7c,
+	0x35, 0xad, 0x7d, 0x8f, 0x7a, 0x3d, 0x21, 0xdf, 0xb4, 0xed, 0x39, 0xa2, 0x8b, 0xa9, 0xf6, 0xf7,
+	0x0a, 0x56, 0x0f, 0xd9, 0x2a, 0x77, 0x4e, 0xb7, 0x74, 0xd9, 0xad, 0x6a, 0xf3, 0xcb, 0xd9, 0x02,
+	0x59, 0xdb, 0x41, 0xe5, 0xbe, 0x23, 0x4f, 0xda, 0xf6, 0x3c, 0x55, 0xac, 0x93, 0x2c, 0xd4, 0x73,
+	0xd5, 0xfb, 0xb5, 0x82, 0xce, 0x9a, 0xaa, 0xa4, 0xcf, 0xd3, 0xed, 0xc6, 0xfc, 0x72, 0xaa, 0x02,
+	0xd7, 0x7e, 0x1f, 0x15, 0x7b, 0x4c, 0x1e, 0xb5, 0xed, 0x0c, 0xc9, 0x05, 0xb5, 0xe2, 0xf9, 0x36,
+	0x9a, 0x13, 0x7f, 0x30, 0xdf, 0x66, 0xe7, 0xcf, 0xe9, 0x7c, 0x1b, 0xf1, 0xb0, 0xa1, 0x9e, 0x68,
+	0x44, 0xc9, 0x46, 0x7c, 0x86, 0xcc, 0x30, 0x40, 0x5d, 0xcd, 0xcc, 0x28, 0xb4, 0xcf, 0x91, 0xe1,
+	0x67, 0xe4, 0x16, 0xe6, 0x5a, 0x81, 0x6d, 0xbf, 0x5b, 0xa0, 0xfb, 0x19, 0x90, 0xf9, 0x8e, 0x97,
+	0x6c, 0xce, 0xcb, 0x4b, 0x0f, 0x0b, 0xd4, 0x9b, 0x1f, 0xa0, 0x10, 0x27, 0xbb, 0x8e, 0x8a, 0xb4,
+	0xb4, 0xb5, 0xb6, 0x3d, 0x47, 0xf4, 0x44, 0xb9, 0x47, 0x7e, 0xa5, 0x60, 0x7d, 0x96, 0xdb, 0x6d,
+	0x93, 0xcf, 0x16, 0xf2, 0x4f, 0x75, 0xff, 0xea, 0xed, 0x73, 0xe9, 0x84, 0x36, 0x22, 0xfb, 0x6a,
+	0x1b, 0x6d, 0x7b, 0x01, 0x29, 0xd3, 0xe9, 0x4f, 0x60, 0x35, 0xd3, 0xc4, 0x47, 0xb6, 0x9f, 0xff,
+	0xa3, 0x3b, 0xca, 0x13, 0x0b, 0xfa, 0x7e, 0x8d, 0xa0, 0xcc, 0xc6, 0x13, 0xe5, 0x9e, 0x56, 0x69,
+	0x07, 0x8c, 0x68, 0x46, 0x74, 0x58, 0xed, 0xce, 0xe8, 0xf0, 0x82, 0x12, 0xe6, 0x5f, 0x11, 0xc1,
+	0x53, 0xab, 0xb4, 0x29, 0x63, 0x33, 0x63, 0x5a, 0xff, 0x04, 0xb5, 0xa8, 0xd1, 0x21, 0x57, 0x16,
+	0x34, 0x52, 0x6a, 0x6b, 0x7e, 0x21, 0xfd, 0x3c, 0x6b, 0xd0, 0x0e, 0xe4, 0xda, 0x13, 0xe5, 0xde,
+	0x97, 0x0a, 0xf9, 0x43, 0x74, 0xc3, 0xa8, 0xb5, 0x49, 0xb8, 0x61, 0xa6, 0x39, 0x52, 0xd5, 0xbc,
+	0xa5, 0x3c, 0x17, 0x8f, 0x98, 0xb9, 0x18, 0xca, 0xa9, 0xea, 0x3c, 0xf9, 0xee, 0xe4, 0xb5, 0x1e,
+	0xea, 0x8d, 0x85, 0xeb, 0x42, 0xd4, 0x06, 0x8a, 0x5a, 0x23, 0x97, 0xda, 0x76, 0x86, 0x84, 0x8c,
+	0xe2, 0x87, 0x95, 0xd7, 0xe5, 0x73, 0x0f, 0x6b, 0xaa, 0xba, 0x8f, 0x4e, 0x94, 0x53, 0xc9, 0x6b,
+	0x9b, 0x28, 0x46, 0x25, 0xad, 0xe8, 0xa5, 0xe3, 0x04, 0xd1, 0x3b, 0x27, 0xa4, 0x25, 0x8a, 0xe9,
+	0xa4, 0xb4, 0xf9, 0x92, 0x3c, 0x92, 0x96, 0x53, 0x7e, 0xa7, 0xa5, 0x25, 0x08, 0x62, 0x69, 0x03,
+	0x58, 0x4e, 0x15, 0xcb, 0xe4, 0x6a, 0x46, 0x58, 0xb2, 0x84, 0x56, 0x37, 0x92, 0xb2, 0x52, 0xc5,
+	0xb1, 0xb4, 0x9f, 0xb6, 0x12, 0x89, 0xc2, 0xf5, 0x27, 0xca, 0xbd, 0x41, 0x19, 0xff, 0x6e, 0x7d,
+	0xf0, 0x3f, 0x03, 0x00, 0x9d, 0x1c, 0x2d, 0xa0, 0x34, 0x2a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3566,6 +4284,16 @@ type ApiServiceClient interface {
 	ExecTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TxReceipt, error)
 	// subscribe an event
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
+	// get the equivocation evidences of block producers
+	GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error)
+	// get the block producing statistics of block producers in the last 24 hours
+	GetProducerStats(ctx context.Context, in *GetProducerStatsRequest, opts ...grpc.CallOption) (*GetProducerStatsResponse, error)
+	// get the signed block header by number, for the light clients verifying the headers
+	GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest, opts ...grpc.CallOption) (*BlockHeaderResponse, error)
+	// get the digest of the state writes of the irreversible block by number, for comparing the state across the nodes
+	GetStateDigest(ctx context.Context, in *GetStateDigestRequest, opts ...grpc.CallOption) (*StateDigestResponse, error)
+	// get the proof of a key of the state at the block committing the state root, for the light clients verifying the state
+	GetStateProof(ctx context.Context, in *GetStateProofRequest, opts ...grpc.CallOption) (*StateProofResponse, error)
 }
 
 type apiServiceClient struct {
@@ -3770,6 +4498,51 @@ func (x *apiServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
 	return m, nil
 }
 
+func (c *apiServiceClient) GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error) {
+	out := new(GetEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetProducerStats(ctx context.Context, in *GetProducerStatsRequest, opts ...grpc.CallOption) (*GetProducerStatsResponse, error) {
+	out := new(GetProducerStatsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetProducerStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest, opts ...grpc.CallOption) (*BlockHeaderResponse, error) {
+	out := new(BlockHeaderResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockHeader", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetStateDigest(ctx context.Context, in *GetStateDigestRequest, opts ...grpc.CallOption) (*StateDigestResponse, error) {
+	out := new(StateDigestResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetStateDigest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetStateProof(ctx context.Context, in *GetStateProofRequest, opts ...grpc.CallOption) (*StateProofResponse, error) {
+	out := new(StateProofResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetStateProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApiServiceServer is the server API for ApiService service.
 type ApiServiceServer interface {
 	// get the node information
@@ -3810,6 +4583,16 @@ type ApiServiceServer interface {
 	ExecTransaction(context.Context, *TransactionRequest) (*TxReceipt, error)
 	// subscribe an event
 	Subscribe(*SubscribeRequest, ApiService_SubscribeServer) error
+	// get the equivocation evidences of block producers
+	GetEvidence(context.Context, *GetEvidenceRequest) (*GetEvidenceResponse, error)
+	// get the block producing statistics of block producers in the last 24 hours
+	GetProducerStats(context.Context, *GetProducerStatsRequest) (*GetProducerStatsResponse, error)
+	// get the signed block header by number, for the light clients verifying the headers
+	GetBlockHeader(context.Context, *GetBlockHeaderRequest) (*BlockHeaderResponse, error)
+	// get the digest of the state writes of the irreversible block by number, for comparing the state across the nodes
+	GetStateDigest(context.Context, *GetStateDigestRequest) (*StateDigestResponse, error)
+	// get the proof of a key of the state at the block committing the state root, for the light clients verifying the state
+	GetStateProof(context.Context, *GetStateProofRequest) (*StateProofResponse, error)
 }
 
 func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
@@ -4161,6 +4944,96 @@ func (x *apiServiceSubscribeServer) Send(m *SubscribeResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ApiService_GetEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetEvidence(ctx, req.(*GetEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetProducerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProducerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetProducerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetProducerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetProducerStats(ctx, req.(*GetProducerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockHeaderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockHeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockHeader(ctx, req.(*GetBlockHeaderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetStateDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetStateDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetStateDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetStateDigest(ctx, req.(*GetStateDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetStateProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetStateProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetStateProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetStateProof(ctx, req.(*GetStateProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApiService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.ApiService",
 	HandlerType: (*ApiServiceServer)(nil),
@@ -4237,6 +5110,26 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExecTransaction",
 			Handler:    _ApiService_ExecTransaction_Handler,
 		},
+		{
+			MethodName: "GetEvidence",
+			Handler:    _ApiService_GetEvidence_Handler,
+		},
+		{
+			MethodName: "GetProducerStats",
+			Handler:    _ApiService_GetProducerStats_Handler,
+		},
+		{
+			MethodName: "GetBlockHeader",
+			Handler:    _ApiService_GetBlockHeader_Handler,
+		},
+		{
+			MethodName: "GetStateDigest",
+			Handler:    _ApiService_GetStateDigest_Handler,
+		},
+		{
+			MethodName: "GetStateProof",
+			Handler:    _ApiService_GetStateProof_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
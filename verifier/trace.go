@@ -0,0 +1,112 @@
+package verifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/vm"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+// StateChange is a write to the state db.
+type StateChange struct {
+	Table   string `json:"table"`
+	Key     string `json:"key"`
+	Old     string `json:"old"`
+	New     string `json:"new"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// TxTrace is the result of re-executing a tx of a block.
+type TxTrace struct {
+	Receipt *tx.TxReceipt
+	Changes []*StateChange
+	// Err is the error stopping the tx from being executed, the receipt is nil then.
+	Err error
+}
+
+// recorder records the writes to the db.
+type recorder struct {
+	database.IMultiValue
+	changes []*StateChange
+}
+
+func (r *recorder) Put(table string, key string, value string) error {
+	old, _ := r.IMultiValue.Get(table, key)
+	r.changes = append(r.changes, &StateChange{Table: table, Key: key, Old: old, New: value})
+	return r.IMultiValue.Put(table, key, value)
+}
+
+func (r *recorder) Del(table string, key string) error {
+	old, _ := r.IMultiValue.Get(table, key)
+	r.changes = append(r.changes, &StateChange{Table: table, Key: key, Old: old, Deleted: true})
+	return r.IMultiValue.Del(table, key)
+}
+
+func (r *recorder) take() []*StateChange {
+	changes := r.changes
+	r.changes = nil
+	return changes
+}
+
+// Trace re-executes the txs of the block one by one on db, and returns the receipt and the state changes of each tx.
+// Unlike Verify, the txs are executed and committed whatever their receipts are, so the first divergent tx can be found.
+func (v *Verifier) Trace(blk *block.Block, db database.IMultiValue, c *Config) ([]*TxTrace, error) {
+	if len(blk.Txs) == 0 {
+		return nil, fmt.Errorf("block has no base tx")
+	}
+	if len(blk.Receipts) != len(blk.Txs) {
+		return nil, fmt.Errorf("block has %v txs but %v receipts", len(blk.Txs), len(blk.Receipts))
+	}
+	rec := &recorder{IMultiValue: db}
+	traces := make([]*TxTrace, 0, len(blk.Txs))
+
+	isolator := &vm.Isolator{}
+	r, err := blockBaseExec(blk, rec, isolator, blk.Txs[0], c)
+	traces = append(traces, &TxTrace{Receipt: r, Changes: rec.take(), Err: err})
+	if err != nil {
+		return traces, nil
+	}
+
+	engine := vm.Isolator{}
+	vi := database.NewVisitor(100, rec)
+	err = engine.Prepare(blk.Head, vi, getLogger(false))
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range blk.Txs[1:] {
+		traces = append(traces, traceTx(engine, t, blk.Receipts[i+1], c.TxTimeLimit, blk))
+		traces[len(traces)-1].Changes = rec.take()
+	}
+	return traces, nil
+}
+
+func traceTx(isolator vm.Isolator, t *tx.Tx, r *tx.TxReceipt, timeout time.Duration, blk *block.Block) *TxTrace {
+	if !t.IsCreatedBefore(blk.Head.Time) {
+		return &TxTrace{Err: ErrNotArrivedTx}
+	}
+	if t.IsExpired(blk.Head.Time) && !t.IsDefer() {
+		return &TxTrace{Err: ErrExpiredTx}
+	}
+	isolator.ClearTx()
+	to := timeout * 2
+	if r.Status.Code == tx.ErrorTimeout {
+		to = timeout / 2
+	}
+	err := isolator.PrepareTx(t, to)
+	if err != nil {
+		return &TxTrace{Err: err}
+	}
+	_, err = isolator.Run()
+	if err != nil {
+		return &TxTrace{Err: err}
+	}
+	receipt, err := isolator.PayCost()
+	if err != nil {
+		return &TxTrace{Err: err}
+	}
+	isolator.Commit()
+	return &TxTrace{Receipt: receipt}
+}
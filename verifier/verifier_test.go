@@ -60,3 +60,20 @@ func TestVerifier_Gen(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestVerifier_TraceMalformed(t *testing.T) {
+	v := Verifier{}
+	blk := &block.Block{
+		Head:     &block.BlockHead{Time: time.Now().UnixNano()},
+		Txs:      []*tx.Tx{},
+		Receipts: []*tx.TxReceipt{},
+	}
+	if _, err := v.Trace(blk, nil, &Config{}); err == nil {
+		t.Fatal("expect an error for the block without txs")
+	}
+	blk.Txs = []*tx.Tx{{}, {}}
+	blk.Receipts = []*tx.TxReceipt{{}}
+	if _, err := v.Trace(blk, nil, &Config{}); err == nil {
+		t.Fatal("expect an error for the block with missing receipts")
+	}
+}